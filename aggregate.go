@@ -0,0 +1,156 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Count returns the number of rows of parameter `o`'s table matching
+// Condition `where`, such as Where(map[string]interface{}{"status": "active"}).
+// A nil `where` counts every row.
+func (self *Cartographer) Count(db Querier, o interface{}, where *Condition) (int64, error) {
+	return self.CountContext(context.Background(), db, o, where)
+}
+
+// CountContext behaves as Count, counting the rows with context `ctx`.
+func (self *Cartographer) CountContext(ctx context.Context, db Querier, o interface{}, where *Condition) (count int64, err error) {
+	query, args, err := self.aggregateSQL(o, "COUNT(*)", where)
+
+	if nil != err {
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+
+	if nil != err {
+		return
+	}
+
+	defer rows.Close()
+
+	if rows.Next() {
+		err = rows.Scan(&count)
+	}
+
+	if nil == err {
+		err = rows.Err()
+	}
+
+	return
+}
+
+// Exists returns true if any row of parameter `o`'s table matches `where`,
+// as Count matches them, selecting no more than a single row.
+func (self *Cartographer) Exists(db Querier, o interface{}, where *Condition) (bool, error) {
+	return self.ExistsContext(context.Background(), db, o, where)
+}
+
+// ExistsContext behaves as Exists, selecting the row with context `ctx`.
+func (self *Cartographer) ExistsContext(ctx context.Context, db Querier, o interface{}, where *Condition) (exists bool, err error) {
+	query, args, err := self.aggregateSQL(o, "1", where)
+
+	if nil != err {
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, query+" LIMIT 1", args...)
+
+	if nil != err {
+		return
+	}
+
+	defer rows.Close()
+
+	exists = rows.Next()
+	err = rows.Err()
+
+	return
+}
+
+// aggregateSQL returns a statement selecting `selection` from parameter
+// `o`'s table for the rows matching `where`, along with its arguments.
+func (self *Cartographer) aggregateSQL(o interface{}, selection string, where *Condition) (query string, args []interface{}, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	table, err := self.TableFor(o)
+
+	if nil != err {
+		return
+	}
+
+	query = fmt.Sprintf("SELECT %s FROM %s", selection, table)
+
+	condition, args, err := self.renderCondition(typ, where, nil)
+
+	if nil != err {
+		return
+	}
+
+	if 0 != len(condition) {
+		query += " WHERE " + condition
+	}
+
+	return
+}
+
+// whereEqual returns a condition matching the columns of `where`, sorted,
+// to their values, along with `args` followed by the values, or an error
+// should a column not be mapped by type `typ`. Nil values match NULL,
+// slices other than []byte match any of their elements.
+func (self *Cartographer) whereEqual(typ reflect.Type, where map[string]interface{}, args []interface{}) (condition string, bound []interface{}, err error) {
+	var columns, conditions []string
+
+	for column, _ := range where {
+		if _, ok := self.byColumn[typ][column]; !ok {
+			err = errors.New(fmt.Sprintf("No field for column %s on %v", column, typ))
+			return
+		}
+
+		columns = append(columns, column)
+	}
+
+	sort.Strings(columns)
+
+	bound = args
+
+	for _, column := range columns {
+		value := where[column]
+
+		if nil == value {
+			conditions = append(conditions, column+" IS NULL")
+			continue
+		}
+
+		if list := reflect.ValueOf(value); reflect.Slice == list.Kind() && reflect.Uint8 != list.Type().Elem().Kind() {
+			if 0 == list.Len() {
+				conditions = append(conditions, "1 = 0") // Nothing is IN an empty list.
+				continue
+			}
+
+			var placeholders []string
+
+			for index := 0; index < list.Len(); index++ {
+				bound = append(bound, list.Index(index).Interface())
+				placeholders = append(placeholders, self.bind(len(bound)))
+			}
+
+			conditions = append(conditions, column+" IN ("+strings.Join(placeholders, ", ")+")")
+			continue
+		}
+
+		bound = append(bound, value)
+		conditions = append(conditions, column+" = "+self.bind(len(bound)))
+	}
+
+	condition = strings.Join(conditions, " AND ")
+
+	return
+}
@@ -0,0 +1,45 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT COUNT(*) FROM invoice WHERE customer = ? AND total IS NULL": {
+			columns: []string{"count"},
+			rows:    [][]driver.Value{{int64(3)}},
+		},
+	})
+
+	count, err := Initialize("db").Count(db, invoice{}, Where(map[string]interface{}{"total": nil, "customer": "ada"}))
+
+	if nil != err {
+		t.Fatalf("Basic Count test returned an unexpected error: %v", err)
+	}
+
+	if 3 != count {
+		t.Errorf("Basic Count test expected 3, received %d", count)
+	}
+
+	if _, err = Initialize("db").Count(db, invoice{}, Where(map[string]interface{}{"missing": 1})); nil == err {
+		t.Error("Basic Count test expected an error counting by an unmapped column")
+	}
+}
+
+func TestExists(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT 1 FROM invoice WHERE id = ? LIMIT 1": {columns: []string{"1"}, rows: [][]driver.Value{{int64(1)}}},
+	})
+
+	c := Initialize("db")
+
+	if exists, err := c.Exists(db, invoice{}, Where(map[string]interface{}{"id": 1})); nil != err || !exists {
+		t.Errorf("Basic Exists test expected row to exist, received %v, %v", exists, err)
+	}
+
+	if exists, err := c.Exists(db, invoice{}, nil); nil != err || exists {
+		t.Errorf("Basic Exists test expected no rows, received %v, %v", exists, err)
+	}
+}
@@ -0,0 +1,118 @@
+// Package audit builds structured audit trails of changes made to structs
+// mapped by a cartographer.Cartographer, writing them to pluggable sinks.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// Entry records a single column changed by an actor.
+type Entry struct {
+	Actor      string                 `json:"actor"`       // Who made the change.
+	Table      string                 `json:"table"`       // Table the changed row belongs to.
+	PrimaryKey map[string]interface{} `json:"primary_key"` // Primary key columns of the changed row to their values.
+	Column     string                 `json:"column"`      // Column changed.
+	Old        interface{}            `json:"old"`         // Value before the change.
+	New        interface{}            `json:"new"`         // Value after the change.
+	Timestamp  time.Time              `json:"timestamp"`   // When the change was audited.
+}
+
+// Sink receives the entries produced by an Auditor.
+type Sink interface {
+	Write(entries []Entry) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(entries []Entry) error
+
+// Write calls the SinkFunc itself.
+func (self SinkFunc) Write(entries []Entry) error {
+	return self(entries)
+}
+
+// JSONSink returns a Sink writing each entry to writer `w` as a line of JSON.
+func JSONSink(w io.Writer) Sink {
+	encoder := json.NewEncoder(w)
+
+	return SinkFunc(func(entries []Entry) error {
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); nil != err {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Auditor produces audit entries from the changes between two states of
+// a struct, using the metadata cached by its Cartographer.
+type Auditor struct {
+	cartographer *cartographer.Cartographer // Cartographer used for diffing and metadata.
+	sinks        []Sink                     // Sinks entries are written to.
+	now          func() time.Time           // Clock used to timestamp entries.
+}
+
+// New returns a pointer to a new Auditor using Cartographer `c`, writing
+// entries to each of the `sinks`.
+func New(c *cartographer.Cartographer, sinks ...Sink) *Auditor {
+	return &Auditor{c, sinks, time.Now}
+}
+
+// AddSink adds Sink `sink` to those entries are written to.
+func (self *Auditor) AddSink(sink Sink) {
+	self.sinks = append(self.sinks, sink)
+}
+
+// SetClock replaces the clock entries are timestamped with, such as for tests.
+func (self *Auditor) SetClock(now func() time.Time) {
+	self.now = now
+}
+
+// AuditFor returns an entry for each column changed between structs `before`
+// and `after` by `actor`, writing them to each sink, or an error if the structs
+// can not be diffed, declare no primary key, or a sink fails.
+func (self *Auditor) AuditFor(actor string, before, after interface{}) (entries []Entry, err error) {
+	changes, err := self.cartographer.DiffFor(before, after)
+
+	if nil != err || 0 == len(changes) {
+		return
+	}
+
+	table, err := self.cartographer.TableFor(after)
+
+	if nil != err {
+		return
+	}
+
+	values, err := self.cartographer.PrimaryKeyValuesFor(before)
+
+	if nil != err {
+		return
+	}
+
+	var (
+		timestamp = self.now()
+		key       = make(map[string]interface{})
+	)
+
+	for column, value := range values {
+		key[column.(string)] = value
+	}
+
+	for _, change := range changes {
+		entries = append(entries, Entry{actor, table, key, change.Column, change.Old, change.New, timestamp})
+	}
+
+	for _, sink := range self.sinks {
+		if err = sink.Write(entries); nil != err {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+type account struct {
+	Id    int    `db:"id,pk"`
+	Email string `db:"email"`
+}
+
+func TestAuditFor(t *testing.T) {
+	var (
+		buffer  bytes.Buffer
+		now     = time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)
+		auditor = New(cartographer.Initialize("db"), JSONSink(&buffer))
+	)
+
+	auditor.SetClock(func() time.Time { return now })
+
+	entries, err := auditor.AuditFor("admin", account{1, "before@example.com"}, account{1, "after@example.com"})
+
+	if nil != err || 1 != len(entries) {
+		t.Fatalf("Basic AuditFor test returned an unexpected results: %v, %v", entries, err)
+	}
+
+	entry := entries[0]
+
+	if "admin" != entry.Actor || "account" != entry.Table || "email" != entry.Column || 1 != entry.PrimaryKey["id"] || !now.Equal(entry.Timestamp) {
+		t.Errorf("Basic AuditFor test returned an unexpected entry: %v", entry)
+	}
+
+	if !strings.Contains(buffer.String(), `"primary_key":{"id":1}`) {
+		t.Errorf("Basic AuditFor test wrote an unexpected entry: %s", buffer.String())
+	}
+}
@@ -1,10 +1,15 @@
 package cartographer
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type ScannableRows interface {
@@ -15,16 +20,60 @@ type ScannableRows interface {
 
 type Hook func(reflect.Value) error
 
+// ErrMultipleRows is returned by Sync when more than a single row is read.
+var ErrMultipleRows = errors.New("Sync expected a single row, received several")
+
+// errRowSkipped is returned by hydrate for rows failing conversion once
+// they are passed to the call's RowErrorHandler, so that they are skipped.
+var errRowSkipped = errors.New("Row skipped")
+
 type Cartographer struct {
-	fieldsToColumns map[reflect.Type]map[interface{}]interface{} // Map from an reflect.Type's fields to database columns.
-	columnsToFields map[reflect.Type]map[interface{}]interface{} // Map from an reflect.Type's database columns to fields.
-	typeCache       map[reflect.Type]bool                        // Is the reflect.Type cached?
-	structTag       string                                       // Struct field tag for field to column mapping.
+	*mappings                                                 // Mappings discovered under the active struct tag.
+	namespaces       map[string]*mappings                     // Mappings discovered under each struct tag used.
+	sharedMappings   bool                                     // Are the namespaces shared with a Cartographer derived with With, or derived from?
+	hooks            map[reflect.Type][]registered[RowHook]   // Hooks registered to run for every Map or Sync of an reflect.Type.
+	writeHooks       map[reflect.Type][]registered[WriteHook] // Hooks registered to transform an reflect.Type's value maps.
+	syncHooks        map[reflect.Type][]registered[SyncHook]  // Hooks registered to run once an reflect.Type is synced.
+	transforms       map[reflect.Type]map[string][]Transform  // Transforms registered for an reflect.Type's columns.
+	tables           map[reflect.Type]string                  // Database tables registered for an reflect.Type.
+	listeners        map[EventKind][]subscriber               // Listeners subscribed to each kind of Event.
+	subscriptions    Subscription                             // Last Subscription handed out by Subscribe.
+	structTag        string                                   // Struct field tag for field to column mapping.
+	namer            Namer                                    // Names the columns of untagged fields, if set.
+	comparator       Comparator                               // Comparator used when diffing, reflect.DeepEqual if nil.
+	comparators      map[reflect.Type]Comparator              // Comparators registered for diffing values of a reflect.Type.
+	writeConverters  map[reflect.Type]Converter               // Converters registered for writing values of a reflect.Type.
+	strictColumns    bool                                     // Error on columns not mapped by the type, rather than skipping them?
+	syncMultipleRows bool                                     // Allow Sync to read several rows, the last taking precedence?
+	jsonCollections  bool                                     // Store slice and map fields as JSON?
+	tracked          map[interface{}]*Snapshot                // Snapshots of tracked pointers to structs.
+	autoTrack        bool                                     // Track every struct hydrated by Map or synced by Sync?
+	placeholder      Placeholder                              // Placeholder style of generated SQL, Question if nil.
+	clock            func() time.Time                         // Clock stamping `autocreate` and `autoupdate` columns, time.Now if nil.
+	lazyQuerier      Querier                                  // Querier lazy relations are loaded with, if lazy loading is enabled.
+	inheritance      map[reflect.Type]*inheritance            // Variants registered for an reflect.Type sharing its table.
+	variants         map[reflect.Type]variant                 // Base types registered for variant reflect.Types.
+	timeLayouts      []string                                 // Layouts textual values of time.Time fields are parsed with.
+	typeTags         map[reflect.Type]string                  // Struct tags registered for an reflect.Type in place of the active one.
+	validator        Validator                                // Validator run against hydrated structs and written values, if set.
+	validations      map[reflect.Type][]Validation            // Validations registered for an reflect.Type.
+	notNull          map[reflect.Type]map[string]bool         // Columns of an reflect.Type known to be NOT NULL.
+	validation       bool                                     // Validate hydrated structs against their `validate` tags?
+	cacheSize        int                                      // Most types cached per namespace, unbounded if not positive.
+	logger           Logger                                   // Logger diagnostics are written to, if set.
+	metrics          MetricsSink                              // MetricsSink measurements are recorded to, NopMetrics by default.
+	slowThreshold    time.Duration                            // Duration beyond which calls to Map or Sync are reported to `slowMapping`.
+	slowMapping      SlowMapping                              // Callback reporting slow calls to Map or Sync, if set.
 }
 
 // DiscoverType the reflect.Type of the `o` parameter passed, caching
 // its fields and database columns taken from the fields `db` tag, or an
-// error if the reflect.Type's kind is not a struct.
+// error if the reflect.Type's kind is not a struct. Any options following
+// the column name in the tag, such as `db:"email,unique"`, are cached
+// alongside the field, as are relations declared through `rel` tags and
+// validation rules declared through `validate` tags. The tagged fields
+// of untagged embedded structs are promoted, outer fields hiding promoted
+// fields sharing their name or column.
 func (self *Cartographer) DiscoverType(o interface{}) (typ reflect.Type, err error) {
 	typ = reflect.TypeOf(o)
 
@@ -38,34 +87,216 @@ func (self *Cartographer) DiscoverType(o interface{}) (typ reflect.Type, err err
 		return
 	}
 
-	if _, cached := self.typeCache[typ]; !cached {
-		self.fieldsToColumns[typ] = make(map[interface{}]interface{})
-		self.columnsToFields[typ] = make(map[interface{}]interface{})
-		self.typeCache[typ] = true
+	var (
+		discovered bool
+		evicted    []reflect.Type
+	)
+
+	if 0 < self.cacheSize {
+		self.lock.Lock()
+
+		if discovered, err = self.discover(typ); nil == err {
+			self.touch(typ)
+			evicted = self.trim(self.cacheSize)
+		}
+
+		self.lock.Unlock()
+	} else {
+		discovered, err = self.discover(typ)
+	}
+
+	if nil != err {
+		return
+	}
+
+	if discovered {
+		self.metrics.CacheMiss(typ)
+		self.publish(Event{Kind: OnTypeDiscovered, Type: typ})
+	} else {
+		self.metrics.CacheHit(typ)
+	}
+
+	self.evicted(evicted)
+
+	return
+}
+
+// discover caches the fields, columns and relations of struct type `typ`,
+// reporting whether it was not yet cached, or returns an error should a
+// `rel` tag of the type be malformed.
+func (self *Cartographer) discover(typ reflect.Type) (discovered bool, err error) {
+	if _, cached := self.typeCache[typ]; cached {
+		return
+	}
+
+	self.fieldsToColumns[typ] = make(map[interface{}]interface{})
+	self.columnsToFields[typ] = make(map[interface{}]interface{})
+	self.byColumn[typ] = make(map[interface{}]*mappedField)
+	self.typeCache[typ] = true
+	atomic.AddInt64(&self.cached, 1)
+
+	var mapped = self.collectFields(typ, nil, map[reflect.Type]bool{typ: true})
+
+	for position, field := range mapped {
+		if shadowed(position, mapped) {
+			continue
+		}
+
+		self.columnsToFields[typ][field.column] = field.name
+		self.fieldsToColumns[typ][field.name] = field.column
+		self.byColumn[typ][field.column] = field
+		self.fields[typ] = append(self.fields[typ], field)
+	}
+
+	var numberOfFields = typ.NumField()
+
+	for i := 0; i < numberOfFields; i++ {
+		var field = typ.Field(i)
+
+		if tag, ok := field.Tag.Lookup(RelationTag); ok {
+			var relation Relation
+
+			if relation, err = parseRelation(field, tag); nil != err {
+				self.forget(typ)
+				return
+			}
+
+			self.relations[typ] = append(self.relations[typ], relation)
+		}
+	}
+
+	self.debug(context.Background(), "cartographer: type discovered", "type", typ.String(), "columns", len(self.fields[typ]))
 
-		var numberOfFields = typ.NumField()
+	return true, nil
+}
 
-		for i := 0; i < numberOfFields; i++ {
-			var (
-				field  = typ.Field(i)
-				name   = field.Name
-				column = field.Tag.Get(self.structTag)
-			)
+// collectFields returns the tagged fields of struct type `typ`, whose
+// index path within the type being discovered is prefixed by `index`,
+// in declaration order. The fields of untagged embedded structs, or
+// pointers to structs, are promoted in place of the embedded field,
+// unless the embedded type has already been `visited`. Untagged fields
+// are named by the Namer set with SetNamer, if any, while fields tagged
+// `-` are never mapped.
+func (self *Cartographer) collectFields(typ reflect.Type, index []int, visited map[reflect.Type]bool) (fields []*mappedField) {
+	var (
+		numberOfFields = typ.NumField()
+		tag            = self.tagFor(typ)
+	)
+
+	for i := 0; i < numberOfFields; i++ {
+		var (
+			field           = typ.Field(i)
+			path            = append(append([]int(nil), index...), i)
+			column, options = parseTag(field.Tag.Get(tag))
+		)
+
+		if "-" == column {
+			continue // The field is explicitly not mapped.
+		}
 
-			if 0 != len(column) {
-				self.columnsToFields[typ][column] = name
-				self.fieldsToColumns[typ][name] = column
+		_, tagged := field.Tag.Lookup(tag)
+
+		if embedded := indirectType(field.Type); !tagged && field.Anonymous && reflect.Struct == embedded.Kind() {
+			if !visited[embedded] {
+				visited[embedded] = true
+				fields = append(fields, self.collectFields(embedded, path, visited)...)
 			}
 
+			continue
+		}
+
+		if _, related := field.Tag.Lookup(RelationTag); 0 == len(column) && nil != self.namer && field.IsExported() && !related {
+			column = self.namer(field.Name)
+		}
+
+		if 0 != len(column) {
+			fields = append(fields, &mappedField{field.Name, column, options, path, field.Type, parseRules(field.Tag.Get(ValidationTag))})
 		}
 	}
 
 	return
 }
 
+// shadowed reports whether the field at `position` within `fields` is
+// hidden by another sharing its name or column at a shallower depth, or
+// at the same depth but declared earlier, much as Go hides promoted fields.
+func shadowed(position int, fields []*mappedField) bool {
+	field := fields[position]
+
+	for index, other := range fields {
+		if index == position || (field.name != other.name && field.column != other.column) {
+			continue
+		}
+
+		if len(other.index) < len(field.index) || (len(other.index) == len(field.index) && index < position) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// indirectType returns the type pointed to by `typ` should it be a pointer, else `typ`.
+func indirectType(typ reflect.Type) reflect.Type {
+	if reflect.Ptr == typ.Kind() {
+		return typ.Elem()
+	}
+
+	return typ
+}
+
+// fieldByIndex returns the field of struct value `item` at index path
+// `index`, allocating nil embedded pointers along the way if `allocate`
+// is true. Should `allocate` be false and a nil pointer be met, an
+// invalid reflect.Value is returned.
+func fieldByIndex(item reflect.Value, index []int, allocate bool) reflect.Value {
+	for position, i := range index {
+		if 0 != position {
+			if reflect.Ptr == item.Kind() {
+				if item.IsNil() {
+					if !allocate || !item.CanSet() {
+						return reflect.Value{}
+					}
+
+					item.Set(reflect.New(item.Type().Elem()))
+				}
+
+				item = item.Elem()
+			}
+		}
+
+		item = item.Field(i)
+	}
+
+	return item
+}
+
+// fieldValue returns the value of the field `mapped` of struct value `item`,
+// or the zero value of the field's type should it be reached through a nil
+// embedded pointer.
+func fieldValue(item reflect.Value, mapped *mappedField) interface{} {
+	if field := fieldByIndex(item, mapped.index, false); field.IsValid() {
+		return field.Interface()
+	}
+
+	return reflect.Zero(mapped.typ).Interface()
+}
+
+// forget removes the reflect.Type `typ` from the cache.
+func (self *Cartographer) forget(typ reflect.Type) {
+	self.evict(typ)
+	delete(self.transforms, typ)
+}
+
 // CreateReplica uses the reflect package to create a replica of the interface passed,
 // returning a reflect.Value, or an error if `o` is not a struct.
 func (self *Cartographer) CreateReplica(o interface{}, hooks ...Hook) (replica reflect.Value, err error) {
+	return self.createReplica(context.Background(), o, contextHooks(hooks))
+}
+
+// createReplica creates a replica of the interface passed, running
+// each of the `hooks` with context `ctx` against it.
+func (self *Cartographer) createReplica(ctx context.Context, o interface{}, hooks []ContextHook) (replica reflect.Value, err error) {
 	typ, err := self.DiscoverType(o)
 
 	if nil != err {
@@ -75,7 +306,8 @@ func (self *Cartographer) CreateReplica(o interface{}, hooks ...Hook) (replica r
 	replica = reflect.New(typ)
 
 	for _, hook := range hooks {
-		if err = hook(replica); nil != err {
+		if err = protect(func() error { return hook(ctx, replica) }, "in hook creating replica of %v", typ); nil != err {
+			self.metricsFor(ctx).HookFailed(typ, "")
 			return // Hook returned an error, return it to caller to deal with.
 		}
 	}
@@ -154,7 +386,9 @@ func (self *Cartographer) ColumnForField(o interface{}, field interface{}) (inte
 }
 
 // FieldValueMapFor returns a map of parameter `o`'s fields to their values, or an
-// error if `o` is not a struct.
+// error if `o` is not a struct. Hooks registered with RegisterWriteHook
+// transform the map before it is returned, after which fields tagged with
+// the `omitempty` option holding their zero value are omitted.
 func (self *Cartographer) FieldValueMapFor(o interface{}) (values map[interface{}]interface{}, err error) {
 	typ, err := self.DiscoverType(o)
 
@@ -162,16 +396,32 @@ func (self *Cartographer) FieldValueMapFor(o interface{}) (values map[interface{
 		return
 	}
 
+	return self.fieldValues(typ, reflect.Indirect(reflect.ValueOf(o)), true)
+}
+
+// fieldValues returns a map of struct `item`'s fields to their values,
+// transformed by the write hooks registered for type `typ`, omitting
+// zero valued `omitempty` fields if `omitEmpty` is true.
+func (self *Cartographer) fieldValues(typ reflect.Type, item reflect.Value, omitEmpty bool) (values map[interface{}]interface{}, err error) {
 	values = make(map[interface{}]interface{})
 
-	item := reflect.ValueOf(o)
+	for _, mapped := range self.fields[typ] {
+		values[mapped.name] = fieldValue(item, mapped)
+	}
 
-	if reflect.Ptr == item.Kind() {
-		item = item.Elem()
+	for _, hook := range self.writeHooks[typ] {
+		if err = protect(func() error { return hook.hook(item, values) }, "in write hook %q of %v", hook.name, typ); nil != err {
+			self.metrics.HookFailed(typ, hook.name)
+			return // Hook returned an error, return it to caller to deal with.
+		}
 	}
 
-	for key, _ := range self.fieldsToColumns[typ] {
-		values[key] = item.FieldByName(key.(string)).Interface()
+	if omitEmpty {
+		for _, mapped := range self.fields[typ] {
+			if mapped.options.Has("omitempty") && isZero(values[mapped.name]) {
+				delete(values, mapped.name)
+			}
+		}
 	}
 
 	return
@@ -180,10 +430,21 @@ func (self *Cartographer) FieldValueMapFor(o interface{}) (values map[interface{
 // ModifiedColumnsValuesMapFor accepts a map of strings to interfaces
 // intedned to be a snap shot of the object `o` at an early time/previous state,
 // returning a map of the column name for the modified field to its value,
-// or an error if one occurs.
+// or an error if one occurs. The current values are taken as FieldValueMapFor
+// takes them, so are transformed by any hooks registered with RegisterWriteHook,
+// though `omitempty` fields are never omitted; such fields missing from the
+// snap shot are taken to have held their zero value.
+// Values are compared with the Comparator registered for their type with
+// RegisterComparator, the Comparator set with SetComparator, or
+// reflect.DeepEqual. See Snapshot for recording the previous state of `o`.
 func (self *Cartographer) ModifiedColumnsValuesMapFor(i map[interface{}]interface{}, o interface{}) (values map[interface{}]interface{}, err error) {
 	typ, err := self.DiscoverType(o)
-	n, _ := self.FieldValueMapFor(o)
+
+	if nil != err {
+		return
+	}
+
+	n, err := self.fieldValues(typ, reflect.Indirect(reflect.ValueOf(o)), false)
 
 	if nil != err {
 		return
@@ -192,7 +453,13 @@ func (self *Cartographer) ModifiedColumnsValuesMapFor(i map[interface{}]interfac
 	values = make(map[interface{}]interface{})
 
 	for key, value := range n {
-		if n[key] != i[key] {
+		previous, ok := i[key]
+
+		if mapped := self.fieldNamed(typ, key); !ok && nil != mapped && mapped.options.Has("omitempty") {
+			previous = reflect.Zero(mapped.typ).Interface()
+		}
+
+		if !self.equal(previous, value) {
 			values[self.fieldsToColumns[typ][key]] = value
 		}
 	}
@@ -200,109 +467,341 @@ func (self *Cartographer) ModifiedColumnsValuesMapFor(i map[interface{}]interfac
 	return
 }
 
+// fieldNamed returns the mapped field of type `typ` named `name`, or nil.
+func (self *Cartographer) fieldNamed(typ reflect.Type, name interface{}) *mappedField {
+	for _, mapped := range self.fields[typ] {
+		if name == mapped.name {
+			return mapped
+		}
+	}
+
+	return nil
+}
+
 // Sync is a helper method that is inteded to be used typically after
 // an insert statement has been executed and the tables primary key
 // that's potentially auto incremented returned, returning the synced
-// objected or an error.
+// objected or an error. Sync expects a single row, returning
+// ErrMultipleRows should a second be read, unless multiple rows
+// are allowed with SetSyncMultipleRows.
 func (self *Cartographer) Sync(rows ScannableRows, o interface{}, hooks ...Hook) (err error) {
-	typ, err := self.DiscoverType(o)
+	return self.SyncWith(rows, o, WithHooks(hooks...))
+}
+
+// SyncWith behaves as Sync, each of the `opts` overriding the
+// Cartographer's defaults for this call alone, see CallOption.
+func (self *Cartographer) SyncWith(rows ScannableRows, o interface{}, opts ...CallOption) (err error) {
+	call := newCall(opts)
+	return self.forCall(call).sync(context.Background(), rows, o, call.hooks, call)
+}
+
+// Map takes any type that implements the ScannableRows interface,
+// calling methods Columns, Next, and Scan. Map's parameter `o`
+// must have a reflect.Kind of struct. Map attempts to read and
+// cache tags on struct fields labled with that string passed to the
+// Initialize function, which is intended to be a map to the field's
+// corresponding database column. An array of pointers to the `o`
+// struct passed with it's members populated based on the names
+// of the columns associated with the rows is returned.  Any `hook`
+// passed to map are given a replica generated by reflect.New of
+// the `o` parameter, a list of it's fields, and their initial values.
+// Replicas implementing BeforeScanner or AfterScanner have their
+// callbacks invoked before and after they are populated, followed by
+// any hooks registered with RegisterHook.
+func (self *Cartographer) Map(rows ScannableRows, o interface{}, hooks ...Hook) (results []interface{}, err error) {
+	return self.MapWith(rows, o, WithHooks(hooks...))
+}
+
+// MapWith behaves as Map, each of the `opts` overriding the Cartographer's
+// defaults for this call alone, see CallOption; Hooks are themselves
+// CallOptions and may be passed alongside them.
+func (self *Cartographer) MapWith(rows ScannableRows, o interface{}, opts ...CallOption) (results []interface{}, err error) {
+	call := newCall(opts)
+	return self.forCall(call).mapContext(context.Background(), rows, o, call.hooks, call)
+}
+
+// MapContext behaves as Map, passing context `ctx` to each of the hooks
+// given among `opts` so they may read request-scoped values while hydrating
+// each row. Mapping stops, returning the context's error, if `ctx` is done
+// before a row is read.
+func (self *Cartographer) MapContext(ctx context.Context, rows ScannableRows, o interface{}, opts ...CallOption) (results []interface{}, err error) {
+	call := newCall(opts)
+	return self.forCall(call).mapContext(ctx, rows, o, call.hooks, call)
+}
+
+// mapContext behaves as MapContext, applying the overrides of `call`.
+func (self *Cartographer) mapContext(ctx context.Context, rows ScannableRows, o interface{}, hooks []ContextHook, call *call) (results []interface{}, err error) {
+	defer self.hold()()
+
+	var (
+		typ   = indirectType(reflect.TypeOf(o))
+		start = time.Now()
+	)
+
+	defer func() {
+		self.measure(ctx, typ, "map", len(results), start)
+	}()
+
+	columns, err := rows.Columns() // Columns returned for the results returned.
 
 	if nil != err {
-		return
+		return results, err
 	}
 
-	object := reflect.ValueOf(o)
+	columns = call.alias(columns)
 
-	if reflect.Ptr != object.Kind() {
-		err = errors.New("Sync expected a pointer to be passed for manipulation")
-		return
+	var invalid RowErrors // Rows failing validation, should mapping continue past them.
+
+	for row := 0; rows.Next(); row++ {
+		if err = ctx.Err(); nil != err {
+			return results, err
+		}
+
+		values, err := populatedRowValues(rows, len(columns))
+
+		if nil != err {
+			return results, err
+		}
+
+		replica, err := self.mapRow(ctx, self.variantFor(o, columns, values), hooks, columns, values, row, call)
+
+		if errRowSkipped == err {
+			continue
+		}
+
+		if failed, ok := err.(*RowError); ok && nil != call && call.continueOnInvalid {
+			invalid = append(invalid, failed)
+			continue
+		}
+
+		if nil != err {
+			return results, err
+		}
+
+		// Finally, append the replica of the passed item.
+		results = append(results, replica.Interface())
 	}
 
-	element := object.Elem()
-	columns, err := rows.Columns()
+	if 0 != len(invalid) {
+		err = invalid
+	}
 
-	if nil != err {
+	self.debug(ctx, "cartographer: rows mapped", "type", typ.String(), "rows", len(results), "invalid", len(invalid))
+
+	return
+}
+
+// mapRow hydrates a replica of `o` from the scanned `values` of row `row`,
+// as Map hydrates each row, running each of the `hooks` against it first.
+func (self *Cartographer) mapRow(ctx context.Context, o interface{}, hooks []ContextHook, columns []string, values []interface{}, row int, call *call) (replica reflect.Value, err error) {
+	if replica, err = self.createReplica(ctx, o, hooks); nil != err {
 		return
 	}
 
-	// FIXME: The logic within this loop is similar enough with Maps's to be refactored into a method.
-	for rows.Next() {
-		values, err := populatedRowValues(rows, len(columns))
+	if err = self.hydrate(ctx, replica, columns, values, row, call); nil != err {
+		return
+	}
 
-		if nil != err {
-			return err
+	if self.autoTrack || call.tracks() {
+		if err = self.Track(replica.Interface()); nil != err {
+			return
 		}
+	}
 
-		for index, _ := range values {
-			name := self.columnsToFields[typ][columns[index]] // The name of the field.
-			field := element.FieldByName(name.(string))       // The field the value belongs to.
-			err = setFieldValue(field, (*values[index].(*interface{})))
+	self.publish(Event{Kind: OnRowMapped, Type: replica.Type().Elem(), Value: replica})
 
-			if nil != err {
-				return errors.New(fmt.Sprintf("%s for %s", err.Error(), columns[index]))
-			}
+	return
+}
+
+// call holds options overriding the Cartographer's behavior for a single
+// Map or Sync call. A nil *call applies the Cartographer's defaults.
+type call struct {
+	columns           map[string]bool   // Columns to populate, every column if nil.
+	report            *SyncReport       // Report of the columns populated, if wanted.
+	strict            *bool             // Strict columns, overriding SetStrictColumns if set.
+	nullsAsZero       bool              // Set fields of NULL columns to their zero value, rather than leaving them untouched?
+	hooks             []ContextHook     // Hooks passed as options.
+	namespace         string            // Struct tag to map through, the active one if empty.
+	uncached          bool              // Discover types afresh, without caching them?
+	aliases           map[string]string // Columns read in place of the aliases returned, keyed by alias.
+	continueOnInvalid bool              // Skip rows failing validation, rather than stopping at the first?
+	rowErrors         RowErrorHandler   // Handler of rows failing conversion, skipped rather than stopping at the first, if set.
+	track             bool              // Track the structs hydrated or synced, see WithTracking?
+}
+
+// tracks returns true if the structs hydrated or synced by the call are
+// tracked, see WithTracking. A nil *call tracks none.
+func (self *call) tracks() bool {
+	return nil != self && self.track
+}
+
+// alias returns `columns` with each aliased column replaced by the
+// column it is an alias of. A nil *call leaves `columns` as they are.
+func (self *call) alias(columns []string) []string {
+	if nil == self || 0 == len(self.aliases) {
+		return columns
+	}
+
+	var aliased = make([]string, len(columns))
+
+	for index, column := range columns {
+		if original, ok := self.aliases[column]; ok {
+			column = original
 		}
 
-		for _, hook := range hooks {
-			if err = hook(object); nil != err {
-				return err // Hook returned an error, return it to caller to deal with.
-			}
+		aliased[index] = column
+	}
+
+	return aliased
+}
+
+// hydrate populates the struct pointed to by `value` from the scanned
+// `values` of row `row`, invoking its scan callbacks, registered hooks
+// and Validator.
+// Panics raised while doing so are returned as errors.
+func (self *Cartographer) hydrate(ctx context.Context, value reflect.Value, columns []string, values []interface{}, row int, call *call) (err error) {
+	if err = protect(func() error { return beforeScan(value) }, "in BeforeScan at row %d", row); nil != err {
+		return
+	}
+
+	if err = self.populate(ctx, value.Elem(), columns, values, row, call); nil != err {
+		if nil != call && nil != call.rowErrors {
+			call.rowErrors(Row{row, columns, rawValues(values)}, err)
+			err = errRowSkipped
 		}
+
+		return
+	}
+
+	if err = protect(func() error { return afterScan(value) }, "in AfterScan at row %d", row); nil != err {
+		return
+	}
+
+	self.installLoaders(value)
+
+	if err = self.runRegisteredHooks(ctx, value, Row{row, columns, rawValues(values)}); nil != err {
+		return
+	}
+
+	if err = self.validateHydrated(value.Interface()); nil != err {
+		return &RowError{row, err}
 	}
 
 	return
 }
 
-// Map takes any type that implements the ScannableRows interface,
-// calling methods Columns, Next, and Scan. Map's parameter `o`
-// must have a reflect.Kind of struct. Map attempts to read and
-// cache tags on struct fields labled with that string passed to the
-// Initialize function, which is intended to be a map to the field's
-// corresponding database column. An array of pointers to the `o`
-// struct passed with it's members populated based on the names
-// of the columns associated with the rows is returned.  Any `hook`
-// passed to map are given a replica generated by reflect.New of
-// the `o` parameter, a list of it's fields, and their initial values.
-func (self *Cartographer) Map(rows ScannableRows, o interface{}, hooks ...Hook) (results []interface{}, err error) {
-	columns, err := rows.Columns() // Columns returned for the results returned.
+// populate sets the fields of struct value `element` mapped to `columns`
+// to their corresponding scanned `values` of row `row`, or returns an error,
+// passing context `ctx` to the Logger and MetricsSink.
+// Columns not mapped by the struct are skipped, unless strict columns are
+// enabled with SetStrictColumns or WithStrict.
+func (self *Cartographer) populate(ctx context.Context, element reflect.Value, columns []string, values []interface{}, row int, call *call) (err error) {
+	var strict = self.strictColumns
 
-	if nil != err {
-		return results, err
+	if nil != call && nil != call.strict {
+		strict = *call.strict
 	}
 
-	// FIXME: The logic within this loop is similar enough with Sync's to be refactored into a method.
-	for rows.Next() {
-		values, err := populatedRowValues(rows, len(columns))
+	for index, _ := range values {
+		if nil != call && nil != call.columns && !call.columns[columns[index]] {
+			continue
+		}
 
-		if nil != err {
-			return results, err
+		mapped := self.byColumn[element.Type()][columns[index]] // The mapped field of the column.
+
+		if nil == mapped {
+			if strict {
+				return errors.New(fmt.Sprintf("No field for column %s on %v", columns[index], element.Type()))
+			}
+
+			if 0 == row {
+				self.debug(ctx, "cartographer: column skipped", "type", element.Type().String(), "column", columns[index])
+			}
+
+			self.publish(Event{Kind: OnColumnSkipped, Type: element.Type(), Value: element.Addr(), Column: columns[index], Row: row})
+
+			continue // Skip columns the type does not map.
 		}
 
-		replica, err := self.CreateReplica(o, hooks...)
+		field := fieldByIndex(element, mapped.index, true) // The field the value belongs to.
+		value := *values[index].(*interface{})
+		coerced := false // Was the value converted to the field's type?
 
-		if nil != err {
-			return results, err
+		if nil == value {
+			self.publish(Event{Kind: OnNullEncountered, Type: element.Type(), Value: element.Addr(), Column: columns[index], Field: mapped.name, Row: row})
 		}
 
-		element := replica.Elem()
+		err = protect(func() (err error) {
+			if value, err = self.transform(element.Type(), columns[index], value); nil != err {
+				return
+			}
+
+			if nil == value && nil != call && call.nullsAsZero {
+				field.Set(reflect.Zero(field.Type()))
+				return
+			}
 
-		for index, _ := range values {
-			name := self.columnsToFields[element.Type()][columns[index]]
-			field := element.FieldByName(name.(string)) // The field the value belongs to.
-			err = setFieldValue(field, (*values[index].(*interface{})))
+			if nil == value && !self.isNullable(element.Type(), mapped) {
+				self.warn(ctx, "cartographer: NULL scanned into non-nullable field, left unchanged", "type", element.Type().String(), "field", mapped.name, "column", columns[index], "row", row)
+			}
 
-			if nil != err {
-				return results, errors.New(fmt.Sprintf("%s for column %s", err.Error(), columns[index]))
+			if self.isJSON(mapped) {
+				return setJSONFieldValue(field, value)
 			}
+
+			value = sanitizeValue(mapped, value)
+
+			if value, err = self.parseTime(mapped.typ, value); nil != err {
+				return
+			}
+
+			coerced = nil != value && !reflect.TypeOf(value).AssignableTo(indirectType(field.Type()))
+
+			return setFieldValue(field, value)
+		}, "setting field %s at row %d", mapped.name, row)
+
+		if nil == err && nil != call && nil != call.report {
+			call.report.record(mapped, value)
 		}
 
-		// Finally, append the replica of the passed item.
-		results = append(results, replica.Interface())
+		if nil == err && nil != value {
+			self.publish(Event{Kind: OnFieldSet, Type: element.Type(), Value: element.Addr(), Column: columns[index], Field: mapped.name, Row: row})
+
+			if coerced {
+				self.publish(Event{Kind: OnValueCoerced, Type: element.Type(), Value: element.Addr(), Column: columns[index], Field: mapped.name, Row: row})
+			}
+		}
+
+		if nil != err {
+			err = errors.New(fmt.Sprintf("%s for column %s", err.Error(), columns[index]))
+			self.metricsFor(ctx).ConversionError(element.Type(), columns[index])
+			self.warn(ctx, "cartographer: conversion failed", "type", element.Type().String(), "field", mapped.name, "column", columns[index], "row", row, "error", err)
+			self.publish(Event{Kind: OnConversionError, Type: element.Type(), Value: element.Addr(), Column: columns[index], Field: mapped.name, Row: row, Err: err})
+			return
+		}
 	}
 
 	return
 }
 
+// isZero reports whether `value` is nil or the zero value of its type.
+func isZero(value interface{}) bool {
+	return nil == value || reflect.ValueOf(value).IsZero()
+}
+
+// protect calls `fn`, returning its error or, should it panic, an error
+// describing the panic followed by the context formatted from `format`
+// and `args`.
+func protect(fn func() error, format string, args ...interface{}) (err error) {
+	defer func() {
+		if recovered := recover(); nil != recovered {
+			err = errors.New(fmt.Sprintf("Recovered from panic %v %s", recovered, fmt.Sprintf(format, args...)))
+		}
+	}()
+
+	return fn()
+}
+
 func setFieldValue(field reflect.Value, value interface{}) (err error) {
 	if nil == value {
 		return
@@ -312,14 +811,42 @@ func setFieldValue(field reflect.Value, value interface{}) (err error) {
 		switch field.Kind() {
 		case reflect.String:
 			field.SetString(parseString(value))
-		case reflect.Int:
-			field.SetInt(parseInt(value))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var integer int64
+
+			if integer, err = parseInt(value); nil == err && field.OverflowInt(integer) {
+				err = errors.New(fmt.Sprintf("Failed to convert %v to %v without overflowing", value, field.Type()))
+			} else if nil == err {
+				field.SetInt(integer)
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			var integer uint64
+
+			if integer, err = parseUint(value); nil == err && field.OverflowUint(integer) {
+				err = errors.New(fmt.Sprintf("Failed to convert %v to %v without overflowing", value, field.Type()))
+			} else if nil == err {
+				field.SetUint(integer)
+			}
 		case reflect.Float32, reflect.Float64:
-			field.SetFloat(parseFloat(value))
+			var float float64
+
+			if float, err = parseFloat(value); nil == err {
+				field.SetFloat(float)
+			}
 		case reflect.Bool:
-			field.SetBool(parseBool(value))
+			var boolean bool
+
+			if boolean, err = parseBool(value); nil == err {
+				field.SetBool(boolean)
+			}
 		case reflect.Struct:
 			field.Set(parseStruct(value))
+		case reflect.Ptr:
+			element := reflect.New(field.Type().Elem())
+
+			if err = setFieldValue(element.Elem(), value); nil == err {
+				field.Set(element)
+			}
 		}
 	} else {
 		err = errors.New("Failed to set field")
@@ -328,6 +855,17 @@ func setFieldValue(field reflect.Value, value interface{}) (err error) {
 	return
 }
 
+// rawValues dereferences the scanned `values` buffer.
+func rawValues(values []interface{}) (raw []interface{}) {
+	raw = make([]interface{}, len(values))
+
+	for index, _ := range values {
+		raw[index] = *values[index].(*interface{})
+	}
+
+	return
+}
+
 func populatedRowValues(rows ScannableRows, size int) (values []interface{}, err error) {
 	values = generateBuffer(size)
 	err = rows.Scan(values...)
@@ -349,34 +887,109 @@ func parseString(o interface{}) string {
 	return fmt.Sprintf("%s", o)
 }
 
-func parseInt(o interface{}) int64 {
+func parseInt(o interface{}) (int64, error) {
 	switch o.(type) {
+	case []uint8, string:
+		return strconv.ParseInt(strings.TrimSpace(parseString(o)), 10, 64)
 	case int:
-		return int64(o.(int))
+		return int64(o.(int)), nil
+	case int8:
+		return int64(o.(int8)), nil
 	case int16:
-		return int64(o.(int16))
+		return int64(o.(int16)), nil
 	case int32:
-		return int64(o.(int32))
+		return int64(o.(int32)), nil
+	case uint8:
+		return int64(o.(uint8)), nil
+	case uint16:
+		return int64(o.(uint16)), nil
+	case uint32:
+		return int64(o.(uint32)), nil
+	case uint64:
+		if math.MaxInt64 < o.(uint64) {
+			return 0, errors.New(fmt.Sprintf("Failed to convert %v to an integer without overflowing", o))
+		}
+
+		return int64(o.(uint64)), nil
+	case float64:
+		if o.(float64) != math.Trunc(o.(float64)) {
+			return 0, errors.New(fmt.Sprintf("Failed to convert %v to an integer", o))
+		}
+
+		return int64(o.(float64)), nil
 	default:
-		return int64(o.(int64))
+		return int64(o.(int64)), nil
+	}
+}
+
+func parseUint(o interface{}) (uint64, error) {
+	switch o.(type) {
+	case []uint8, string:
+		return strconv.ParseUint(strings.TrimSpace(parseString(o)), 10, 64)
+	case uint64:
+		return o.(uint64), nil
+	}
+
+	integer, err := parseInt(o)
+
+	if nil == err && 0 > integer {
+		err = errors.New(fmt.Sprintf("Failed to convert %v to an unsigned integer", o))
 	}
+
+	return uint64(integer), err
 }
 
-func parseFloat(o interface{}) float64 {
+func parseFloat(o interface{}) (float64, error) {
 	switch o.(type) {
-	case []uint8:
-		// FIXME: Should never error, but still bad pratice.
-		float, _ := strconv.ParseFloat(fmt.Sprintf("%s", o), 8)
-		return float
+	case []uint8, string:
+		return strconv.ParseFloat(strings.TrimSpace(parseString(o)), 64)
 	case float32:
-		return float64(o.(float32))
+		return float64(o.(float32)), nil
+	case int64:
+		return float64(o.(int64)), nil
+	case int:
+		return float64(o.(int)), nil
 	default:
-		return float64(o.(float64))
+		return float64(o.(float64)), nil
 	}
 }
 
-func parseBool(o interface{}) bool {
-	return o.(bool)
+func parseBool(o interface{}) (bool, error) {
+	switch o.(type) {
+	case []uint8, string:
+		return strconv.ParseBool(strings.TrimSpace(parseString(o)))
+	case int64:
+		return 0 != o.(int64), nil
+	case int:
+		return 0 != o.(int), nil
+	default:
+		return o.(bool), nil
+	}
+}
+
+// parseTime parses textual `value`s scanned for fields of type `typ`,
+// should it be a time.Time or pointer to one, with the layouts set by
+// SetTimeLayouts, trying each in turn. Any other value is returned as is.
+func (self *Cartographer) parseTime(typ reflect.Type, value interface{}) (interface{}, error) {
+	if 0 == len(self.timeLayouts) || reflect.TypeOf(time.Time{}) != indirectType(typ) {
+		return value, nil
+	}
+
+	switch value.(type) {
+	case []uint8, string:
+	default:
+		return value, nil
+	}
+
+	text := strings.TrimSpace(parseString(value))
+
+	for _, layout := range self.timeLayouts {
+		if parsed, err := time.Parse(layout, text); nil == err {
+			return parsed, nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("Failed to parse %q as a time", text))
 }
 
 func parseStruct(o interface{}) reflect.Value {
@@ -388,10 +1001,50 @@ func parseStruct(o interface{}) reflect.Value {
 // columns to the one passed as parameter `structTag`.
 func Initialize(structTag string) (cartographer *Cartographer) {
 	cartographer = new(Cartographer)
-	cartographer.fieldsToColumns = make(map[reflect.Type]map[interface{}]interface{})
-	cartographer.columnsToFields = make(map[reflect.Type]map[interface{}]interface{})
-	cartographer.typeCache = make(map[reflect.Type]bool)
-	cartographer.structTag = structTag
+	cartographer.hooks = make(map[reflect.Type][]registered[RowHook])
+	cartographer.writeHooks = make(map[reflect.Type][]registered[WriteHook])
+	cartographer.syncHooks = make(map[reflect.Type][]registered[SyncHook])
+	cartographer.transforms = make(map[reflect.Type]map[string][]Transform)
+	cartographer.tables = make(map[reflect.Type]string)
+	cartographer.listeners = make(map[EventKind][]subscriber)
+	cartographer.comparators = make(map[reflect.Type]Comparator)
+	cartographer.writeConverters = make(map[reflect.Type]Converter)
+	cartographer.tracked = make(map[interface{}]*Snapshot)
+	cartographer.inheritance = make(map[reflect.Type]*inheritance)
+	cartographer.variants = make(map[reflect.Type]variant)
+	cartographer.namespaces = make(map[string]*mappings)
+	cartographer.typeTags = make(map[reflect.Type]string)
+	cartographer.validations = make(map[reflect.Type][]Validation)
+	cartographer.notNull = make(map[reflect.Type]map[string]bool)
+	cartographer.metrics = NopMetrics{}
+	cartographer.UseTag(structTag)
 
 	return
 }
+
+// Namer returns the column an untagged field named `field` is mapped to.
+type Namer func(field string) string
+
+// SetNamer sets the Namer used to map untagged exported fields to columns,
+// such as strings.ToLower. Without a Namer, untagged fields are not mapped.
+// As mappings are cached, the Namer should be set before any type is used.
+func (self *Cartographer) SetNamer(namer Namer) {
+	self.detach()
+	self.namer = namer
+}
+
+// SetStrictColumns enables or disables strict columns. When enabled, Map
+// and Sync return an error upon reading a column the destination type does
+// not map, rather than skipping it, such as a column newly added to a table
+// read with `SELECT *` or `RETURNING *`.
+func (self *Cartographer) SetStrictColumns(strict bool) {
+	self.strictColumns = strict
+}
+
+// SetTimeLayouts sets the layouts, as understood by time.Parse, that
+// textual values scanned for time.Time fields are parsed with, such as
+// time.DateTime for drivers returning DATETIME columns as strings. Each
+// layout is tried in turn; without layouts such values are not parsed.
+func (self *Cartographer) SetTimeLayouts(layouts ...string) {
+	self.timeLayouts = layouts
+}
@@ -112,3 +112,45 @@ func TestColumnForField(t *testing.T) {
 		t.Errorf("Basic FieldForColumn test returned an unexpected results: %v, %v", column, err)
 	}
 }
+
+type narrow struct {
+	Small int8   `db:"small"`
+	Count uint16 `db:"count"`
+}
+
+func TestMapOverflow(t *testing.T) {
+	results, err := instance.Map(&fakeRows{columns: []string{"small", "count"}, values: [][]interface{}{{"-128", "65535"}}}, narrow{})
+
+	if nil != err || 1 != len(results) || (narrow{-128, 65535}) != *results[0].(*narrow) {
+		t.Errorf("Basic Map overflow test returned an unexpected results: %v, %v", results, err)
+	}
+
+	for _, values := range [][]interface{}{{"300", 1}, {1, "70000"}, {1, "-1"}, {1, int64(-1)}, {int64(-129), 1}, {uint64(1 << 63), 1}} {
+		if results, err = instance.Map(&fakeRows{columns: []string{"small", "count"}, values: [][]interface{}{values}}, narrow{}); nil == err {
+			t.Errorf("Basic Map overflow test returned %v, expected an error converting %v", results, values)
+		}
+	}
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]interface{}
+	index   int
+}
+
+func (self *fakeRows) Next() bool {
+	self.index++
+	return self.index <= len(self.values)
+}
+
+func (self *fakeRows) Columns() ([]string, error) {
+	return self.columns, nil
+}
+
+func (self *fakeRows) Scan(dest ...interface{}) error {
+	for index, _ := range dest {
+		*dest[index].(*interface{}) = self.values[self.index-1][index]
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Package cartographertest provides a fluent builder of fake rows
+// satisfying cartographer.ScannableRows, with error injection, so tests
+// of code built on cartographer need not hand-write their own fakes.
+//
+//	rows := cartographertest.Rows("id", "name").Add(1, "a").Add(2, "b")
+//	results, err := mapper.Map(rows, User{})
+package cartographertest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// FakeRows are rows built in memory, satisfying cartographer.ScannableRows.
+type FakeRows struct {
+	columns      []string        // Columns of the rows.
+	values       [][]interface{} // Values of each row, in column order.
+	index        int             // Index of the current row, one past it once Next has been called.
+	columnsError error           // Error returned by Columns, if any.
+	scanErrors   map[int]error   // Errors returned by Scan, keyed by row index.
+	closed       bool            // Has Close been called?
+}
+
+// Rows returns a pointer to FakeRows with columns `columns` and no rows.
+func Rows(columns ...string) *FakeRows {
+	return &FakeRows{columns: columns, scanErrors: make(map[int]error)}
+}
+
+// Add appends a row of `values`, one for each column, panicking should
+// the number of values differ from the number of columns.
+func (self *FakeRows) Add(values ...interface{}) *FakeRows {
+	if len(values) != len(self.columns) {
+		panic(fmt.Sprintf("cartographertest: expected %d values, received %d", len(self.columns), len(values)))
+	}
+
+	self.values = append(self.values, values)
+
+	return self
+}
+
+// ColumnsError causes Columns to return error `err`.
+func (self *FakeRows) ColumnsError(err error) *FakeRows {
+	self.columnsError = err
+	return self
+}
+
+// ScanErrorAt causes Scan to return error `err` when scanning the row at
+// index `row`, counting from zero.
+func (self *FakeRows) ScanErrorAt(row int, err error) *FakeRows {
+	self.scanErrors[row] = err
+	return self
+}
+
+// Columns returns the columns of the rows, or the error set by ColumnsError.
+func (self *FakeRows) Columns() ([]string, error) {
+	if nil != self.columnsError {
+		return nil, self.columnsError
+	}
+
+	return self.columns, nil
+}
+
+// Next advances to the next row, returning false once the rows are
+// exhausted or closed.
+func (self *FakeRows) Next() bool {
+	if self.closed || self.index >= len(self.values) {
+		return false
+	}
+
+	self.index++
+
+	return true
+}
+
+// Scan copies the values of the current row into `dest`, returning the
+// error set by ScanErrorAt for the row if any. Each of the `dest` must
+// be a pointer to an interface{}, or to a type the value is assignable
+// or convertible to, as database/sql would accept.
+func (self *FakeRows) Scan(dest ...interface{}) error {
+	if 0 == self.index {
+		return errors.New("Scan called without calling Next")
+	}
+
+	if err, ok := self.scanErrors[self.index-1]; ok {
+		return err
+	}
+
+	if len(dest) != len(self.columns) {
+		return errors.New(fmt.Sprintf("Expected %d destination arguments in Scan, not %d", len(self.columns), len(dest)))
+	}
+
+	for index, value := range self.values[self.index-1] {
+		if pointer, ok := dest[index].(*interface{}); ok {
+			*pointer = value
+			continue
+		}
+
+		target := reflect.ValueOf(dest[index])
+
+		if reflect.Ptr != target.Kind() || target.IsNil() {
+			return errors.New(fmt.Sprintf("Scan destination %T is not a non-nil pointer", dest[index]))
+		}
+
+		target = target.Elem()
+
+		if nil == value {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+
+		source := reflect.ValueOf(value)
+
+		switch {
+		case source.Type().AssignableTo(target.Type()):
+			target.Set(source)
+		case source.Type().ConvertibleTo(target.Type()):
+			target.Set(source.Convert(target.Type()))
+		default:
+			return errors.New(fmt.Sprintf("Unable to scan %T into %T for column %s", value, dest[index], self.columns[index]))
+		}
+	}
+
+	return nil
+}
+
+// Close stops further rows being read.
+func (self *FakeRows) Close() error {
+	self.closed = true
+	return nil
+}
+
+// Err always returns nil, errors being injected through Scan and Columns.
+func (self *FakeRows) Err() error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package cartographertest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+type user struct {
+	Id   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+func TestRows(t *testing.T) {
+	results, err := cartographer.Initialize("db").Map(Rows("id", "name").Add(1, "a").Add(2, "b"), user{})
+
+	if nil != err {
+		t.Errorf("Basic Rows test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(results) || (user{2, "b"}) != *results[1].(*user) {
+		t.Errorf("Basic Rows test returned unexpected results: %v", results)
+	}
+}
+
+func TestScanErrorAt(t *testing.T) {
+	expected := errors.New("broken row")
+	rows := Rows("id", "name").Add(1, "a").Add(2, "b").ScanErrorAt(1, expected)
+
+	if _, err := cartographer.Initialize("db").Map(rows, user{}); expected != err {
+		t.Errorf("Basic ScanErrorAt test returned %v, expected %v", err, expected)
+	}
+}
+
+func TestColumnsError(t *testing.T) {
+	expected := errors.New("no columns")
+
+	if _, err := cartographer.Initialize("db").Map(Rows("id").ColumnsError(expected), user{}); expected != err {
+		t.Errorf("Basic ColumnsError test returned %v, expected %v", err, expected)
+	}
+}
+
+func TestScanTyped(t *testing.T) {
+	rows := Rows("id", "name").Add(int64(7), nil)
+	rows.Next()
+
+	var (
+		id   int
+		name = "unset"
+	)
+
+	if err := rows.Scan(&id, &name); nil != err || 7 != id || "" != name {
+		t.Errorf("Basic Scan test returned an unexpected results: %v, %q, %v", id, name, err)
+	}
+}
+
+func TestAddPanics(t *testing.T) {
+	defer func() {
+		if nil == recover() {
+			t.Error("Basic Add test expected a panic given too few values")
+		}
+	}()
+
+	Rows("id", "name").Add(1)
+}
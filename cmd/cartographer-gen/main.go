@@ -0,0 +1,83 @@
+// Command cartographer-gen generates reflection-free mappers for the
+// tagged structs of a package, see package gen. Annotate each struct with
+// a `//cartographer:generate` comment, or name it with -type, and add
+//
+//	//go:generate cartographer-gen
+//
+// to a file of the package; `go generate` then writes the mappers of the
+// package in the current directory to cartographer_gen.go. Structs
+// annotated `//cartographer:generate repository`, or every struct given
+// -repository, are further given a typed repository, its SQL rendered in
+// the placeholder style named by -placeholder.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+	"github.com/chuckpreslar/cartographer/gen"
+)
+
+var (
+	tag          = flag.String("tag", "", "struct tag fields are mapped through, db if empty")
+	names        = flag.String("type", "", "comma separated structs to generate mappers for, besides those annotated")
+	output       = flag.String("output", "cartographer_gen.go", "file the mappers are written to, within the package's directory")
+	repositories = flag.Bool("repository", false, "generate a repository for every struct, besides those annotated")
+	placeholder  = flag.String("placeholder", "question", "placeholder style of the repositories' SQL, question or dollar")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: cartographer-gen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if err := run(flag.Arg(0)); nil != err {
+		fmt.Fprintf(os.Stderr, "cartographer-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the mappers of the package in directory `dir`, the
+// current directory if empty.
+func run(dir string) (err error) {
+	if 0 == len(dir) {
+		dir = "."
+	}
+
+	var config = gen.Config{Tag: *tag, Repositories: *repositories}
+
+	switch *placeholder {
+	case "question":
+		config.Placeholder = cartographer.Question
+	case "dollar":
+		config.Placeholder = cartographer.Dollar
+	default:
+		return errors.New(fmt.Sprintf("Unknown placeholder style %s", *placeholder))
+	}
+
+	if 0 != len(*names) {
+		config.Types = strings.Split(*names, ",")
+	}
+
+	pkg, err := gen.Parse(dir, config)
+
+	if nil != err {
+		return
+	}
+
+	source, err := pkg.Generate()
+
+	if nil != err {
+		return
+	}
+
+	return os.WriteFile(filepath.Join(dir, *output), source, 0644)
+}
@@ -0,0 +1,117 @@
+// Command cartographer-scaffold generates tagged structs for the tables
+// of an existing database, bootstrapping cartographer's use upon legacy
+// schemas, see gen.Scaffold. The tables are introspected from a live
+// database's information_schema,
+//
+//	cartographer-scaffold -driver=postgres -dsn=$DATABASE_URL -schema=public -output=models.go
+//
+// or read from its DDL, such as a schema dump or its migrations:
+//
+//	cartographer-scaffold -ddl='migrations/*.sql' -output=models.go
+//
+// The database/sql driver named by -driver must be linked into the
+// command, such as by building it alongside a file blank importing it.
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+	"github.com/chuckpreslar/cartographer/gen"
+)
+
+var (
+	driver      = flag.String("driver", "", "database/sql driver of the database introspected")
+	dsn         = flag.String("dsn", "", "data source name of the database introspected")
+	schema      = flag.String("schema", "public", "schema of the database introspected, its name for MySQL")
+	placeholder = flag.String("placeholder", "", "placeholder style of the database introspected, question or dollar, dollar for the postgres and pgx drivers")
+	ddl         = flag.String("ddl", "", "comma separated globs of the DDL files read, in place of introspecting a database")
+	pkg         = flag.String("package", "models", "name of the package generated")
+	tag         = flag.String("tag", "", "struct tag fields are mapped through, db if empty")
+	tables      = flag.String("tables", "", "comma separated tables to generate structs for, every table if empty")
+	nullTypes   = flag.Bool("null-types", false, "type nullable columns as sql.NullString and the like rather than as pointers")
+	output      = flag.String("output", "", "file the structs are written to, standard output if empty")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: cartographer-scaffold [flags]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if err := run(); nil != err {
+		fmt.Fprintf(os.Stderr, "cartographer-scaffold: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the schema and writes the structs scaffolded from it.
+func run() (err error) {
+	read, err := readSchema()
+
+	if nil != err {
+		return
+	}
+
+	var config = gen.ScaffoldConfig{Package: *pkg, Tag: *tag, NullTypes: *nullTypes}
+
+	if 0 != len(*tables) {
+		config.Tables = strings.Split(*tables, ",")
+	}
+
+	source, err := gen.Scaffold(read, config)
+
+	if nil != err {
+		return
+	}
+
+	if 0 == len(*output) {
+		_, err = os.Stdout.Write(source)
+		return
+	}
+
+	return os.WriteFile(*output, source, 0644)
+}
+
+// readSchema returns the schema read from the DDL files named by -ddl, or
+// else introspected from the database named by -driver and -dsn.
+func readSchema() (read cartographer.Schema, err error) {
+	if 0 != len(*ddl) {
+		return cartographer.ReadDDL(os.DirFS("."), strings.Split(*ddl, ",")...)
+	}
+
+	if 0 == len(*driver) {
+		return read, errors.New("Either -ddl or -driver and -dsn must be given")
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+
+	if nil != err {
+		return
+	}
+
+	defer db.Close()
+
+	var c = cartographer.New()
+
+	switch *placeholder {
+	case "dollar":
+		c.SetPlaceholder(cartographer.Dollar)
+	case "question":
+	case "":
+		if "postgres" == *driver || "pgx" == *driver {
+			c.SetPlaceholder(cartographer.Dollar)
+		}
+	default:
+		return read, errors.New(fmt.Sprintf("Unknown placeholder style %s", *placeholder))
+	}
+
+	return c.IntrospectSchema(db, *schema)
+}
@@ -0,0 +1,233 @@
+// Command cartographer-vet reports mistakes within the struct tags
+// cartographer maps fields through, see package tagcheck. It runs as a
+// tool of go vet,
+//
+//	go vet -vettool=$(which cartographer-vet) ./...
+//
+// or on its own, given the directories of the packages to check:
+//
+//	cartographer-vet -tag=db ./models
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer/tagcheck"
+)
+
+var (
+	tag    = flag.String("tag", "", "struct tag fields are mapped through, db if empty")
+	asJSON = flag.Bool("json", false, "print mistakes as JSON, exiting successfully")
+	_      = flag.Int("c", -1, "ignored, accepted for compatibility with go vet")
+)
+
+// config is the subset of the configuration go vet passes its tools
+// that is needed to check a package.
+type config struct {
+	ID         string   // Identifier of the package, as keyed within JSON output.
+	ImportPath string   // Import path of the package.
+	GoFiles    []string // Go files of the package.
+	VetxOnly   bool     // Is the package only analyzed for facts of its dependents?
+	VetxOutput string   // File the facts of the package are written to.
+	Stdout     string   // File JSON output is written to, standard output if empty.
+}
+
+func main() {
+	// go vet queries its tool's version and flags before running it.
+	if 2 == len(os.Args) && "-V=full" == os.Args[1] {
+		version()
+		return
+	}
+
+	if 2 == len(os.Args) && "-flags" == os.Args[1] {
+		fmt.Println(`[{"Name":"tag","Bool":false,"Usage":"struct tag fields are mapped through, db if empty"},` +
+			`{"Name":"json","Bool":true,"Usage":"print mistakes as JSON, exiting successfully"}]`)
+		return
+	}
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: cartographer-vet [flags] [directory ...]\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	var (
+		found bool
+		err   error
+	)
+
+	if 1 == flag.NArg() && strings.HasSuffix(flag.Arg(0), ".cfg") {
+		found, err = vet(flag.Arg(0))
+	} else {
+		found, err = standalone(flag.Args())
+	}
+
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "cartographer-vet: %v\n", err)
+		os.Exit(1)
+	}
+
+	if found && !*asJSON {
+		os.Exit(1)
+	}
+}
+
+// version prints the version of the executable, identified by its hash
+// so that go vet's cache is invalidated when it changes.
+func version() {
+	var hash = sha256.New()
+
+	if executable, err := os.Executable(); nil == err {
+		if file, err := os.Open(executable); nil == err {
+			io.Copy(hash, file)
+			file.Close()
+		}
+	}
+
+	fmt.Printf("%s version devel buildID=%02x\n", filepath.Base(os.Args[0]), hash.Sum(nil))
+}
+
+// vet checks the package described by go vet configuration file `path`,
+// reporting whether mistakes were found.
+func vet(path string) (found bool, err error) {
+	data, err := os.ReadFile(path)
+
+	if nil != err {
+		return
+	}
+
+	var cfg config
+
+	if err = json.Unmarshal(data, &cfg); nil != err {
+		return false, errors.New(fmt.Sprintf("Failed to decode %s: %v", path, err))
+	}
+
+	if 0 != len(cfg.VetxOutput) {
+		if err = os.WriteFile(cfg.VetxOutput, nil, 0666); nil != err {
+			return
+		}
+	}
+
+	if cfg.VetxOnly {
+		return
+	}
+
+	// Newer releases of go vet pass -json, reading the mistakes from the
+	// file named by the configuration to print them and fail itself.
+	var output io.Writer = os.Stdout
+
+	if 0 != len(cfg.Stdout) {
+		file, err := os.Create(cfg.Stdout)
+
+		if nil != err {
+			return false, err
+		}
+
+		defer file.Close()
+
+		output = file
+	}
+
+	return check(output, cfg.ID, cfg.GoFiles)
+}
+
+// standalone checks the packages in directories `dirs`, the current
+// directory if none are given, reporting whether mistakes were found.
+func standalone(dirs []string) (found bool, err error) {
+	if 0 == len(dirs) {
+		dirs = []string{"."}
+	}
+
+	for _, dir := range dirs {
+		pkg, err := build.ImportDir(dir, 0)
+
+		if nil != err {
+			return found, err
+		}
+
+		var filenames []string
+
+		for _, name := range pkg.GoFiles {
+			filenames = append(filenames, filepath.Join(dir, name))
+		}
+
+		reported, err := check(os.Stdout, pkg.ImportPath, filenames)
+
+		if nil != err {
+			return found, err
+		}
+
+		found = found || reported
+	}
+
+	return
+}
+
+// diagnostic is a mistake printed as JSON, as go vet's analyzers print them.
+type diagnostic struct {
+	Posn    string `json:"posn"`
+	Message string `json:"message"`
+}
+
+// check parses and checks the Go files `filenames` of package `id`,
+// printing its mistakes, as JSON to `output` should -json be given, and
+// reporting whether any were found.
+func check(output io.Writer, id string, filenames []string) (found bool, err error) {
+	var (
+		fset  = token.NewFileSet()
+		files []*ast.File
+	)
+
+	for _, filename := range filenames {
+		file, err := parser.ParseFile(fset, filename, nil, 0)
+
+		if nil != err {
+			return false, err
+		}
+
+		files = append(files, file)
+	}
+
+	var diagnostics []diagnostic
+
+	for _, found := range tagcheck.Check(files, *tag) {
+		diagnostics = append(diagnostics, diagnostic{fset.Position(found.Pos).String(), found.Message})
+	}
+
+	if *asJSON {
+		if 0 == len(diagnostics) {
+			return
+		}
+
+		encoded, err := json.MarshalIndent(map[string]map[string][]diagnostic{id: {"tagcheck": diagnostics}}, "", "\t")
+
+		if nil != err {
+			return false, err
+		}
+
+		if _, err = fmt.Fprintln(output, string(encoded)); nil != err {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", diagnostic.Posn, diagnostic.Message)
+	}
+
+	return 0 != len(diagnostics), nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoVet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping go vet test in short mode")
+	}
+
+	if _, err := exec.LookPath("go"); nil != err {
+		t.Skip("Skipping go vet test without the go command")
+	}
+
+	var (
+		dir  = t.TempDir()
+		tool = filepath.Join(dir, "cartographer-vet")
+	)
+
+	if output, err := exec.Command("go", "build", "-o", tool, ".").CombinedOutput(); nil != err {
+		t.Fatalf("Basic go vet test failed to build the tool: %v\n%s", err, output)
+	}
+
+	var files = map[string]string{
+		"go.mod":  "module example.com/models\n\ngo 1.21\n",
+		"user.go": "package models\n\ntype User struct {\n\tEmail string `bd:\"email\"`\n}\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); nil != err {
+			t.Fatalf("Basic go vet test returned an unexpected error: %v", err)
+		}
+	}
+
+	vet := exec.Command("go", "vet", "-vettool="+tool, ".")
+	vet.Dir = dir
+	vet.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+
+	output, err := vet.CombinedOutput()
+
+	if nil == err {
+		t.Errorf("Basic go vet test expected go vet to fail, received:\n%s", output)
+	}
+
+	if !strings.Contains(string(output), `user.go:4:2: struct tag key "bd" resembles "db"`) {
+		t.Errorf("Basic go vet test expected the mistake to be reported, received:\n%s", output)
+	}
+}
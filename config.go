@@ -0,0 +1,96 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Config describes a Cartographer's behavior as read from a configuration
+// file, so deployments may tune it without recompiling.
+type Config struct {
+	Tag         string   `json:"tag" yaml:"tag"`                   // Struct tag fields are mapped through, `db` if empty.
+	Naming      string   `json:"naming" yaml:"naming"`             // Naming of untagged fields: `snake`, `lower`, or empty to leave them unmapped.
+	Strict      bool     `json:"strict" yaml:"strict"`             // Error on columns not mapped, see SetStrictColumns?
+	TimeLayouts []string `json:"time_layouts" yaml:"time_layouts"` // Layouts textual times are parsed with, see SetTimeLayouts.
+	Dialect     string   `json:"dialect" yaml:"dialect"`           // SQL dialect: `postgres`, `mysql` or `sqlite`, selecting the placeholder style.
+}
+
+// Unmarshal decodes `data` into the value pointed to by `v`, such as
+// json.Unmarshal or the Unmarshal of a YAML package.
+type Unmarshal func(data []byte, v interface{}) error
+
+// ConfigFromJSON decodes a Config from JSON `data`, or returns an error
+// should the JSON or any of its settings be invalid.
+func ConfigFromJSON(data []byte) (Config, error) {
+	return ConfigFrom(data, json.Unmarshal)
+}
+
+// ConfigFromYAML decodes a Config from YAML `data` with `unmarshal`,
+// the Unmarshal of the YAML package of the application's choosing,
+// or returns an error should the YAML or any of its settings be invalid.
+func ConfigFromYAML(data []byte, unmarshal Unmarshal) (Config, error) {
+	return ConfigFrom(data, unmarshal)
+}
+
+// ConfigFrom decodes a Config from `data` with `unmarshal`, or returns
+// an error should decoding fail or any of its settings be invalid.
+func ConfigFrom(data []byte, unmarshal Unmarshal) (config Config, err error) {
+	if err = unmarshal(data, &config); nil != err {
+		return
+	}
+
+	_, err = config.Options()
+
+	return
+}
+
+// Options returns the Options configuring a Cartographer as described,
+// for passing to New, or an error for an unknown naming or dialect.
+func (self Config) Options() (opts []Option, err error) {
+	if 0 != len(self.Tag) {
+		opts = append(opts, WithTag(self.Tag))
+	}
+
+	switch strings.ToLower(self.Naming) {
+	case "":
+	case "snake":
+		opts = append(opts, WithNamer(snakeCase))
+	case "lower":
+		opts = append(opts, WithNamer(strings.ToLower))
+	default:
+		return nil, errors.New(fmt.Sprintf("Unknown naming %s", self.Naming))
+	}
+
+	switch strings.ToLower(self.Dialect) {
+	case "":
+	case "postgres", "postgresql":
+		opts = append(opts, WithPlaceholder(Dollar))
+	case "mysql", "sqlite", "sqlite3":
+		opts = append(opts, WithPlaceholder(Question))
+	default:
+		return nil, errors.New(fmt.Sprintf("Unknown dialect %s", self.Dialect))
+	}
+
+	opts = append(opts, WithStrictColumns(self.Strict))
+
+	if 0 != len(self.TimeLayouts) {
+		opts = append(opts, WithTimeLayouts(self.TimeLayouts...))
+	}
+
+	return
+}
+
+// NewFromConfig returns a pointer to a new Cartographer configured as
+// `config` describes, followed by any further `opts`, or an error
+// should the configuration be invalid.
+func NewFromConfig(config Config, opts ...Option) (*Cartographer, error) {
+	configured, err := config.Options()
+
+	if nil != err {
+		return nil, err
+	}
+
+	return New(append(configured, opts...)...), nil
+}
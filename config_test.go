@@ -0,0 +1,42 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigFromJSON(t *testing.T) {
+	config, err := ConfigFromJSON([]byte(`{"tag": "pg", "naming": "snake", "strict": true, "time_layouts": ["2006-01-02"], "dialect": "postgres"}`))
+
+	if nil != err {
+		t.Fatalf("Basic ConfigFrom test returned an unexpected error: %v", err)
+	}
+
+	c, err := NewFromConfig(config)
+
+	if nil != err {
+		t.Fatalf("Basic ConfigFrom test returned an unexpected error: %v", err)
+	}
+
+	if "pg" != c.structTag || !c.strictColumns || "$2" != c.bind(2) || 1 != len(c.timeLayouts) || "user_role" != c.namer("UserRole") {
+		t.Errorf("Basic ConfigFrom test returned unexpected configuration: %+v", c)
+	}
+
+	for _, data := range []string{`{"naming": "kebab"}`, `{"dialect": "oracle"}`, `{"tag": 1}`} {
+		if _, err = ConfigFromJSON([]byte(data)); nil == err {
+			t.Errorf("Basic ConfigFrom test expected an error for configuration %s", data)
+		}
+	}
+}
+
+func TestConfigFromYAML(t *testing.T) {
+	var unmarshal = func(data []byte, v interface{}) error {
+		return json.Unmarshal([]byte(`{"dialect": "mysql"}`), v) // Stands in for a YAML package.
+	}
+
+	config, err := ConfigFromYAML([]byte("dialect: mysql"), unmarshal)
+
+	if nil != err || "mysql" != config.Dialect {
+		t.Errorf("Basic ConfigFrom test returned unexpected configuration: %+v, %v", config, err)
+	}
+}
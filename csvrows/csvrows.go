@@ -0,0 +1,128 @@
+// Package csvrows adapts CSV documents to cartographer.ScannableRows, so the
+// struct mappings used to hydrate models from SQL may hydrate them from CSV
+// imports as well. The first record is read as the columns unless they are
+// given with the Columns option; each field is scanned as a string, which
+// cartographer parses into the numeric and boolean fields it is mapped to.
+package csvrows
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Option configures Rows returned by New.
+type Option func(*Rows)
+
+// Comma sets the field delimiter, a comma by default.
+func Comma(comma rune) Option {
+	return func(self *Rows) {
+		self.reader.Comma = comma
+	}
+}
+
+// Columns sets the columns of a CSV document without a header row.
+func Columns(columns ...string) Option {
+	return func(self *Rows) {
+		self.columns = columns
+	}
+}
+
+// EmptyAsNull scans empty fields as nil, leaving the fields they are
+// mapped to untouched, as cartographer does for NULL columns.
+func EmptyAsNull() Option {
+	return func(self *Rows) {
+		self.emptyAsNull = true
+	}
+}
+
+// Rows is a CSV document read as cartographer.ScannableRows.
+type Rows struct {
+	reader      *csv.Reader // Reader of the underlying CSV document.
+	columns     []string    // Columns, read from the header row or given.
+	record      []string    // Record read by the last call to Next.
+	emptyAsNull bool        // Are empty fields scanned as nil?
+	err         error       // Error encountered while reading, if any.
+}
+
+// New returns a pointer to Rows reading CSV from `r`, configured by `opts`.
+func New(r io.Reader, opts ...Option) *Rows {
+	rows := &Rows{reader: csv.NewReader(r)}
+
+	for _, opt := range opts {
+		opt(rows)
+	}
+
+	return rows
+}
+
+// Columns returns the columns of the document, reading the header row
+// if the columns were not given.
+func (self *Rows) Columns() ([]string, error) {
+	if nil == self.columns && nil == self.err {
+		self.columns, self.err = self.reader.Read()
+
+		if io.EOF == self.err {
+			self.err = errors.New("CSV document has no header row")
+		}
+	}
+
+	return self.columns, self.err
+}
+
+// Next reads the next record, returning false once the document is
+// exhausted or an error is encountered, see Err.
+func (self *Rows) Next() bool {
+	if _, err := self.Columns(); nil != err {
+		return false
+	}
+
+	record, err := self.reader.Read()
+
+	if nil != err {
+		if io.EOF != err {
+			self.err = err
+		}
+
+		self.record = nil
+		return false
+	}
+
+	self.record = record
+
+	return true
+}
+
+// Scan copies the fields of the current record into `dest`, which must
+// hold a pointer to an interface{} for each column.
+func (self *Rows) Scan(dest ...interface{}) error {
+	if nil == self.record {
+		return errors.New("Scan called without calling Next")
+	}
+
+	if len(dest) != len(self.record) {
+		return errors.New(fmt.Sprintf("Expected %d destination arguments in Scan, not %d", len(self.record), len(dest)))
+	}
+
+	for index, field := range self.record {
+		pointer, ok := dest[index].(*interface{})
+
+		if !ok {
+			return errors.New(fmt.Sprintf("Unsupported Scan destination %T", dest[index]))
+		}
+
+		if self.emptyAsNull && 0 == len(field) {
+			*pointer = nil
+		} else {
+			*pointer = field
+		}
+	}
+
+	return nil
+}
+
+// Err returns the error, if any, encountered while reading the document.
+func (self *Rows) Err() error {
+	return self.err
+}
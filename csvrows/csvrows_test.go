@@ -0,0 +1,50 @@
+package csvrows
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+type record struct {
+	Name   string  `db:"name"`
+	Age    int     `db:"age"`
+	Score  float64 `db:"score"`
+	Active bool    `db:"active"`
+}
+
+func TestMapCSV(t *testing.T) {
+	rows := New(strings.NewReader("name,age,score,active\nada,36,9.5,true\nbob,,1.25,false\n"), EmptyAsNull())
+	results, err := cartographer.Initialize("db").Map(rows, record{})
+
+	if nil != err {
+		t.Errorf("Basic csvrows Map test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(results) || (record{"ada", 36, 9.5, true}) != *results[0].(*record) || (record{"bob", 0, 1.25, false}) != *results[1].(*record) {
+		t.Errorf("Basic csvrows Map test returned unexpected results: %v", results)
+	}
+
+	if nil != rows.Err() {
+		t.Errorf("Basic csvrows Map test returned an unexpected error: %v", rows.Err())
+	}
+}
+
+func TestColumnsAndComma(t *testing.T) {
+	var o record
+
+	err := cartographer.Initialize("db").Sync(New(strings.NewReader("ada;36\n"), Columns("name", "age"), Comma(';')), &o)
+
+	if nil != err || "ada" != o.Name || 36 != o.Age {
+		t.Errorf("Basic csvrows Sync test returned an unexpected results: %v, %v", o, err)
+	}
+}
+
+func TestConversionError(t *testing.T) {
+	for _, input := range []string{"name,age\nada,old\n", "name,age\nada,99999999999999999999\n"} {
+		if results, err := cartographer.Initialize("db").Map(New(strings.NewReader(input)), record{}); nil == err {
+			t.Errorf("Basic csvrows conversion test returned %v, expected an error converting %q", results, input)
+		}
+	}
+}
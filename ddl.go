@@ -0,0 +1,843 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Schema describes the tables declared by SQL DDL, such as a schema dump
+// or the migrations applied to a database, as read by ParseDDL.
+type Schema struct {
+	Tables []Table // Tables declared, in the order they were created.
+}
+
+// Table describes a table declared within a Schema.
+type Table struct {
+	Name    string   // Name of the table, without the schema qualifying it.
+	Columns []Column // Columns of the table, in declaration order.
+}
+
+// Column describes a column of a Table.
+type Column struct {
+	Name       string // Name of the column.
+	Type       string // Type of the column as declared, lower cased, such as `varchar(255)`.
+	Nullable   bool   // May the column be NULL?
+	PrimaryKey bool   // Is the column part of the table's primary key?
+	Generated  bool   // Does the database generate the column's values, as for serial and identity columns?
+}
+
+// Table returns the table named `name`, and whether it is declared.
+func (self Schema) Table(name string) (Table, bool) {
+	if index := self.table(name); -1 != index {
+		return self.Tables[index], true
+	}
+
+	return Table{}, false
+}
+
+// Column returns the column named `name`, and whether it is declared.
+func (self Table) Column(name string) (Column, bool) {
+	if index := self.column(name); -1 != index {
+		return self.Columns[index], true
+	}
+
+	return Column{}, false
+}
+
+// table returns the index of the table named `name`, or -1.
+func (self Schema) table(name string) int {
+	for index, table := range self.Tables {
+		if strings.EqualFold(name, table.Name) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// column returns the index of the column named `name`, or -1.
+func (self Table) column(name string) int {
+	for index, column := range self.Columns {
+		if strings.EqualFold(name, column.Name) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// ParseDDL parses the CREATE TABLE, ALTER TABLE and DROP TABLE statements
+// of `ddl` into a Schema, applying each in turn as a database would, or
+// returns an error should a statement be malformed or refer to a table or
+// column not declared. Other statements, such as CREATE INDEX or INSERT,
+// are skipped.
+func ParseDDL(ddl string) (schema Schema, err error) {
+	err = schema.apply(ddl)
+	return
+}
+
+// ReadDDL behaves as ParseDDL for the files of `fsys` matching any of the
+// `patterns`, as understood by fs.Glob, such as `migrations/*.up.sql`.
+// Files are applied in lexical order, as migration tools number them.
+func ReadDDL(fsys fs.FS, patterns ...string) (schema Schema, err error) {
+	var names []string
+
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+
+		if nil != err {
+			return schema, err
+		}
+
+		names = append(names, matches...)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		ddl, err := fs.ReadFile(fsys, name)
+
+		if nil != err {
+			return schema, err
+		}
+
+		if err = schema.apply(string(ddl)); nil != err {
+			return schema, errors.New(fmt.Sprintf("Failed to parse %s: %v", name, err))
+		}
+	}
+
+	return
+}
+
+// SchemaMismatch describes a column mapped by a struct type but not declared
+// by a Schema, or declared but not mapped by any of the types checked.
+type SchemaMismatch struct {
+	Type   reflect.Type // Struct type mapping the column, nil for columns not mapped.
+	Table  string       // Table of the column.
+	Column string       // The column, empty should the table not be declared.
+}
+
+// Error describes the mismatch.
+func (self SchemaMismatch) Error() string {
+	if 0 == len(self.Column) {
+		return fmt.Sprintf("Table %s of %v is not declared", self.Table, self.Type)
+	}
+
+	if nil == self.Type {
+		return fmt.Sprintf("Column %s.%s is not mapped", self.Table, self.Column)
+	}
+
+	return fmt.Sprintf("Column %s.%s of %v is not declared", self.Table, self.Column, self.Type)
+}
+
+// SchemaMismatches is the list of mismatches returned by CheckSchema.
+type SchemaMismatches []SchemaMismatch
+
+// Error joins the description of each mismatch.
+func (self SchemaMismatches) Error() string {
+	var messages = make([]string, 0, len(self))
+
+	for _, mismatch := range self {
+		messages = append(messages, mismatch.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// CheckSchema verifies that every column mapped by the struct types of
+// `types` is declared by their table within `schema`, and that every
+// column of those tables is mapped by one of the types, returning the
+// SchemaMismatches found, so that tests may fail fast rather than scans
+// failing at runtime. Columns of `ignore`, given as `column` to ignore
+// the column of every table or `table.column`, are not required to be
+// mapped. An error is returned should any of the `types` not be a struct.
+func (self *Cartographer) CheckSchema(schema Schema, ignore []string, types ...interface{}) error {
+	var (
+		mismatches SchemaMismatches
+		mapped     = make(map[string]map[string]bool) // Columns mapped, keyed by table.
+		tables     []string                           // Tables checked, in the order first met.
+	)
+
+	for _, o := range types {
+		typ, err := self.DiscoverType(o)
+
+		if nil != err {
+			return err
+		}
+
+		name, err := self.TableFor(o)
+
+		if nil != err {
+			return err
+		}
+
+		table, ok := schema.Table(name)
+
+		if !ok {
+			mismatches = append(mismatches, SchemaMismatch{typ, name, ""})
+			continue
+		}
+
+		if nil == mapped[table.Name] {
+			mapped[table.Name] = make(map[string]bool)
+			tables = append(tables, table.Name)
+		}
+
+		var columns []string
+
+		for _, field := range self.fields[typ] {
+			columns = append(columns, field.column)
+		}
+
+		for _, column := range append(columns, self.inheritedColumns(typ)...) {
+			if index := table.column(column); -1 != index {
+				mapped[table.Name][strings.ToLower(table.Columns[index].Name)] = true
+			} else {
+				mismatches = append(mismatches, SchemaMismatch{typ, table.Name, column})
+			}
+		}
+	}
+
+	var ignored = make(map[string]bool)
+
+	for _, column := range ignore {
+		ignored[strings.ToLower(column)] = true
+	}
+
+	for _, name := range tables {
+		table, _ := schema.Table(name)
+
+		for _, column := range table.Columns {
+			var key = strings.ToLower(column.Name)
+
+			if !mapped[name][key] && !ignored[key] && !ignored[strings.ToLower(name)+"."+key] {
+				mismatches = append(mismatches, SchemaMismatch{nil, name, column.Name})
+			}
+		}
+	}
+
+	if 0 != len(mismatches) {
+		return mismatches
+	}
+
+	return nil
+}
+
+// apply applies the statements of `ddl` to the Schema.
+func (self *Schema) apply(ddl string) error {
+	statements, err := lexDDL(ddl)
+
+	if nil != err {
+		return err
+	}
+
+	for number, statement := range statements {
+		if err = self.applyStatement(statement); nil != err {
+			return errors.New(fmt.Sprintf("Statement %d: %v", number+1, err))
+		}
+	}
+
+	return nil
+}
+
+// applyStatement applies a single statement's `tokens` to the Schema.
+func (self *Schema) applyStatement(tokens ddlTokens) error {
+	switch {
+	case tokens.keyword(0, "CREATE"):
+		var position = tokens.skip(1, "OR", "REPLACE", "TEMP", "TEMPORARY", "UNLOGGED", "GLOBAL", "LOCAL")
+
+		if !tokens.keyword(position, "TABLE") {
+			return nil
+		}
+
+		return self.createTable(tokens[tokens.skip(position+1, "IF", "NOT", "EXISTS"):], tokens.keyword(position+1, "IF"))
+	case tokens.keyword(0, "ALTER") && tokens.keyword(1, "TABLE"):
+		return self.alterTable(tokens[tokens.skip(2, "IF", "EXISTS", "ONLY"):], tokens.keyword(2, "IF"))
+	case tokens.keyword(0, "DROP") && tokens.keyword(1, "TABLE"):
+		position := tokens.skip(2, "IF", "EXISTS")
+
+		for position < len(tokens) {
+			name, next := tokens.name(position)
+
+			if index := self.table(name); -1 != index {
+				self.Tables = append(self.Tables[:index], self.Tables[index+1:]...)
+			} else if !tokens.keyword(2, "IF") {
+				return errors.New(fmt.Sprintf("DROP TABLE of undeclared table %s", name))
+			}
+
+			if position = next + 1; !tokens.punctuation(next, ",") {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// createTable applies the CREATE TABLE statement whose `tokens` follow the
+// TABLE keyword, skipping tables already declared should the statement
+// declare them only if they do not `exist`.
+func (self *Schema) createTable(tokens ddlTokens, exist bool) error {
+	name, position := tokens.name(0)
+
+	if !tokens.punctuation(position, "(") {
+		return nil // CREATE TABLE ... AS SELECT, or LIKE another table.
+	}
+
+	if -1 != self.table(name) {
+		if exist {
+			return nil
+		}
+
+		return errors.New(fmt.Sprintf("Table %s declared twice", name))
+	}
+
+	var table = Table{Name: name}
+
+	for _, element := range tokens.list(position) {
+		if element.keyword(0, "CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "INDEX", "KEY", "FULLTEXT", "SPATIAL", "EXCLUDE", "LIKE") {
+			table.constrain(element)
+			continue
+		}
+
+		column, err := parseColumn(element)
+
+		if nil != err {
+			return err
+		}
+
+		if -1 != table.column(column.Name) {
+			return errors.New(fmt.Sprintf("Column %s declared twice on %s", column.Name, name))
+		}
+
+		table.Columns = append(table.Columns, column)
+	}
+
+	self.Tables = append(self.Tables, table)
+
+	return nil
+}
+
+// alterTable applies the ALTER TABLE statement whose `tokens` follow the
+// TABLE keyword, skipping undeclared tables should the statement alter
+// them only if they `exist`.
+func (self *Schema) alterTable(tokens ddlTokens, exist bool) error {
+	name, position := tokens.name(0)
+	index := self.table(name)
+
+	if -1 == index {
+		if exist {
+			return nil
+		}
+
+		return errors.New(fmt.Sprintf("ALTER TABLE of undeclared table %s", name))
+	}
+
+	var table = &self.Tables[index]
+
+	for _, action := range tokens[position:].split() {
+		if err := table.alter(action); nil != err {
+			return errors.New(fmt.Sprintf("%v on %s", err, table.Name))
+		}
+	}
+
+	return nil
+}
+
+// alter applies a single action of an ALTER TABLE statement to the Table.
+func (self *Table) alter(action ddlTokens) error {
+	switch {
+	case action.keyword(0, "ADD"):
+		if action.keyword(1, "CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "INDEX", "KEY", "FULLTEXT", "SPATIAL", "EXCLUDE") {
+			self.constrain(action[1:])
+			return nil
+		}
+
+		var (
+			position = action.skip(1, "COLUMN")
+			exists   = action.keyword(position, "IF")
+		)
+
+		column, err := parseColumn(action[action.skip(position, "IF", "NOT", "EXISTS"):])
+
+		if nil != err {
+			return err
+		}
+
+		if -1 != self.column(column.Name) {
+			if exists {
+				return nil
+			}
+
+			return errors.New(fmt.Sprintf("Column %s declared twice", column.Name))
+		}
+
+		self.Columns = append(self.Columns, column)
+	case action.keyword(0, "DROP"):
+		if action.keyword(1, "PRIMARY") {
+			for index, _ := range self.Columns {
+				self.Columns[index].PrimaryKey = false
+			}
+
+			return nil
+		}
+
+		if action.keyword(1, "CONSTRAINT", "INDEX", "KEY", "FOREIGN", "CHECK") {
+			return nil
+		}
+
+		var (
+			position = action.skip(1, "COLUMN")
+			exists   = action.keyword(position, "IF")
+			name     = action.text(action.skip(position, "IF", "EXISTS"))
+		)
+
+		if index := self.column(name); -1 != index {
+			self.Columns = append(self.Columns[:index], self.Columns[index+1:]...)
+		} else if !exists {
+			return errors.New(fmt.Sprintf("DROP COLUMN of undeclared column %s", name))
+		}
+	case action.keyword(0, "RENAME"):
+		if action.keyword(1, "TO", "AS") {
+			self.Name, _ = action.name(2)
+			return nil
+		}
+
+		if action.keyword(1, "CONSTRAINT", "INDEX", "KEY") {
+			return nil
+		}
+
+		var (
+			position = action.skip(1, "COLUMN")
+			name     = action.text(position)
+		)
+
+		index := self.column(name)
+
+		if -1 == index {
+			return errors.New(fmt.Sprintf("RENAME COLUMN of undeclared column %s", name))
+		}
+
+		self.Columns[index].Name = action.text(action.skip(position+1, "TO"))
+	case action.keyword(0, "MODIFY", "CHANGE"):
+		var (
+			position = action.skip(1, "COLUMN")
+			name     = action.text(position)
+		)
+
+		if action.keyword(0, "CHANGE") {
+			position++
+		}
+
+		index := self.column(name)
+
+		if -1 == index {
+			return errors.New(fmt.Sprintf("%s COLUMN of undeclared column %s", strings.ToUpper(action.text(0)), name))
+		}
+
+		column, err := parseColumn(action[position:])
+
+		if nil != err {
+			return err
+		}
+
+		column.PrimaryKey = column.PrimaryKey || self.Columns[index].PrimaryKey
+		self.Columns[index] = column
+	case action.keyword(0, "ALTER"):
+		var (
+			position = action.skip(1, "COLUMN")
+			name     = action.text(position)
+		)
+
+		index := self.column(name)
+
+		if -1 == index {
+			return errors.New(fmt.Sprintf("ALTER COLUMN of undeclared column %s", name))
+		}
+
+		var column = &self.Columns[index]
+
+		switch position++; {
+		case action.keyword(position, "TYPE"):
+			column.Type = columnType(action[position+1:])
+		case action.keyword(position, "SET") && action.keyword(position+1, "DATA"):
+			column.Type = columnType(action[action.skip(position+1, "DATA", "TYPE"):])
+		case action.keyword(position, "SET") && action.keyword(position+1, "NOT"):
+			column.Nullable = false
+		case action.keyword(position, "DROP") && action.keyword(position+1, "NOT"):
+			column.Nullable = true
+		}
+	}
+
+	return nil
+}
+
+// constrain applies the table constraint `tokens` to the Table, marking
+// the columns of a PRIMARY KEY constraint as primary keys.
+func (self *Table) constrain(tokens ddlTokens) {
+	for position, _ := range tokens {
+		if !tokens.keyword(position, "PRIMARY") || !tokens.keyword(position+1, "KEY") {
+			continue
+		}
+
+		for _, element := range tokens.list(position + 2) {
+			if index := self.column(element.text(0)); -1 != index {
+				self.Columns[index].PrimaryKey = true
+				self.Columns[index].Nullable = false
+			}
+		}
+
+		return
+	}
+}
+
+// columnConstraints are the keywords ending the type of a column definition.
+var columnConstraints = []string{"NOT", "NULL", "DEFAULT", "PRIMARY", "REFERENCES", "UNIQUE", "CHECK", "CONSTRAINT",
+	"COLLATE", "GENERATED", "AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY", "COMMENT", "ON", "AS", "USING", "FIRST", "AFTER"}
+
+// parseColumn parses the column definition `tokens`, its name followed by
+// its type and constraints.
+func parseColumn(tokens ddlTokens) (column Column, err error) {
+	if 0 == len(tokens) || (!tokens[0].quoted && !isWord(tokens[0].text)) {
+		err = errors.New(fmt.Sprintf("Malformed column definition %s", tokens))
+		return
+	}
+
+	column.Name = tokens[0].text
+	column.Type = columnType(tokens[1:])
+	column.Nullable = true
+	column.Generated = strings.HasSuffix(column.Type, "serial")
+
+	for position, depth := 1, 0; position < len(tokens); position++ {
+		switch {
+		case tokens.punctuation(position, "("):
+			depth++
+		case tokens.punctuation(position, ")"):
+			depth--
+		case 0 != depth:
+		case tokens.keyword(position, "NOT") && tokens.keyword(position+1, "NULL"):
+			column.Nullable = false
+			position++
+		case tokens.keyword(position, "AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY", "GENERATED"):
+			column.Generated = true
+		case tokens.keyword(position, "DEFAULT"):
+			column.Generated = column.Generated || tokens.keyword(position+1, "NEXTVAL")
+			position++ // A DEFAULT NULL does not make the column nullable.
+		case tokens.keyword(position, "PRIMARY") && tokens.keyword(position+1, "KEY"):
+			column.PrimaryKey = true
+			column.Nullable = false
+			position++
+		}
+	}
+
+	return
+}
+
+// columnType returns the type beginning column definition `tokens`,
+// lower cased, up to its first constraint.
+func columnType(tokens ddlTokens) string {
+	var (
+		builder strings.Builder
+		depth   int
+	)
+
+	for position, token := range tokens {
+		if 0 == depth && (tokens.keyword(position, columnConstraints...) || (tokens.keyword(position, "CHARACTER") && tokens.keyword(position+1, "SET"))) {
+			break
+		}
+
+		switch {
+		case tokens.punctuation(position, "("):
+			depth++
+		case tokens.punctuation(position, ")"):
+			depth--
+		}
+
+		if 0 != position && !tokens.punctuation(position, "(", ")", ",") && !tokens.punctuation(position-1, "(", ",") && "[]" != token.text {
+			builder.WriteString(" ")
+		}
+
+		builder.WriteString(strings.ToLower(token.text))
+	}
+
+	return builder.String()
+}
+
+// ddlToken is a single token of SQL DDL.
+type ddlToken struct {
+	text   string // Text of the token, without the quotes of a quoted identifier.
+	quoted bool   // Is the token a quoted identifier?
+}
+
+// ddlTokens is the tokens of a statement, or part of one.
+type ddlTokens []ddlToken
+
+// String returns the tokens separated by spaces.
+func (self ddlTokens) String() string {
+	var texts []string
+
+	for _, token := range self {
+		texts = append(texts, token.text)
+	}
+
+	return strings.Join(texts, " ")
+}
+
+// text returns the text of the token at `position`, or an empty string.
+func (self ddlTokens) text(position int) string {
+	if position < len(self) {
+		return self[position].text
+	}
+
+	return ""
+}
+
+// keyword reports whether the token at `position` is an unquoted
+// identifier matching any of the `keywords`, regardless of case.
+func (self ddlTokens) keyword(position int, keywords ...string) bool {
+	if position >= len(self) || self[position].quoted {
+		return false
+	}
+
+	for _, keyword := range keywords {
+		if strings.EqualFold(keyword, self[position].text) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// punctuation reports whether the token at `position` is any of the `marks`.
+func (self ddlTokens) punctuation(position int, marks ...string) bool {
+	return position < len(self) && !self[position].quoted && isPunctuation(self[position].text) && self.keyword(position, marks...)
+}
+
+// skip returns the position of the first token from `position` that
+// is not any of the `keywords`.
+func (self ddlTokens) skip(position int, keywords ...string) int {
+	for self.keyword(position, keywords...) {
+		position++
+	}
+
+	return position
+}
+
+// name returns the unqualified name beginning at `position`, such as
+// `users` of `public.users`, and the position following it.
+func (self ddlTokens) name(position int) (name string, next int) {
+	for name, next = self.text(position), position+1; self.punctuation(next, "."); next += 2 {
+		name = self.text(next + 1)
+	}
+
+	return
+}
+
+// list returns the comma separated elements of the parenthesized list
+// opening at `position`.
+func (self ddlTokens) list(position int) []ddlTokens {
+	if !self.punctuation(position, "(") {
+		return nil
+	}
+
+	for end, depth := position, 0; end < len(self); end++ {
+		switch {
+		case self.punctuation(end, "("):
+			depth++
+		case self.punctuation(end, ")"):
+			if depth--; 0 == depth {
+				return self[position+1 : end].split()
+			}
+		}
+	}
+
+	return self[position+1:].split()
+}
+
+// split splits the tokens at commas outside of parentheses.
+func (self ddlTokens) split() (elements []ddlTokens) {
+	var (
+		start = 0
+		depth = 0
+	)
+
+	for position, _ := range self {
+		switch {
+		case self.punctuation(position, "("):
+			depth++
+		case self.punctuation(position, ")"):
+			depth--
+		case self.punctuation(position, ",") && 0 == depth:
+			elements = append(elements, self[start:position])
+			start = position + 1
+		}
+	}
+
+	if start < len(self) {
+		elements = append(elements, self[start:])
+	}
+
+	return
+}
+
+// lexDDL splits `ddl` into the tokens of each of its statements, skipping
+// comments and empty statements, or returns an error should a quoted
+// identifier, string or comment not be terminated.
+func lexDDL(ddl string) (statements []ddlTokens, err error) {
+	var (
+		runes     = []rune(ddl)
+		statement ddlTokens
+	)
+
+	for position := 0; position < len(runes); {
+		var (
+			character = runes[position]
+			next      rune
+		)
+
+		if position+1 < len(runes) {
+			next = runes[position+1]
+		}
+
+		switch {
+		case unicode.IsSpace(character):
+			position++
+		case ('-' == character && '-' == next) || '#' == character:
+			for position < len(runes) && '\n' != runes[position] {
+				position++
+			}
+		case '/' == character && '*' == next:
+			end := find(runes, position+2, "*/")
+
+			if -1 == end {
+				return nil, errors.New("Unterminated comment")
+			}
+
+			position = end + 2
+		case '\'' == character:
+			end := position + 1
+
+			for ; end < len(runes) && ('\'' != runes[end] || (end+1 < len(runes) && '\'' == runes[end+1])); end++ {
+				if '\'' == runes[end] {
+					end++ // Skip the quote escaping it.
+				}
+			}
+
+			if end >= len(runes) {
+				return nil, errors.New("Unterminated string")
+			}
+
+			statement = append(statement, ddlToken{string(runes[position : end+1]), false})
+			position = end + 1
+		case '[' == character && ']' == next:
+			statement = append(statement, ddlToken{"[]", false})
+			position += 2
+		case '"' == character || '`' == character || '[' == character:
+			var closing = string(character)
+
+			if '[' == character {
+				closing = "]"
+			}
+
+			end := find(runes, position+1, closing)
+
+			if -1 == end {
+				return nil, errors.New(fmt.Sprintf("Unterminated identifier %c", character))
+			}
+
+			statement = append(statement, ddlToken{string(runes[position+1 : end]), true})
+			position = end + 1
+		case '$' == character && 0 != dollarQuote(runes[position:]):
+			var tag = string(runes[position : position+dollarQuote(runes[position:])])
+
+			end := find(runes, position+len(tag), tag)
+
+			if -1 == end {
+				return nil, errors.New(fmt.Sprintf("Unterminated string %s", tag))
+			}
+
+			statement = append(statement, ddlToken{string(runes[position : end+len(tag)]), false})
+			position = end + len(tag)
+		case ';' == character:
+			if 0 != len(statement) {
+				statements = append(statements, statement)
+			}
+
+			statement = nil
+			position++
+		case isPunctuation(string(character)):
+			statement = append(statement, ddlToken{string(character), false})
+			position++
+		default:
+			end := position
+
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("();,.'\"`[", runes[end]) {
+				end++
+			}
+
+			statement = append(statement, ddlToken{string(runes[position:end]), false})
+			position = end
+		}
+	}
+
+	if 0 != len(statement) {
+		statements = append(statements, statement)
+	}
+
+	return
+}
+
+// find returns the position of `target` within `runes` from `position`, or -1.
+func find(runes []rune, position int, target string) int {
+	var sought = []rune(target)
+
+	for ; position+len(sought) <= len(runes); position++ {
+		if string(runes[position:position+len(sought)]) == target {
+			return position
+		}
+	}
+
+	return -1
+}
+
+// dollarQuote returns the length of the PostgreSQL dollar quote, such as
+// `$$` or `$body$`, beginning `runes`, or zero should there be none.
+func dollarQuote(runes []rune) int {
+	for end := 1; end < len(runes); end++ {
+		if '$' == runes[end] {
+			return end + 1
+		}
+
+		if !isWordRune(runes[end]) || unicode.IsDigit(runes[end]) && 1 == end {
+			return 0
+		}
+	}
+
+	return 0
+}
+
+// isPunctuation reports whether `text` is punctuation separating tokens.
+func isPunctuation(text string) bool {
+	return "(" == text || ")" == text || "," == text || "." == text
+}
+
+// isWord reports whether `text` is a bare identifier.
+func isWord(text string) bool {
+	for _, character := range text {
+		if !isWordRune(character) {
+			return false
+		}
+	}
+
+	return 0 != len(text)
+}
+
+// isWordRune reports whether `character` may be part of a bare identifier.
+func isWordRune(character rune) bool {
+	return '_' == character || unicode.IsLetter(character) || unicode.IsDigit(character)
+}
@@ -0,0 +1,175 @@
+package cartographer
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+const warehouseDDL = `
+-- Warehouses and their stock.
+CREATE TABLE IF NOT EXISTS "public"."warehouses" (
+	id bigserial PRIMARY KEY,
+	name varchar(255) NOT NULL DEFAULT 'unnamed; really',
+	city text,
+	capacity numeric(10, 2) DEFAULT NULL,
+	opened timestamp with time zone,
+	CONSTRAINT capacity_positive CHECK (capacity > 0 AND city IS NOT NULL)
+);
+
+/* Stock is keyed by warehouse and sku. */
+CREATE TABLE stock (
+	warehouse_id bigint REFERENCES warehouses (id),
+	` + "`sku`" + ` text,
+	tags text[],
+	quantity int unsigned NOT NULL,
+	PRIMARY KEY (warehouse_id, sku)
+);
+
+CREATE INDEX idx_city ON warehouses (city);
+
+CREATE FUNCTION touch() RETURNS trigger AS $$ BEGIN RETURN NEW; END; $$ LANGUAGE plpgsql;
+
+ALTER TABLE warehouses ADD COLUMN region text NOT NULL, DROP COLUMN IF EXISTS legacy, ALTER COLUMN city SET NOT NULL;
+ALTER TABLE stock RENAME COLUMN quantity TO on_hand;
+ALTER TABLE stock ALTER COLUMN on_hand TYPE bigint;
+`
+
+func TestParseDDL(t *testing.T) {
+	schema, err := ParseDDL(warehouseDDL)
+
+	if nil != err {
+		t.Fatalf("Basic ParseDDL test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(schema.Tables) || "warehouses" != schema.Tables[0].Name || "stock" != schema.Tables[1].Name {
+		t.Fatalf("Basic ParseDDL test returned unexpected tables: %+v", schema.Tables)
+	}
+
+	warehouses, _ := schema.Table("warehouses")
+
+	if 6 != len(warehouses.Columns) || "region" != warehouses.Columns[5].Name {
+		t.Errorf("Basic ParseDDL test returned unexpected warehouse columns: %+v", warehouses.Columns)
+	}
+
+	var expected = []Column{
+		{"id", "bigserial", false, true, true},
+		{"name", "varchar(255)", false, false, false},
+		{"city", "text", false, false, false},
+		{"capacity", "numeric(10,2)", true, false, false},
+		{"opened", "timestamp with time zone", true, false, false},
+	}
+
+	for index, column := range expected {
+		if column != warehouses.Columns[index] {
+			t.Errorf("Basic ParseDDL test expected column %+v, received %+v", column, warehouses.Columns[index])
+		}
+	}
+
+	stock, _ := schema.Table("STOCK")
+
+	if column, ok := stock.Column("sku"); !ok || !column.PrimaryKey || column.Nullable {
+		t.Errorf("Basic ParseDDL test returned unexpected sku column: %+v", column)
+	}
+
+	if column, ok := stock.Column("tags"); !ok || "text[]" != column.Type {
+		t.Errorf("Basic ParseDDL test returned unexpected tags column: %+v", column)
+	}
+
+	if column, ok := stock.Column("on_hand"); !ok || "bigint" != column.Type || column.Nullable {
+		t.Errorf("Basic ParseDDL test returned unexpected on_hand column: %+v", column)
+	}
+
+	if _, ok := stock.Column("quantity"); ok {
+		t.Errorf("Basic ParseDDL test expected the quantity column to be renamed")
+	}
+}
+
+func TestParseDDLErrors(t *testing.T) {
+	var statements = []string{
+		"CREATE TABLE a (id int); CREATE TABLE a (id int)",
+		"CREATE TABLE a (id int, id text)",
+		"ALTER TABLE missing ADD COLUMN id int",
+		"CREATE TABLE a (id int); ALTER TABLE a DROP COLUMN missing",
+		"CREATE TABLE a (id int); ALTER TABLE a RENAME COLUMN missing TO other",
+		"DROP TABLE missing",
+		"CREATE TABLE a (name text DEFAULT 'unterminated)",
+		"CREATE TABLE a (id int) /* unterminated",
+	}
+
+	for _, statement := range statements {
+		if _, err := ParseDDL(statement); nil == err {
+			t.Errorf("Basic ParseDDL test expected an error parsing %q", statement)
+		}
+	}
+
+	if schema, err := ParseDDL("DROP TABLE IF EXISTS missing; ALTER TABLE IF EXISTS missing ADD id int"); nil != err || 0 != len(schema.Tables) {
+		t.Errorf("Basic ParseDDL test returned unexpected schema: %+v, %v", schema, err)
+	}
+}
+
+func TestReadDDL(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/002_add_email.up.sql":      {Data: []byte("ALTER TABLE users ADD email text;")},
+		"migrations/001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id int PRIMARY KEY);")},
+		"migrations/001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	}
+
+	schema, err := ReadDDL(fsys, "migrations/*.up.sql")
+
+	if nil != err {
+		t.Fatalf("Basic ReadDDL test returned an unexpected error: %v", err)
+	}
+
+	if users, ok := schema.Table("users"); !ok || 2 != len(users.Columns) || "email" != users.Columns[1].Name {
+		t.Errorf("Basic ReadDDL test returned unexpected schema: %+v", schema)
+	}
+
+	fsys["migrations/003_broken.up.sql"] = &fstest.MapFile{Data: []byte("ALTER TABLE orders ADD id int;")}
+
+	if _, err = ReadDDL(fsys, "migrations/*.up.sql"); nil == err {
+		t.Errorf("Basic ReadDDL test expected an error reading a broken migration")
+	}
+}
+
+type warehouse struct {
+	Id       int64   `db:"id,pk"`
+	Name     string  `db:"name"`
+	Capacity float64 `db:"capacity"`
+	Zone     string  `db:"zone"`
+}
+
+func (self warehouse) TableName() string {
+	return "warehouses"
+}
+
+func TestCheckSchema(t *testing.T) {
+	schema, err := ParseDDL(warehouseDDL)
+
+	if nil != err {
+		t.Fatalf("Basic CheckSchema test returned an unexpected error: %v", err)
+	}
+
+	mapper := Initialize("db")
+	err = mapper.CheckSchema(schema, []string{"opened", "warehouses.region"}, warehouse{})
+
+	var mismatches SchemaMismatches
+
+	if !errors.As(err, &mismatches) || 2 != len(mismatches) {
+		t.Fatalf("Basic CheckSchema test expected 2 mismatches, received %v", err)
+	}
+
+	if "zone" != mismatches[0].Column || nil == mismatches[0].Type || "city" != mismatches[1].Column || nil != mismatches[1].Type {
+		t.Errorf("Basic CheckSchema test returned unexpected mismatches: %v", mismatches)
+	}
+
+	if err = mapper.CheckSchema(schema, nil, book{}); nil == err {
+		t.Errorf("Basic CheckSchema test expected an error checking a type whose table is not declared")
+	}
+
+	schema, _ = ParseDDL("CREATE TABLE warehouses (id int, name text, capacity real, zone text, notes text)")
+
+	if err = mapper.CheckSchema(schema, []string{"notes"}, warehouse{}); nil != err {
+		t.Errorf("Basic CheckSchema test returned unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package cartographer
+
+import (
+	"sync"
+)
+
+var (
+	defaultOnce sync.Once     // Guards the lazy creation of the default Cartographer.
+	defaultInst *Cartographer // The default Cartographer, see Default.
+)
+
+// Default returns the default Cartographer used by the package-level Map,
+// Sync and ColumnsFor functions, creating it with New upon first use
+// unless one has been set with SetDefault.
+func Default() *Cartographer {
+	defaultOnce.Do(func() {
+		if nil == defaultInst {
+			defaultInst = New()
+		}
+	})
+
+	return defaultInst
+}
+
+// SetDefault replaces the default Cartographer with `c`, such as one
+// created with New and options, and should be called before the
+// package-level functions are used.
+func SetDefault(c *Cartographer) {
+	defaultOnce.Do(func() {})
+	defaultInst = c
+}
+
+// Map behaves as the Default Cartographer's Map.
+func Map(rows ScannableRows, o interface{}, hooks ...Hook) ([]interface{}, error) {
+	return Default().Map(rows, o, hooks...)
+}
+
+// MapWith behaves as the Default Cartographer's MapWith.
+func MapWith(rows ScannableRows, o interface{}, opts ...CallOption) ([]interface{}, error) {
+	return Default().MapWith(rows, o, opts...)
+}
+
+// Sync behaves as the Default Cartographer's Sync.
+func Sync(rows ScannableRows, o interface{}, hooks ...Hook) error {
+	return Default().Sync(rows, o, hooks...)
+}
+
+// SyncWith behaves as the Default Cartographer's SyncWith.
+func SyncWith(rows ScannableRows, o interface{}, opts ...CallOption) error {
+	return Default().SyncWith(rows, o, opts...)
+}
+
+// ColumnsFor behaves as the Default Cartographer's ColumnsFor.
+func ColumnsFor(o interface{}) ([]interface{}, error) {
+	return Default().ColumnsFor(o)
+}
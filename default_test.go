@@ -0,0 +1,34 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	if Default() != Default() || "db" != Default().structTag {
+		t.Fatal("Basic Default test expected a single default Cartographer mapping through `db` tags")
+	}
+
+	previous := Default()
+	defer SetDefault(previous)
+
+	SetDefault(New(WithTag("pg")))
+
+	columns, err := ColumnsFor(dialected{})
+
+	if nil != err || 2 != len(columns) {
+		t.Errorf("Basic Default test returned unexpected columns: %v, %v", columns, err)
+	}
+
+	results, err := Map(&fakeRows{columns: []string{"full_name"}, values: [][]interface{}{{"ada"}}}, dialected{})
+
+	if nil != err || "ada" != results[0].(*dialected).Name {
+		t.Errorf("Basic Default test returned unexpected results: %v, %v", results, err)
+	}
+
+	var item dialected
+
+	if err = Sync(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{3}}}, &item); nil != err || 3 != item.Id {
+		t.Errorf("Basic Default test returned unexpected sync: %v, %v", item, err)
+	}
+}
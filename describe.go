@@ -0,0 +1,111 @@
+package cartographer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Describe returns a readable dump of the mapping of parameter `o`'s type,
+// tabulating each field's column, Go type, nullability, tag options and
+// conversions, being JSON encoding, the Transforms registered for its
+// column with RegisterTransform and the Converter registered for its type
+// with RegisterWriteConverter, followed by its relations, for diagnosing
+// fields left empty by a Map or Sync. An error is returned should `o` not
+// be a struct.
+func (self *Cartographer) Describe(o interface{}) (string, error) {
+	mapping, err := self.MappingFor(o)
+
+	if nil != err {
+		return "", err
+	}
+
+	table, err := self.TableFor(o)
+
+	if nil != err {
+		return "", err
+	}
+
+	var (
+		builder strings.Builder
+		writer  = tabwriter.NewWriter(&builder, 0, 4, 2, ' ', 0)
+		dumped  = mapping.dump()
+	)
+
+	fmt.Fprintf(&builder, "%s (table %s)\n", dumped.Type, table)
+	fmt.Fprintf(writer, "  FIELD\tCOLUMN\tTYPE\tNULLABLE\tOPTIONS\tCONVERSIONS\n")
+
+	for position, field := range dumped.Fields {
+		var mapped = self.fields[mapping.Type][position]
+
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\t%s\n", field.Name, field.Column, field.Type,
+			yesNo(self.isNullable(mapping.Type, mapped)), strings.Join(field.Options, ","), strings.Join(self.conversions(mapping.Type, mapped), ", "))
+	}
+
+	writer.Flush()
+
+	if 0 != len(dumped.Relations) {
+		builder.WriteString("  relations:\n")
+
+		for _, relation := range dumped.Relations {
+			fmt.Fprintf(&builder, "    %s %s %s (%s -> %s)", relation.Field, relation.Kind, relation.Type, relation.ForeignKey, relation.References)
+
+			if 0 != len(relation.JoinTable) {
+				fmt.Fprintf(&builder, " through %s", relation.JoinTable)
+			}
+
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// String returns the Describe dump of every cached type, ordered by type
+// name, such as for printing with fmt.Println while debugging.
+func (self *Cartographer) String() string {
+	var (
+		types        = make([]reflect.Type, 0, len(self.typeCache))
+		descriptions = make([]string, 0, len(self.typeCache))
+	)
+
+	for typ, _ := range self.typeCache {
+		types = append(types, typ)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+
+	for _, typ := range types {
+		described, err := self.Describe(reflect.New(typ).Interface())
+
+		if nil != err {
+			described = fmt.Sprintf("%v: %v\n", typ, err)
+		}
+
+		descriptions = append(descriptions, described)
+	}
+
+	return strings.Join(descriptions, "\n")
+}
+
+// conversions returns the conversions applied to the values of field
+// `mapped` of type `typ` between its column and field.
+func (self *Cartographer) conversions(typ reflect.Type, mapped *mappedField) (conversions []string) {
+	if self.isJSON(mapped) {
+		conversions = append(conversions, "json")
+	}
+
+	if transforms := len(self.transforms[typ][mapped.column]); 0 != transforms {
+		conversions = append(conversions, fmt.Sprintf("%d transform(s)", transforms))
+	}
+
+	if _, ok := self.writeConverters[mapped.typ]; ok {
+		conversions = append(conversions, "write converter")
+	}
+
+	return
+}
@@ -0,0 +1,47 @@
+package cartographer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	var c = New()
+
+	if err := c.RegisterTransform(consignment{}, "carrier", func(value interface{}) (interface{}, error) { return value, nil }); nil != err {
+		t.Fatalf("Basic Describe test returned an unexpected error: %v", err)
+	}
+
+	c.RegisterWriteConverter(time.Time{}, func(value interface{}) (interface{}, error) { return value, nil })
+
+	described, err := c.Describe(&consignment{})
+
+	if nil != err {
+		t.Fatalf("Basic Describe test returned an unexpected error: %v", err)
+	}
+
+	var expected = strings.Join([]string{
+		"cartographer.consignment (table shipments)",
+		"  FIELD    COLUMN      TYPE       NULLABLE  OPTIONS  CONVERSIONS",
+		"  ID       id          int64      no        pk       ",
+		"  Weight   weight      float64    no                 ",
+		"  Carrier  carrier     *string    yes                1 transform(s)",
+		"  Shipped  shipped_at  time.Time  no                 write converter",
+		"",
+	}, "\n")
+
+	if expected != described {
+		t.Errorf("Basic Describe test expected description:\n%s\nreceived:\n%s", expected, described)
+	}
+
+	if _, err = c.Describe(1); nil == err {
+		t.Error("Basic Describe test expected an error describing a non-struct")
+	}
+
+	c.DiscoverType(crate{})
+
+	if dumped := c.String(); !strings.HasPrefix(dumped, described+"\ncartographer.crate (table crate)\n") {
+		t.Errorf("Basic Describe test returned unexpected dump of every type:\n%s", dumped)
+	}
+}
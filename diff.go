@@ -0,0 +1,181 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Comparator reports whether values `a` and `b` of a field are equal
+// when diffing a struct against its previous state.
+type Comparator func(a, b interface{}) bool
+
+// SetComparator replaces the Comparator used when diffing, which defaults
+// to reflect.DeepEqual. Passing nil restores the default.
+func (self *Cartographer) SetComparator(comparator Comparator) {
+	self.comparator = comparator
+}
+
+// RegisterComparator registers Comparator `comparator` for comparing
+// field values of the same Go type as `example`, e.g. float64(0) or
+// time.Time{}, taking precedence over the Comparator set with
+// SetComparator.
+func (self *Cartographer) RegisterComparator(example interface{}, comparator Comparator) {
+	self.comparators[reflect.TypeOf(example)] = comparator
+}
+
+// FloatTolerance returns a Comparator treating floats within
+// `epsilon` of one another as equal.
+func FloatTolerance(epsilon float64) Comparator {
+	return func(a, b interface{}) bool {
+		return math.Abs(reflect.ValueOf(a).Float()-reflect.ValueOf(b).Float()) <= epsilon
+	}
+}
+
+// TimeTruncation returns a Comparator treating times as equal when
+// they are equal once truncated to a multiple of `duration`.
+func TimeTruncation(duration time.Duration) Comparator {
+	return func(a, b interface{}) bool {
+		return a.(time.Time).Truncate(duration).Equal(b.(time.Time).Truncate(duration))
+	}
+}
+
+// equal reports whether field values `a` and `b` are equal.
+func (self *Cartographer) equal(a, b interface{}) bool {
+	if typ := reflect.TypeOf(a); nil != typ && typ == reflect.TypeOf(b) {
+		if comparator, ok := self.comparators[typ]; ok {
+			return comparator(a, b)
+		}
+	}
+
+	if nil != self.comparator {
+		return self.comparator(a, b)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// deepCopy returns a copy of `value` sharing no slices, maps or
+// pointers with the original, so that in place modifications of
+// the original are not reflected in the copy. Unexported struct
+// fields are copied shallowly.
+func deepCopy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		duplicate := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+
+		for index := 0; index < value.Len(); index++ {
+			duplicate.Index(index).Set(deepCopy(value.Index(index)))
+		}
+
+		return duplicate
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		duplicate := reflect.MakeMapWithSize(value.Type(), value.Len())
+
+		for _, key := range value.MapKeys() {
+			duplicate.SetMapIndex(key, deepCopy(value.MapIndex(key)))
+		}
+
+		return duplicate
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+
+		duplicate := reflect.New(value.Type().Elem())
+		duplicate.Elem().Set(deepCopy(value.Elem()))
+
+		return duplicate
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		duplicate := reflect.New(value.Type()).Elem()
+		duplicate.Set(deepCopy(value.Elem()))
+
+		return duplicate
+	case reflect.Array:
+		duplicate := reflect.New(value.Type()).Elem()
+
+		for index := 0; index < value.Len(); index++ {
+			duplicate.Index(index).Set(deepCopy(value.Index(index)))
+		}
+
+		return duplicate
+	case reflect.Struct:
+		duplicate := reflect.New(value.Type()).Elem()
+		duplicate.Set(value)
+
+		for index := 0; index < value.NumField(); index++ {
+			if duplicate.Field(index).CanSet() {
+				duplicate.Field(index).Set(deepCopy(value.Field(index)))
+			}
+		}
+
+		return duplicate
+	}
+
+	return value
+}
+
+// Change describes a field whose value differs between two states of a struct.
+type Change struct {
+	Field  string      // Name of the field changed.
+	Column string      // Column the field is mapped to.
+	Old    interface{} // Value of the field before the change.
+	New    interface{} // Value of the field after the change.
+}
+
+// DiffFor returns the changes between structs `before` and `after`, in field
+// declaration order, or an error if either is not a struct or they are of
+// differing types. Values are taken and compared as ModifiedColumnsValuesMapFor
+// takes and compares them.
+func (self *Cartographer) DiffFor(before, after interface{}) (changes []Change, err error) {
+	typ, err := self.DiscoverType(before)
+
+	if nil != err {
+		return
+	}
+
+	if other, _ := self.DiscoverType(after); typ != other {
+		err = errors.New(fmt.Sprintf("DiffFor expected %v to be passed, received %T", typ, after))
+		return
+	}
+
+	old, err := self.fieldValues(typ, reflect.Indirect(reflect.ValueOf(before)), false)
+
+	if nil != err {
+		return
+	}
+
+	return self.diff(typ, old, after)
+}
+
+// diff returns the changes between the map of fields to values `old`
+// and the current values of `o`, a struct of type `typ`.
+func (self *Cartographer) diff(typ reflect.Type, old map[interface{}]interface{}, o interface{}) (changes []Change, err error) {
+	current, err := self.fieldValues(typ, reflect.Indirect(reflect.ValueOf(o)), false)
+
+	if nil != err {
+		return
+	}
+
+	for _, mapped := range self.fields[typ] {
+		if !self.equal(old[mapped.name], current[mapped.name]) {
+			changes = append(changes, Change{mapped.name, mapped.column, old[mapped.name], current[mapped.name]})
+		}
+	}
+
+	return
+}
@@ -0,0 +1,54 @@
+package cartographer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffFor(t *testing.T) {
+	var (
+		before = indexed{Id: 1, Email: "before@example.com"}
+		after  = indexed{Id: 1, Email: "after@example.com"}
+	)
+
+	changes, err := instance.DiffFor(before, &after)
+
+	if nil != err || 1 != len(changes) {
+		t.Fatalf("Basic DiffFor test returned an unexpected results: %v, %v", changes, err)
+	}
+
+	if change := changes[0]; "Email" != change.Field || "email" != change.Column || before.Email != change.Old || after.Email != change.New {
+		t.Errorf("Basic DiffFor test returned an unexpected change: %v", change)
+	}
+
+	if _, err = instance.DiffFor(before, faker{}); nil == err {
+		t.Errorf("Basic DiffFor test expected an error diffing differing types")
+	}
+}
+
+type measurement struct {
+	Value float64   `db:"value"`
+	At    time.Time `db:"at"`
+}
+
+func TestRegisterComparator(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		now          = time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)
+		before       = measurement{1.0, now}
+		after        = measurement{1.0 + 1e-9, now.Add(time.Millisecond)}
+	)
+
+	cartographer.RegisterComparator(float64(0), FloatTolerance(1e-6))
+	cartographer.RegisterComparator(time.Time{}, TimeTruncation(time.Second))
+
+	if changes, err := cartographer.DiffFor(before, after); nil != err || 0 != len(changes) {
+		t.Errorf("Basic RegisterComparator test returned an unexpected results: %v, %v", changes, err)
+	}
+
+	after.Value = 2.0
+
+	if changes, err := cartographer.DiffFor(before, after); nil != err || 1 != len(changes) || "value" != changes[0].Column {
+		t.Errorf("Basic RegisterComparator test returned an unexpected results: %v, %v", changes, err)
+	}
+}
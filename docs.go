@@ -0,0 +1,202 @@
+package cartographer
+
+import (
+	"database/sql"
+	"fmt"
+	"html/template"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// typeDoc is the documentation of a cached type's mapping.
+type typeDoc struct {
+	Type      string         // Name of the type.
+	Table     string         // Table the type is stored in.
+	Fields    []fieldDoc     // Mapped fields, in declaration order.
+	Relations []relationDump // Relations declared through `rel` tags.
+}
+
+// fieldDoc is the documentation of a single mapped field.
+type fieldDoc struct {
+	Name     string // Name of the struct field.
+	Column   string // Database column the field is mapped to.
+	Type     string // Go type of the field.
+	Nullable bool   // May the column hold NULL?
+	Options  string // Options following the column name in the field's tag.
+	Rules    string // Validation rules declared through the field's `validate` tag.
+}
+
+// MarkdownMappings writes a data dictionary of every cached type to
+// writer `w` as Markdown, a section per type ordered by type name
+// tabulating its table, fields, columns, Go types, nullability, options,
+// validation rules and relations, returning any error encountered.
+// Generated from the mappings themselves, it can not go stale.
+func (self *Cartographer) MarkdownMappings(w io.Writer) error {
+	docs, err := self.mappingDocs()
+
+	if nil != err {
+		return err
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString("# Mappings\n")
+
+	for _, doc := range docs {
+		fmt.Fprintf(&builder, "\n## %s\n\nTable `%s`.\n\n", doc.Type, doc.Table)
+		builder.WriteString("| Field | Column | Type | Nullable | Options | Rules |\n| --- | --- | --- | --- | --- | --- |\n")
+
+		for _, field := range doc.Fields {
+			markdownRow(&builder, field.Name, field.Column, field.Type, yesNo(field.Nullable), field.Options, field.Rules)
+		}
+
+		if 0 == len(doc.Relations) {
+			continue
+		}
+
+		builder.WriteString("\n### Relations\n\n| Field | Kind | Type | Foreign key | References | Join table |\n| --- | --- | --- | --- | --- | --- |\n")
+
+		for _, relation := range doc.Relations {
+			markdownRow(&builder, relation.Field, string(relation.Kind), relation.Type, relation.ForeignKey, relation.References, relation.JoinTable)
+		}
+	}
+
+	_, err = io.WriteString(w, builder.String())
+
+	return err
+}
+
+// HTMLMappings behaves as MarkdownMappings, writing the data dictionary
+// as an HTML document.
+func (self *Cartographer) HTMLMappings(w io.Writer) error {
+	docs, err := self.mappingDocs()
+
+	if nil != err {
+		return err
+	}
+
+	return htmlMappings.Execute(w, docs)
+}
+
+// htmlMappings is the template HTMLMappings renders.
+var htmlMappings = template.Must(template.New("mappings").Funcs(template.FuncMap{"yesNo": yesNo}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Mappings</title></head>
+<body>
+<h1>Mappings</h1>
+{{- range .}}
+<h2 id="{{.Type}}">{{.Type}}</h2>
+<p>Table <code>{{.Table}}</code>.</p>
+<table>
+<tr><th>Field</th><th>Column</th><th>Type</th><th>Nullable</th><th>Options</th><th>Rules</th></tr>
+{{- range .Fields}}
+<tr><td>{{.Name}}</td><td>{{.Column}}</td><td>{{.Type}}</td><td>{{yesNo .Nullable}}</td><td>{{.Options}}</td><td>{{.Rules}}</td></tr>
+{{- end}}
+</table>
+{{- if .Relations}}
+<h3>Relations</h3>
+<table>
+<tr><th>Field</th><th>Kind</th><th>Type</th><th>Foreign key</th><th>References</th><th>Join table</th></tr>
+{{- range .Relations}}
+<tr><td>{{.Field}}</td><td>{{.Kind}}</td><td>{{.Type}}</td><td>{{.ForeignKey}}</td><td>{{.References}}</td><td>{{.JoinTable}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// mappingDocs returns the documentation of every cached type, ordered by type name.
+func (self *Cartographer) mappingDocs() (docs []typeDoc, err error) {
+	for typ, _ := range self.typeCache {
+		var (
+			o      = reflect.New(typ).Interface()
+			doc    typeDoc
+			dumped typeMappingDump
+		)
+
+		mapping, err := self.MappingFor(o)
+
+		if nil != err {
+			return nil, err
+		}
+
+		if doc.Table, err = self.TableFor(o); nil != err {
+			return nil, err
+		}
+
+		dumped = mapping.dump()
+		doc.Type = dumped.Type
+		doc.Relations = dumped.Relations
+
+		for position, field := range mapping.Fields {
+			var rules []string
+
+			for _, rule := range field.Rules {
+				rules = append(rules, rule.String())
+			}
+
+			doc.Fields = append(doc.Fields, fieldDoc{
+				Name:     field.Name,
+				Column:   field.Column,
+				Type:     dumped.Fields[position].Type,
+				Nullable: self.isNullable(typ, self.fields[typ][position]),
+				Options:  strings.Join(dumped.Fields[position].Options, ", "),
+				Rules:    strings.Join(rules, ", "),
+			})
+		}
+
+		docs = append(docs, doc)
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Type < docs[j].Type
+	})
+
+	return
+}
+
+// scannerType is the reflect.Type of the sql.Scanner interface.
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// isNullable reports whether the column of field `mapped` of type `typ`
+// may hold NULL: its field must be able to hold nil, as pointers, maps,
+// slices and interfaces can, or scan NULL itself, as sql.NullString and
+// other sql.Scanners can, and the column must not be declared NOT NULL,
+// see RegisterNotNull.
+func (self *Cartographer) isNullable(typ reflect.Type, mapped *mappedField) bool {
+	if self.isNotNull(typ, mapped) {
+		return false
+	}
+
+	switch mapped.typ.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return true
+	}
+
+	return reflect.PointerTo(mapped.typ).Implements(scannerType) || mapped.options.Has("nullable")
+}
+
+// markdownRow writes a row of Markdown table `cells` to `builder`,
+// escaping any pipes within them.
+func markdownRow(builder *strings.Builder, cells ...string) {
+	builder.WriteString("|")
+
+	for _, cell := range cells {
+		fmt.Fprintf(builder, " %s |", strings.ReplaceAll(cell, "|", `\|`))
+	}
+
+	builder.WriteString("\n")
+}
+
+// yesNo returns `yes` should `value` be true, else `no`.
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
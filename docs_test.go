@@ -0,0 +1,79 @@
+package cartographer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownMappings(t *testing.T) {
+	mapper := Initialize("db")
+	mapper.DiscoverType(review{})
+	mapper.DiscoverType(signup{})
+
+	var builder strings.Builder
+
+	if err := mapper.MarkdownMappings(&builder); nil != err {
+		t.Fatalf("Basic Markdown test returned an unexpected error: %v", err)
+	}
+
+	document := builder.String()
+
+	for _, expected := range []string{
+		"# Mappings\n\n## cartographer.review\n\nTable `review`.\n",
+		"| Id | id | int | no |  |  |\n",
+		"| ReviewerId | reviewer_id | *int | yes |  |  |\n",
+		"### Relations\n",
+		"| Reviewer | belongs_to | cartographer.reviewer | reviewer_id | id |  |\n",
+		"## cartographer.signup\n",
+	} {
+		if !strings.Contains(document, expected) {
+			t.Errorf("Basic Markdown test expected %q within Markdown:\n%s", expected, document)
+		}
+	}
+
+	if strings.Index(document, "cartographer.review\n") > strings.Index(document, "cartographer.signup\n") {
+		t.Errorf("Basic Markdown test expected types to be ordered by name")
+	}
+}
+
+func TestHTMLMappings(t *testing.T) {
+	mapper := Initialize("db")
+	mapper.DiscoverType(review{})
+	mapper.RegisterTable(review{}, "<reviews>")
+
+	var builder strings.Builder
+
+	if err := mapper.HTMLMappings(&builder); nil != err {
+		t.Fatalf("Basic HTML test returned an unexpected error: %v", err)
+	}
+
+	document := builder.String()
+
+	for _, expected := range []string{
+		"<h2 id=\"cartographer.review\">cartographer.review</h2>",
+		"<p>Table <code>&lt;reviews&gt;</code>.</p>",
+		"<tr><td>ReviewerId</td><td>reviewer_id</td><td>*int</td><td>yes</td><td></td><td></td></tr>",
+		"<h3>Relations</h3>",
+	} {
+		if !strings.Contains(document, expected) {
+			t.Errorf("Basic HTML test expected %q within HTML:\n%s", expected, document)
+		}
+	}
+}
+
+func TestIsNullable(t *testing.T) {
+	mapper := Initialize("db")
+	typ, _ := mapper.DiscoverType(biography{})
+
+	for column, expected := range map[string]bool{"bio": true, "website": true, "avatar": true} {
+		if nullable := mapper.isNullable(typ, mapper.byColumn[typ][column]); expected != nullable {
+			t.Errorf("Basic IsNullable test expected %s nullable to be %v", column, expected)
+		}
+	}
+
+	mapper.RegisterNotNull(biography{}, "bio")
+
+	if mapper.isNullable(typ, mapper.byColumn[typ]["bio"]) {
+		t.Errorf("Basic IsNullable test expected a NOT NULL column not to be nullable")
+	}
+}
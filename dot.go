@@ -0,0 +1,108 @@
+package cartographer
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExportDOT writes a Graphviz DOT graph of every cached type, and every type
+// reachable from them through relations, to writer `w`, returning any error
+// encountered mapping the types or writing. Each type is drawn as a record
+// of its table and columns, primary keys marked `pk`, and each relation as
+// an edge from its owner to the related type labeled with its kind and
+// foreign key, or join table for many2many relations. Render the graph
+// with, for example, `dot -Tsvg`.
+func (self *Cartographer) ExportDOT(w io.Writer) error {
+	var (
+		cached []reflect.Type
+		graph  = make(map[reflect.Type][]Relation)
+		types  []reflect.Type
+	)
+
+	for typ, _ := range self.typeCache {
+		cached = append(cached, typ)
+	}
+
+	for _, typ := range cached {
+		reachable, err := self.RelationGraph(reflect.New(typ).Interface())
+
+		if nil != err {
+			return err
+		}
+
+		for related, relations := range reachable {
+			graph[related] = relations
+		}
+	}
+
+	for typ, _ := range graph {
+		types = append(types, typ)
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+
+	var builder strings.Builder
+
+	builder.WriteString("digraph cartographer {\n\tnode [shape=record];\n")
+
+	for _, typ := range types {
+		table, err := self.TableFor(reflect.New(typ).Interface())
+
+		if nil != err {
+			return err
+		}
+
+		var columns []string
+
+		for _, mapped := range self.fields[typ] {
+			if mapped.options.Has("pk") {
+				columns = append(columns, escapeRecord(mapped.column)+" (pk)\\l")
+			} else {
+				columns = append(columns, escapeRecord(mapped.column)+"\\l")
+			}
+		}
+
+		fmt.Fprintf(&builder, "\t%q [label=\"{%s|%s|%s}\"];\n", typ.String(), escapeRecord(typ.String()), escapeRecord(table), strings.Join(columns, ""))
+	}
+
+	for _, typ := range types {
+		for _, relation := range graph[typ] {
+			var label = fmt.Sprintf("%s %s", relation.Field, relation.Kind)
+
+			if ManyToMany == relation.Kind {
+				label += " via " + relation.JoinTable
+			} else {
+				label += " on " + relation.ForeignKey
+			}
+
+			fmt.Fprintf(&builder, "\t%q -> %q [label=%q];\n", typ.String(), relation.Type.String(), label)
+		}
+	}
+
+	builder.WriteString("}\n")
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err
+}
+
+// escapeRecord escapes the characters of `text` special to the labels of
+// Graphviz record shapes.
+func escapeRecord(text string) string {
+	var builder strings.Builder
+
+	for _, character := range text {
+		if strings.ContainsRune(`{}|<>"\ `, character) {
+			builder.WriteRune('\\')
+		}
+
+		builder.WriteRune(character)
+	}
+
+	return builder.String()
+}
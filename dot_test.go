@@ -0,0 +1,36 @@
+package cartographer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	mapper := Initialize("db")
+	mapper.DiscoverType(review{})
+	mapper.RegisterTable(reviewer{}, "review|ers")
+
+	var builder strings.Builder
+
+	if err := mapper.ExportDOT(&builder); nil != err {
+		t.Fatalf("Basic ExportDOT test returned an unexpected error: %v", err)
+	}
+
+	expected := "digraph cartographer {\n" +
+		"\tnode [shape=record];\n" +
+		"\t\"cartographer.review\" [label=\"{cartographer.review|review|id\\lreviewer_id\\l}\"];\n" +
+		"\t\"cartographer.reviewer\" [label=\"{cartographer.reviewer|review\\|ers|id\\l}\"];\n" +
+		"\t\"cartographer.review\" -> \"cartographer.reviewer\" [label=\"Reviewer belongs_to on reviewer_id\"];\n" +
+		"}\n"
+
+	if expected != builder.String() {
+		t.Errorf("Basic ExportDOT test expected DOT:\n%s\nreceived:\n%s", expected, builder.String())
+	}
+
+	builder.Reset()
+	mapper.DiscoverType(warehouse{})
+
+	if err := mapper.ExportDOT(&builder); nil != err || !strings.Contains(builder.String(), "|warehouses|id (pk)\\lname\\l") {
+		t.Errorf("Basic ExportDOT test expected primary keys to be marked, received %v:\n%s", err, builder.String())
+	}
+}
@@ -0,0 +1,264 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaSnapshotVersion is the version of the SchemaSnapshot format
+// written by SnapshotSchema. CompareSchema refuses snapshots of later
+// versions, whose descriptions it may not understand.
+const SchemaSnapshotVersion = 1
+
+// SchemaSnapshot is a serializable description of the mappings of a set
+// of types, as returned by SnapshotSchema. Committed alongside the
+// code, it may be compared against with CompareSchema to detect changes
+// made to the mappings since, such as by a test run in CI.
+type SchemaSnapshot struct {
+	Version int            `json:"version"` // Version of the format, SchemaSnapshotVersion when snapshotted.
+	Types   []TypeSnapshot `json:"types"`   // Types mapped, ordered by name.
+}
+
+// TypeSnapshot describes the mapping of a single type within a SchemaSnapshot.
+type TypeSnapshot struct {
+	Type      string             `json:"type"`                // Name of the type, qualified by its package.
+	Table     string             `json:"table"`               // Table the type is stored in.
+	Columns   []ColumnSnapshot   `json:"columns"`             // Columns mapped, in field declaration order.
+	Relations []RelationSnapshot `json:"relations,omitempty"` // Relations declared through `rel` tags.
+}
+
+// ColumnSnapshot describes a mapped column within a TypeSnapshot.
+type ColumnSnapshot struct {
+	Column   string   `json:"column"`            // Name of the column.
+	Field    string   `json:"field"`             // Name of the field mapped to the column.
+	Type     string   `json:"type"`              // Go type of the field.
+	Nullable bool     `json:"nullable"`          // May the column hold NULL? See MarkdownMappings.
+	Options  []string `json:"options,omitempty"` // Options following the column name in the field's tag.
+}
+
+// RelationSnapshot describes a relation within a TypeSnapshot.
+type RelationSnapshot struct {
+	Field      string       `json:"field"`                // Name of the field holding the related structs.
+	Kind       RelationKind `json:"kind"`                 // Kind of the relation.
+	Type       string       `json:"type"`                 // Type of the related structs.
+	ForeignKey string       `json:"foreign_key"`          // Column holding the key of the relation.
+	References string       `json:"references"`           // Column referenced by the foreign key.
+	JoinTable  string       `json:"join_table,omitempty"` // Join table of many to many relations.
+}
+
+// SchemaChange is a change made to a mapping since a SchemaSnapshot, as
+// found by CompareSchema.
+type SchemaChange struct {
+	Type   string // Name of the type changed.
+	Column string // Column, or relation field, changed, empty for changes of the type as a whole.
+	Change string // Description of the change.
+}
+
+// Error describes the change.
+func (self SchemaChange) Error() string {
+	if 0 == len(self.Column) {
+		return fmt.Sprintf("%s: %s", self.Type, self.Change)
+	}
+
+	return fmt.Sprintf("%s.%s: %s", self.Type, self.Column, self.Change)
+}
+
+// SchemaDrift is the list of changes returned by CompareSchema.
+type SchemaDrift []SchemaChange
+
+// Error joins the description of each change.
+func (self SchemaDrift) Error() string {
+	var messages = make([]string, 0, len(self))
+
+	for _, change := range self {
+		messages = append(messages, change.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// SnapshotSchema returns a SchemaSnapshot of the mappings of the struct
+// types of `types`, ordered by type name, or an error should any of the
+// `types` not be a struct or its table not be determined. Encode it as
+// JSON to commit it.
+func (self *Cartographer) SnapshotSchema(types ...interface{}) (snapshot SchemaSnapshot, err error) {
+	defer self.hold()()
+
+	var seen = make(map[reflect.Type]bool)
+
+	snapshot.Version = SchemaSnapshotVersion
+	snapshot.Types = make([]TypeSnapshot, 0, len(types))
+
+	for _, o := range types {
+		var typ reflect.Type
+
+		if typ, err = self.DiscoverType(o); nil != err {
+			return
+		} else if seen[typ] {
+			continue
+		}
+
+		seen[typ] = true
+
+		var described = TypeSnapshot{Type: typ.String(), Columns: make([]ColumnSnapshot, 0, len(self.fields[typ]))}
+
+		if described.Table, err = self.TableFor(reflect.New(typ).Interface()); nil != err {
+			return
+		}
+
+		for _, mapped := range self.fields[typ] {
+			var options []string
+
+			for _, option := range mapped.options {
+				options = append(options, option.String())
+			}
+
+			described.Columns = append(described.Columns, ColumnSnapshot{mapped.column, mapped.name, mapped.typ.String(), self.isNullable(typ, mapped), options})
+		}
+
+		for _, relation := range self.relations[typ] {
+			described.Relations = append(described.Relations, RelationSnapshot{relation.Field, relation.Kind, relation.Type.String(), relation.ForeignKey, relation.References, relation.JoinTable})
+		}
+
+		snapshot.Types = append(snapshot.Types, described)
+	}
+
+	sort.Slice(snapshot.Types, func(i, j int) bool {
+		return snapshot.Types[i].Type < snapshot.Types[j].Type
+	})
+
+	return
+}
+
+// CompareSchema compares SchemaSnapshot `old`, such as one decoded from a
+// committed file, to a snapshot of the current mappings of the struct
+// types of `types`, returning the SchemaDrift found should they differ:
+// types added or removed, tables renamed, columns added or removed, and
+// columns whose field, type, nullability or options changed, along with
+// changed relations. An error is returned should `old` be of a later
+// version than SchemaSnapshotVersion, or the snapshot of `types` fail.
+func (self *Cartographer) CompareSchema(old SchemaSnapshot, types ...interface{}) error {
+	if SchemaSnapshotVersion < old.Version {
+		return errors.New(fmt.Sprintf("Schema snapshot version %d is later than supported version %d", old.Version, SchemaSnapshotVersion))
+	}
+
+	current, err := self.SnapshotSchema(types...)
+
+	if nil != err {
+		return err
+	}
+
+	var (
+		drift    SchemaDrift
+		previous = make(map[string]TypeSnapshot) // Types of `old`, keyed by name.
+		seen     = make(map[string]bool)         // Types of `old` still mapped.
+	)
+
+	for _, described := range old.Types {
+		previous[described.Type] = described
+	}
+
+	for _, described := range current.Types {
+		before, ok := previous[described.Type]
+
+		if !ok {
+			drift = append(drift, SchemaChange{described.Type, "", fmt.Sprintf("type added, stored in table %s", described.Table)})
+			continue
+		}
+
+		seen[described.Type] = true
+		drift = append(drift, compareTypes(before, described)...)
+	}
+
+	for _, described := range old.Types {
+		if !seen[described.Type] {
+			drift = append(drift, SchemaChange{described.Type, "", "type removed"})
+		}
+	}
+
+	if 0 != len(drift) {
+		return drift
+	}
+
+	return nil
+}
+
+// compareTypes returns the changes made to the mapping of a type from
+// `before` to `after`.
+func compareTypes(before TypeSnapshot, after TypeSnapshot) (drift SchemaDrift) {
+	var (
+		report = func(column string, format string, args ...interface{}) {
+			drift = append(drift, SchemaChange{after.Type, column, fmt.Sprintf(format, args...)})
+		}
+		columns   = make(map[string]ColumnSnapshot)   // Columns of `before`, keyed by name.
+		relations = make(map[string]RelationSnapshot) // Relations of `before`, keyed by field.
+	)
+
+	if before.Table != after.Table {
+		report("", "table changed from %s to %s", before.Table, after.Table)
+	}
+
+	for _, column := range before.Columns {
+		columns[column.Column] = column
+	}
+
+	for _, column := range after.Columns {
+		previous, ok := columns[column.Column]
+
+		if !ok {
+			report(column.Column, "column added, mapped by field %s of type %s", column.Field, column.Type)
+			continue
+		}
+
+		delete(columns, column.Column)
+
+		if previous.Field != column.Field {
+			report(column.Column, "field changed from %s to %s", previous.Field, column.Field)
+		}
+
+		if previous.Type != column.Type {
+			report(column.Column, "type changed from %s to %s", previous.Type, column.Type)
+		}
+
+		if previous.Nullable != column.Nullable {
+			report(column.Column, "nullable changed from %t to %t", previous.Nullable, column.Nullable)
+		}
+
+		if strings.Join(previous.Options, ",") != strings.Join(column.Options, ",") {
+			report(column.Column, "options changed from %q to %q", strings.Join(previous.Options, ","), strings.Join(column.Options, ","))
+		}
+	}
+
+	for _, column := range before.Columns {
+		if _, ok := columns[column.Column]; ok {
+			report(column.Column, "column removed")
+		}
+	}
+
+	for _, relation := range before.Relations {
+		relations[relation.Field] = relation
+	}
+
+	for _, relation := range after.Relations {
+		previous, ok := relations[relation.Field]
+
+		if !ok {
+			report(relation.Field, "relation added")
+		} else if previous != relation {
+			report(relation.Field, "relation changed from %+v to %+v", previous, relation)
+		}
+
+		delete(relations, relation.Field)
+	}
+
+	for _, relation := range before.Relations {
+		if _, ok := relations[relation.Field]; ok {
+			report(relation.Field, "relation removed")
+		}
+	}
+
+	return
+}
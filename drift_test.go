@@ -0,0 +1,86 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotSchema(t *testing.T) {
+	c := New()
+
+	if _, err := c.DiscoverType(invoice{}); nil != err {
+		t.Fatalf("Basic SnapshotSchema test returned an unexpected error: %v", err)
+	}
+
+	snapshot, err := c.SnapshotSchema(consignment{}, &consignment{})
+
+	if nil != err {
+		t.Fatalf("Basic SnapshotSchema test returned an unexpected error: %v", err)
+	}
+
+	if SchemaSnapshotVersion != snapshot.Version || 1 != len(snapshot.Types) || "shipments" != snapshot.Types[0].Table {
+		t.Fatalf("Basic SnapshotSchema test returned unexpected snapshot: %+v", snapshot)
+	}
+
+	if column := snapshot.Types[0].Columns[2]; "carrier" != column.Column || "*string" != column.Type || !column.Nullable {
+		t.Errorf("Basic SnapshotSchema test returned unexpected column: %+v", column)
+	}
+
+	encoded, err := json.Marshal(snapshot)
+
+	if nil != err {
+		t.Fatalf("Basic SnapshotSchema test returned an unexpected error: %v", err)
+	}
+
+	var decoded SchemaSnapshot
+
+	if err = json.Unmarshal(encoded, &decoded); nil != err {
+		t.Fatalf("Basic SnapshotSchema test returned an unexpected error: %v", err)
+	}
+
+	if err = c.CompareSchema(decoded, consignment{}); nil != err {
+		t.Errorf("Basic SnapshotSchema test expected no drift, received %v", err)
+	}
+}
+
+func TestCompareSchema(t *testing.T) {
+	c := New()
+
+	old, err := c.SnapshotSchema(consignment{})
+
+	if nil != err {
+		t.Fatalf("Basic CompareSchema test returned an unexpected error: %v", err)
+	}
+
+	old.Types[0].Columns[1].Type = "string"
+	old.Types[0].Columns = append(old.Types[0].Columns[:2], old.Types[0].Columns[3:]...)
+	old.Types[0].Columns = append(old.Types[0].Columns, ColumnSnapshot{Column: "legacy", Field: "Legacy", Type: "int"})
+	old.Types = append(old.Types, TypeSnapshot{Type: "cartographer.removed", Table: "removed"})
+
+	err = c.CompareSchema(old, consignment{})
+	drift, ok := err.(SchemaDrift)
+
+	if !ok {
+		t.Fatalf("Basic CompareSchema test expected SchemaDrift, received %v", err)
+	}
+
+	var expected = []string{
+		"cartographer.consignment.weight: type changed from string to float64",
+		"cartographer.consignment.carrier: column added, mapped by field Carrier of type *string",
+		"cartographer.consignment.legacy: column removed",
+		"cartographer.removed: type removed",
+	}
+
+	if strings.Join(expected, "; ") != drift.Error() {
+		t.Errorf("Basic CompareSchema test expected drift %s, received %s", strings.Join(expected, "; "), drift.Error())
+	}
+
+	if err = c.CompareSchema(SchemaSnapshot{Version: SchemaSnapshotVersion + 1}); !strings.Contains(err.Error(), "version") {
+		t.Errorf("Basic CompareSchema test expected a version error, received %v", err)
+	}
+
+	if _, err = c.SnapshotSchema("consignment"); nil == err {
+		t.Error("Basic SnapshotSchema test expected an error snapshotting a non-struct")
+	}
+}
@@ -0,0 +1,136 @@
+package cartographer
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// fakeResult is the result the fake driver returns for a query.
+type fakeResult struct {
+	columns  []string
+	rows     [][]driver.Value
+	affected int64
+	insertId int64
+}
+
+// fakeStatement records a statement executed through the fake driver.
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+}
+
+var (
+	fakeResults    = make(map[string]fakeResult) // Results returned by the fake driver, keyed by query.
+	fakeStatements []fakeStatement               // Statements executed through the fake driver.
+)
+
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct {
+	query string
+}
+
+type fakeDriverRows struct {
+	result fakeResult
+	index  int
+}
+
+func init() {
+	sql.Register("cartographer", fakeDriver{})
+}
+
+// openFake opens a database backed by the fake driver, resetting its
+// results and recorded statements.
+func openFake(results map[string]fakeResult) *sql.DB {
+	fakeResults = results
+	fakeStatements = nil
+
+	db, _ := sql.Open("cartographer", "")
+
+	return db
+}
+
+func (self fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{}, nil
+}
+
+func (self fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{query}, nil
+}
+
+func (self fakeConn) Close() error {
+	return nil
+}
+
+func (self fakeConn) Begin() (driver.Tx, error) {
+	return self, nil
+}
+
+func (self fakeConn) BeginTx(ctx context.Context, options driver.TxOptions) (driver.Tx, error) {
+	return self, nil
+}
+
+func (self fakeConn) Commit() error {
+	return nil
+}
+
+func (self fakeConn) Rollback() error {
+	return nil
+}
+
+func (self fakeStmt) Close() error {
+	return nil
+}
+
+func (self fakeStmt) NumInput() int {
+	return -1
+}
+
+func (self fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeStatements = append(fakeStatements, fakeStatement{self.query, args})
+	return fakeExecResult(fakeResults[self.query]), nil
+}
+
+// fakeExecResult is the driver.Result of a statement executed through the fake driver.
+type fakeExecResult fakeResult
+
+func (self fakeExecResult) LastInsertId() (int64, error) {
+	if 0 == self.insertId {
+		return 0, errors.New("LastInsertId is not supported")
+	}
+
+	return self.insertId, nil
+}
+
+func (self fakeExecResult) RowsAffected() (int64, error) {
+	return self.affected, nil
+}
+
+func (self fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	fakeStatements = append(fakeStatements, fakeStatement{self.query, args})
+	return &fakeDriverRows{result: fakeResults[self.query]}, nil
+}
+
+func (self *fakeDriverRows) Columns() []string {
+	return self.result.columns
+}
+
+func (self *fakeDriverRows) Close() error {
+	return nil
+}
+
+func (self *fakeDriverRows) Next(dest []driver.Value) error {
+	if self.index >= len(self.result.rows) {
+		return io.EOF
+	}
+
+	copy(dest, self.result.rows[self.index])
+	self.index++
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package cartographer
+
+import (
+	"reflect"
+)
+
+// EventKind identifies the mapping activity an Event describes.
+type EventKind int
+
+const (
+	OnTypeDiscovered   EventKind = iota // A type was discovered and cached for the first time.
+	OnRowMapped                         // A row was mapped into a new struct by Map.
+	OnSyncComplete                      // Sync finished syncing rows into a struct.
+	OnConversionError                   // A scanned value could not be set on a field.
+	OnSyncMultipleRows                  // Sync read more than a single row, see SetSyncMultipleRows.
+	OnTypeEvicted                       // A type was evicted from a cache bounded by SetCacheSize.
+	OnFieldSet                          // A scanned value was set on a field.
+	OnValueCoerced                      // A scanned value was converted to its field's type before being set, following OnFieldSet.
+	OnColumnSkipped                     // A column of a row was skipped, mapping no field.
+	OnNullEncountered                   // A column of a row was NULL.
+)
+
+// Event describes mapping activity published to subscribed Listeners.
+type Event struct {
+	Kind   EventKind     // Kind of the event.
+	Type   reflect.Type  // Struct type the event concerns.
+	Value  reflect.Value // Pointer to the struct mapped or synced, if any.
+	Column string        // Column that failed conversion, was set, skipped or NULL, if any.
+	Field  string        // Field the column is mapped to, if any.
+	Row    int           // Index of the row the column was read from, for events concerning columns.
+	Err    error         // Error that occurred, if any.
+}
+
+// Listener receives Events it has been subscribed to.
+type Listener func(Event)
+
+// Subscription identifies a subscribed Listener so it may later be unsubscribed.
+type Subscription int
+
+type subscriber struct {
+	subscription Subscription
+	listener     Listener
+}
+
+// Subscribe subscribes Listener `listener` to events of kind `kind`,
+// returning a Subscription to pass to Unsubscribe.
+func (self *Cartographer) Subscribe(kind EventKind, listener Listener) Subscription {
+	self.subscriptions++
+	self.listeners[kind] = append(self.listeners[kind], subscriber{self.subscriptions, listener})
+
+	return self.subscriptions
+}
+
+// Unsubscribe removes the Listener subscribed with `subscription`.
+func (self *Cartographer) Unsubscribe(subscription Subscription) {
+	for kind, subscribers := range self.listeners {
+		for index, subscriber := range subscribers {
+			if subscription == subscriber.subscription {
+				self.listeners[kind] = append(subscribers[:index:index], subscribers[index+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publish delivers Event `event` to each Listener subscribed to its kind.
+func (self *Cartographer) publish(event Event) {
+	for _, subscriber := range self.listeners[event.Kind] {
+		subscriber.listener(event)
+	}
+}
@@ -0,0 +1,76 @@
+package cartographer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSubscribe(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		discovered   int
+		mapped       int
+	)
+
+	cartographer.Subscribe(OnTypeDiscovered, func(event Event) {
+		discovered++
+	})
+
+	subscription := cartographer.Subscribe(OnRowMapped, func(event Event) {
+		mapped++
+	})
+
+	cartographer.Map(&scanner{}, faker{})
+	cartographer.Map(&scanner{}, faker{})
+
+	if 1 != discovered || 2 != mapped {
+		t.Errorf("Basic Subscribe test received unexpected events: %d discovered, %d mapped", discovered, mapped)
+	}
+
+	cartographer.Unsubscribe(subscription)
+	cartographer.Map(&scanner{}, faker{})
+
+	if 2 != mapped {
+		t.Errorf("Basic Unsubscribe test received unexpected events: %d mapped", mapped)
+	}
+}
+
+func TestConversionEvents(t *testing.T) {
+	var (
+		c        = New()
+		received []string
+		record   = func(event Event) {
+			received = append(received, fmt.Sprintf("%d %s %s %d", event.Kind, event.Column, event.Field, event.Row))
+		}
+		rows = &fakeRows{
+			columns: []string{"id", "weight", "carrier", "extra"},
+			values:  [][]interface{}{{int64(1), "1.5", nil, 1}, {int32(2), 2.5, "post", 2}},
+		}
+	)
+
+	for _, kind := range []EventKind{OnFieldSet, OnValueCoerced, OnColumnSkipped, OnNullEncountered} {
+		c.Subscribe(kind, record)
+	}
+
+	if _, err := c.Map(rows, consignment{}); nil != err {
+		t.Fatalf("Basic conversion event test returned an unexpected error: %v", err)
+	}
+
+	var expected = []string{
+		fmt.Sprintf("%d id ID 0", OnFieldSet),
+		fmt.Sprintf("%d weight Weight 0", OnFieldSet),
+		fmt.Sprintf("%d weight Weight 0", OnValueCoerced),
+		fmt.Sprintf("%d carrier Carrier 0", OnNullEncountered),
+		fmt.Sprintf("%d extra  0", OnColumnSkipped),
+		fmt.Sprintf("%d id ID 1", OnFieldSet),
+		fmt.Sprintf("%d id ID 1", OnValueCoerced),
+		fmt.Sprintf("%d weight Weight 1", OnFieldSet),
+		fmt.Sprintf("%d carrier Carrier 1", OnFieldSet),
+		fmt.Sprintf("%d extra  1", OnColumnSkipped),
+	}
+
+	if strings.Join(expected, "\n") != strings.Join(received, "\n") {
+		t.Errorf("Basic conversion event test expected events:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(received, "\n"))
+	}
+}
@@ -0,0 +1,89 @@
+package cartographer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// WriteCSV writes `items`, structs or pointers to structs of a single type,
+// to `w` as CSV, so exports share the column names and conversions of the
+// database layer. A header of the type's columns, in field declaration
+// order, is followed by a row of each item's ColumnValueMapFor values. NULL
+// values are written as empty fields, times in RFC 3339 format. Nothing is
+// written should `items` be empty.
+func (self *Cartographer) WriteCSV(w io.Writer, items []interface{}) (err error) {
+	if 0 == len(items) {
+		return
+	}
+
+	typ, err := self.DiscoverType(items[0])
+
+	if nil != err {
+		return
+	}
+
+	var (
+		writer = csv.NewWriter(w)
+		header []string
+	)
+
+	for _, mapped := range self.fields[typ] {
+		header = append(header, mapped.column)
+	}
+
+	if err = writer.Write(header); nil != err {
+		return
+	}
+
+	for index, item := range items {
+		if indirectType(reflect.TypeOf(item)) != typ {
+			return errors.New(fmt.Sprintf("WriteCSV expected %v at index %d, received %T", typ, index, item))
+		}
+
+		values, err := self.columnValues(item, false)
+
+		if nil != err {
+			return err
+		}
+
+		var record = make([]string, len(header))
+
+		for position, column := range header {
+			record[position] = csvField(values[column])
+		}
+
+		if err = writer.Write(record); nil != err {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// csvField formats converted `value` as a CSV field, dereferencing pointers.
+func csvField(value interface{}) string {
+	if pointer := reflect.ValueOf(value); reflect.Ptr == pointer.Kind() {
+		if pointer.IsNil() {
+			return ""
+		}
+
+		return csvField(pointer.Elem().Interface())
+	}
+
+	switch value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value.([]byte))
+	case time.Time:
+		return value.(time.Time).Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,47 @@
+package cartographer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type shipment struct {
+	Id      int       `db:"id"`
+	Carrier string    `db:"carrier"`
+	Sent    time.Time `db:"sent"`
+	Note    *string   `db:"note"`
+}
+
+func TestWriteCSV(t *testing.T) {
+	var (
+		buffer bytes.Buffer
+		note   = "fragile, handle with care"
+		sent   = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	items := []interface{}{
+		shipment{1, "post", sent, &note},
+		&shipment{Id: 2, Carrier: "courier", Sent: sent},
+	}
+
+	if err := Initialize("db").WriteCSV(&buffer, items); nil != err {
+		t.Fatalf("Basic WriteCSV test returned an unexpected error: %v", err)
+	}
+
+	expected := "id,carrier,sent,note\n" +
+		"1,post,2024-01-02T03:04:05Z,\"fragile, handle with care\"\n" +
+		"2,courier,2024-01-02T03:04:05Z,\n"
+
+	if expected != buffer.String() {
+		t.Errorf("Basic WriteCSV test expected %q, received %q", expected, buffer.String())
+	}
+}
+
+func TestWriteCSVMixedTypes(t *testing.T) {
+	var buffer bytes.Buffer
+
+	if err := Initialize("db").WriteCSV(&buffer, []interface{}{shipment{}, faker{}}); nil == err {
+		t.Error("Basic WriteCSV test expected an error writing items of differing types")
+	}
+}
@@ -0,0 +1,179 @@
+// Package fixtures loads test fixtures into structs through cartographer's
+// column mappings. A fixture file is an object keyed by table name, each
+// table holding a list of records keyed by column:
+//
+//	{"users": [{"id": 1, "name": "ada"}], "posts": [{"id": 1, "user_id": 1}]}
+//
+// Files are decoded with encoding/json by default; pass yaml.Unmarshal, from
+// gopkg.in/yaml.v3 or a compatible package, to New to load YAML fixtures.
+package fixtures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+	"github.com/chuckpreslar/cartographer/maprows"
+)
+
+// Unmarshal decodes fixture file contents into the value pointed to by
+// its second argument, as json.Unmarshal and yaml.Unmarshal do.
+type Unmarshal func([]byte, interface{}) error
+
+// Fixtures are the structs hydrated from a fixture file, keyed by table.
+type Fixtures map[string][]interface{}
+
+// Statement is an INSERT statement seeding a single fixture.
+type Statement struct {
+	SQL  string        // SQL of the statement, with `?` placeholders.
+	Args []interface{} // Arguments of the statement's placeholders.
+}
+
+// Loader hydrates fixtures into the struct types registered for their tables.
+type Loader struct {
+	mapper    *cartographer.Cartographer // Cartographer mapping records to structs.
+	unmarshal Unmarshal                  // Decoder of fixture files.
+	tables    []string                   // Registered tables, in registration order.
+	types     map[string]interface{}     // Example struct registered for each table.
+}
+
+// New returns a pointer to a Loader hydrating fixtures through `mapper`,
+// decoding files with `unmarshal`, or json.Unmarshal if it is nil.
+func New(mapper *cartographer.Cartographer, unmarshal Unmarshal) *Loader {
+	if nil == unmarshal {
+		unmarshal = json.Unmarshal
+	}
+
+	return &Loader{mapper: mapper, unmarshal: unmarshal, types: make(map[string]interface{})}
+}
+
+// Register registers the type of each of the `examples` for the table
+// returned by the mapper's TableFor, or returns an error if an example
+// is not a struct.
+func (self *Loader) Register(examples ...interface{}) error {
+	for _, example := range examples {
+		table, err := self.mapper.TableFor(example)
+
+		if nil != err {
+			return err
+		}
+
+		if _, ok := self.types[table]; !ok {
+			self.tables = append(self.tables, table)
+		}
+
+		self.types[table] = example
+	}
+
+	return nil
+}
+
+// LoadFile loads the fixture file at `path`, see Load.
+func (self *Loader) LoadFile(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return self.Load(data)
+}
+
+// Load decodes fixture file contents `data`, hydrating each record into
+// a pointer to the struct type registered for its table, or returns an
+// error should a table have no registered type or a record fail to map.
+func (self *Loader) Load(data []byte) (fixtures Fixtures, err error) {
+	var tables map[string][]map[string]interface{}
+
+	if err = self.unmarshal(data, &tables); nil != err {
+		return
+	}
+
+	fixtures = make(Fixtures)
+
+	for table, records := range tables {
+		example, ok := self.types[table]
+
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("No type registered for table %s", table))
+		}
+
+		for _, record := range records {
+			if err = encodeNested(record); nil != err {
+				return nil, err
+			}
+		}
+
+		if fixtures[table], err = self.mapper.Map(maprows.From(records), example); nil != err {
+			return nil, errors.New(fmt.Sprintf("%s in table %s", err, table))
+		}
+	}
+
+	return
+}
+
+// Inserts returns an INSERT statement for each of the `fixtures`, tables
+// in the order their types were registered so that parents registered
+// before their children are inserted first, records in file order and
+// columns sorted by name.
+func (self *Loader) Inserts(fixtures Fixtures) (statements []Statement, err error) {
+	for _, table := range self.tables {
+		for _, item := range fixtures[table] {
+			values, err := self.mapper.ColumnValueMapFor(item)
+
+			if nil != err {
+				return nil, err
+			}
+
+			var columns []string
+
+			for column, _ := range values {
+				columns = append(columns, column.(string))
+			}
+
+			sort.Strings(columns)
+
+			var statement Statement
+
+			for _, column := range columns {
+				statement.Args = append(statement.Args, values[column])
+			}
+
+			statement.SQL = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "),
+				strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+
+			statements = append(statements, statement)
+		}
+	}
+
+	return
+}
+
+// encodeNested re-encodes the nested objects and lists of `record` as
+// JSON, so that they may be decoded into fields tagged with the `json`
+// option as JSON columns are.
+func encodeNested(record map[string]interface{}) error {
+	for column, value := range record {
+		if nil == value {
+			continue
+		}
+
+		switch reflect.TypeOf(value).Kind() {
+		case reflect.Map, reflect.Slice:
+			encoded, err := json.Marshal(value)
+
+			if nil != err {
+				return errors.New(fmt.Sprintf("%s for column %s", err, column))
+			}
+
+			record[column] = encoded
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+type user struct {
+	Id   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+type post struct {
+	Id     int               `db:"id,pk"`
+	UserId int               `db:"user_id"`
+	Meta   map[string]string `db:"meta,json"`
+}
+
+func (self user) TableName() string { return "users" }
+
+func (self post) TableName() string { return "posts" }
+
+const document = `{
+	"posts": [{"id": 10, "user_id": 1, "meta": {"lang": "en"}}],
+	"users": [{"id": 1, "name": "ada"}, {"id": 2, "name": "bob"}]
+}`
+
+func loader(t *testing.T, unmarshal Unmarshal) *Loader {
+	loader := New(cartographer.Initialize("db"), unmarshal)
+
+	if err := loader.Register(user{}, post{}); nil != err {
+		t.Fatalf("Basic Register test returned an unexpected error: %v", err)
+	}
+
+	return loader
+}
+
+func TestLoad(t *testing.T) {
+	fixtures, err := loader(t, nil).Load([]byte(document))
+
+	if nil != err {
+		t.Fatalf("Basic Load test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(fixtures["users"]) || (user{2, "bob"}) != *fixtures["users"][1].(*user) {
+		t.Errorf("Basic Load test returned unexpected users: %v", fixtures["users"])
+	}
+
+	if p := fixtures["posts"][0].(*post); 10 != p.Id || 1 != p.UserId || "en" != p.Meta["lang"] {
+		t.Errorf("Basic Load test returned unexpected post: %v", p)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+
+	if err := os.WriteFile(path, []byte(document), 0600); nil != err {
+		t.Fatalf("Basic LoadFile test returned an unexpected error: %v", err)
+	}
+
+	var called bool
+
+	fixtures, err := loader(t, func(data []byte, o interface{}) error {
+		called = true
+		return json.Unmarshal(data, o)
+	}).LoadFile(path)
+
+	if nil != err || !called || 1 != len(fixtures["posts"]) {
+		t.Errorf("Basic LoadFile test returned an unexpected results: %v, %v", fixtures, err)
+	}
+}
+
+func TestLoadUnregistered(t *testing.T) {
+	if _, err := loader(t, nil).Load([]byte(`{"comments": [{"id": 1}]}`)); nil == err {
+		t.Error("Basic Load test expected an error loading an unregistered table")
+	}
+}
+
+func TestInserts(t *testing.T) {
+	l := loader(t, nil)
+	fixtures, _ := l.Load([]byte(document))
+	statements, err := l.Inserts(fixtures)
+
+	if nil != err || 3 != len(statements) {
+		t.Fatalf("Basic Inserts test returned an unexpected results: %v, %v", statements, err)
+	}
+
+	if expected := "INSERT INTO users (id, name) VALUES (?, ?)"; expected != statements[0].SQL {
+		t.Errorf("Basic Inserts test returned %q, expected %q", statements[0].SQL, expected)
+	}
+
+	if 2 != len(statements[1].Args) || "bob" != statements[1].Args[1] {
+		t.Errorf("Basic Inserts test returned unexpected arguments: %v", statements[1].Args)
+	}
+
+	if expected := "INSERT INTO posts (id, meta, user_id) VALUES (?, ?, ?)"; expected != statements[2].SQL {
+		t.Errorf("Basic Inserts test returned %q, expected %q", statements[2].SQL, expected)
+	}
+}
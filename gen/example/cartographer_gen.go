@@ -0,0 +1,329 @@
+// Code generated by cartographer-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// ScanColumn sets the field of the User mapped to `column` from the
+// scanned `value`, reporting whether the column is mapped.
+func (self *User) ScanColumn(column string, value interface{}) (bool, error) {
+	switch column {
+	case "id":
+		if nil != value {
+			integer, err := cartographer.AsInt(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			converted := integer
+
+			self.Base.ID = converted
+		}
+	case "created_at":
+		if nil != value {
+			if err := cartographer.Assign(&self.Base.Created, value); nil != err {
+				return true, err
+			}
+		}
+	case "name":
+		if nil != value {
+			converted := cartographer.AsString(value)
+
+			self.Name = converted
+		}
+	case "age":
+		if nil != value {
+			integer, err := cartographer.AsInt(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			converted := uint8(integer)
+
+			self.Age = converted
+		}
+	case "score":
+		if nil != value {
+			float, err := cartographer.AsFloat(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			converted := float
+
+			self.Score = &converted
+		}
+	case "admin":
+		if nil != value {
+			converted, err := cartographer.AsBool(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			self.Admin = converted
+		}
+	case "bio":
+		if nil != value {
+			if err := cartographer.Assign(&self.Bio, value); nil != err {
+				return true, err
+			}
+		}
+	case "verified_at":
+		if nil != value {
+			var converted time.Time
+
+			if err := cartographer.Assign(&converted, value); nil != err {
+				return true, err
+			}
+
+			self.Verified = &converted
+		}
+	case "revision":
+		if nil != value {
+			integer, err := cartographer.AsInt(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			converted := int(integer)
+
+			self.Revision = converted
+		}
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ColumnValues returns a map of the User's columns to their values.
+func (self *User) ColumnValues() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"id":          self.Base.ID,
+		"created_at":  self.Base.Created,
+		"name":        self.Name,
+		"age":         self.Age,
+		"score":       self.Score,
+		"admin":       self.Admin,
+		"bio":         self.Bio,
+		"verified_at": self.Verified,
+		"revision":    self.Revision,
+	}
+}
+
+// MapUser returns a pointer to a new User for each row of `rows`.
+func MapUser(rows cartographer.ScannableRows) ([]*User, error) {
+	return cartographer.MapScanned[User](rows)
+}
+
+// SyncUser sets the fields of `item` from the single row of `rows`.
+func SyncUser(rows cartographer.ScannableRows, item *User) error {
+	return cartographer.SyncScanned(rows, item)
+}
+
+// UserRepository performs the common CRUD operations against table users,
+// its SQL rendered when generated rather than built through reflection.
+type UserRepository struct {
+	db cartographer.Querier
+}
+
+// NewUserRepository returns a pointer to a UserRepository querying `db`.
+func NewUserRepository(db cartographer.Querier) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Find returns a pointer to the User identified by its primary keys, or
+// sql.ErrNoRows if there is no such row.
+func (self *UserRepository) Find(ctx context.Context, id int64) (*User, error) {
+	return self.one(ctx, "SELECT id, created_at, name, age, score, admin, bio, verified_at, revision FROM users WHERE id = ?", id)
+}
+
+// FindAll returns a pointer to each User in table users.
+func (self *UserRepository) FindAll(ctx context.Context) ([]*User, error) {
+	return self.query(ctx, "SELECT id, created_at, name, age, score, admin, bio, verified_at, revision FROM users")
+}
+
+// FindWhere returns a pointer to each User matching SQL condition `where`
+// bound to `args`.
+func (self *UserRepository) FindWhere(ctx context.Context, where string, args ...interface{}) ([]*User, error) {
+	return self.query(ctx, "SELECT id, created_at, name, age, score, admin, bio, verified_at, revision FROM users WHERE "+where, args...)
+}
+
+// FindByName returns a pointer to the User whose name is `name`, or
+// sql.ErrNoRows if there is no such row.
+func (self *UserRepository) FindByName(ctx context.Context, name string) (*User, error) {
+	return self.one(ctx, "SELECT id, created_at, name, age, score, admin, bio, verified_at, revision FROM users WHERE name = ?", name)
+}
+
+// FindByAge returns a pointer to each User whose age is `age`.
+func (self *UserRepository) FindByAge(ctx context.Context, age uint8) ([]*User, error) {
+	return self.query(ctx, "SELECT id, created_at, name, age, score, admin, bio, verified_at, revision FROM users WHERE age = ?", age)
+}
+
+// Insert inserts `item` into table users, setting its ID from the
+// key the database generates should it be zero.
+func (self *UserRepository) Insert(ctx context.Context, item *User) error {
+	now := time.Now()
+	item.Base.Created = now
+
+	if 0 != item.Base.ID {
+		_, err := self.db.ExecContext(ctx, "INSERT INTO users (id, created_at, name, age, score, admin, bio, verified_at, revision) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", item.Base.ID, item.Base.Created, item.Name, item.Age, item.Score, item.Admin, item.Bio, item.Verified, item.Revision)
+
+		return err
+	}
+
+	result, err := self.db.ExecContext(ctx, "INSERT INTO users (created_at, name, age, score, admin, bio, verified_at, revision) VALUES (?, ?, ?, ?, ?, ?, ?, ?)", item.Base.Created, item.Name, item.Age, item.Score, item.Admin, item.Bio, item.Verified, item.Revision)
+
+	if nil != err {
+		return err
+	}
+
+	key, err := result.LastInsertId()
+
+	if nil != err {
+		return nil // The driver can not report generated keys.
+	}
+
+	item.Base.ID = key
+
+	return nil
+}
+
+// Update updates the row identified by `item`'s primary keys, returning
+// a *cartographer.ErrStaleRow should no row at its revision be affected,
+// else incrementing its Revision.
+func (self *UserRepository) Update(ctx context.Context, item *User) error {
+	result, err := self.db.ExecContext(ctx, "UPDATE users SET created_at = ?, name = ?, age = ?, score = ?, admin = ?, bio = ?, verified_at = ?, revision = revision + 1 WHERE id = ? AND revision = ?", item.Base.Created, item.Name, item.Age, item.Score, item.Admin, item.Bio, item.Verified, item.Base.ID, item.Revision)
+
+	if err = affected(result, err); sql.ErrNoRows == err {
+		return &cartographer.ErrStaleRow{Type: reflect.TypeOf(*item), Version: item.Revision}
+	} else if nil != err {
+		return err
+	}
+
+	item.Revision++
+
+	return nil
+}
+
+// Delete deletes the row identified by `item`'s primary keys, returning
+// sql.ErrNoRows should no row be affected.
+func (self *UserRepository) Delete(ctx context.Context, item *User) error {
+	result, err := self.db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", item.Base.ID)
+
+	return affected(result, err)
+}
+
+// one returns a pointer to the first User returned by `query` bound to
+// `args`, or sql.ErrNoRows should none be returned.
+func (self *UserRepository) one(ctx context.Context, query string, args ...interface{}) (*User, error) {
+	items, err := self.query(ctx, query, args...)
+
+	if nil != err {
+		return nil, err
+	}
+
+	if 0 == len(items) {
+		return nil, sql.ErrNoRows
+	}
+
+	return items[0], nil
+}
+
+// query returns a pointer to each User returned by `query` bound to `args`.
+func (self *UserRepository) query(ctx context.Context, query string, args ...interface{}) ([]*User, error) {
+	rows, err := self.db.QueryContext(ctx, query, args...)
+
+	if nil != err {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	items, err := MapUser(rows)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return items, rows.Err()
+}
+
+// ScanColumn sets the field of the Tag mapped to `column` from the
+// scanned `value`, reporting whether the column is mapped.
+func (self *Tag) ScanColumn(column string, value interface{}) (bool, error) {
+	switch column {
+	case "id":
+		if nil != value {
+			integer, err := cartographer.AsInt(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			converted := int32(integer)
+
+			self.ID = converted
+		}
+	case "label":
+		if nil != value {
+			converted := cartographer.AsString(value)
+
+			self.Name = converted
+		}
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// ColumnValues returns a map of the Tag's columns to their values.
+func (self *Tag) ColumnValues() map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		"id":    self.ID,
+		"label": self.Name,
+	}
+}
+
+// MapTag returns a pointer to a new Tag for each row of `rows`.
+func MapTag(rows cartographer.ScannableRows) ([]*Tag, error) {
+	return cartographer.MapScanned[Tag](rows)
+}
+
+// SyncTag sets the fields of `item` from the single row of `rows`.
+func SyncTag(rows cartographer.ScannableRows, item *Tag) error {
+	return cartographer.SyncScanned(rows, item)
+}
+
+// affected returns `err`, or sql.ErrNoRows should `result` report that no
+// rows were affected.
+func affected(result sql.Result, err error) error {
+	if nil != err {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+
+	if nil != err {
+		return err
+	}
+
+	if 0 == rows {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package example declares the structs the gen package's tests generate
+// mappers for; cartographer_gen.go is regenerated with go generate.
+package example
+
+import (
+	"database/sql"
+	"time"
+)
+
+//go:generate go run ../../cmd/cartographer-gen
+
+// Base holds the columns shared by every model.
+type Base struct {
+	ID      int64     `db:"id,pk"`
+	Created time.Time `db:"created_at,autocreate"`
+}
+
+// User is a user account.
+//
+//cartographer:generate repository table=users
+type User struct {
+	Base
+	Name     string         `db:"name,unique"`
+	Age      uint8          `db:"age,index"`
+	Score    *float64       `db:"score"`
+	Admin    bool           `db:"admin"`
+	Bio      sql.NullString `db:"bio"`
+	Verified *time.Time     `db:"verified_at"`
+	Password string         `db:"-"`
+	Revision int            `db:"revision,version"`
+}
+
+// Tag labels users.
+//
+//cartographer:generate
+type Tag struct {
+	ID   int32  `db:"id"`
+	Name string `db:"label"`
+}
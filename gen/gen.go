@@ -0,0 +1,603 @@
+// Package gen generates reflection-free mappers for the tagged structs of
+// a package, as run by the cartographer-gen command. Each struct whose
+// declaration is annotated with a `//cartographer:generate` comment, or
+// named in Config.Types, is given a ScanColumn method implementing
+// cartographer.ColumnScanner through a switch over its columns, with
+// setters typed to each field, a ColumnValues method, and MapT and SyncT
+// functions built upon cartographer.MapScanned and SyncScanned.
+//
+// Structs annotated `//cartographer:generate repository`, or every struct
+// should Config.Repositories be set, are further given a TRepository
+// whose SQL is rendered at generation time, see Struct.Table.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// Directive annotates the struct declarations mappers are generated for.
+const Directive = "cartographer:generate"
+
+// Header begins every file generated, marking it as generated so that it
+// is skipped when the package is parsed again.
+const Header = "// Code generated by cartographer-gen. DO NOT EDIT."
+
+// Config configures the structs parsed and the code generated for them.
+type Config struct {
+	Tag          string                   // Struct tag fields are mapped through, cartographer.DefaultStructTag if empty.
+	Types        []string                 // Structs to generate mappers for, in addition to those annotated with Directive.
+	Repositories bool                     // Generate a repository for every struct, not only those annotated `repository`?
+	Placeholder  cartographer.Placeholder // Placeholder style of the repositories' SQL, cartographer.Question if nil.
+}
+
+// Package is a parsed package's structs that mappers are generated for.
+type Package struct {
+	Name        string                   // Name of the package.
+	Structs     []Struct                 // Structs mappers are generated for, in declaration order.
+	Placeholder cartographer.Placeholder // Placeholder style of the repositories' SQL.
+	imports     map[string]string        // Import paths of the packages named by the Structs' field types.
+}
+
+// Struct is a struct type mappers are generated for.
+//
+// The Table of a Struct is that named by a `table=name` argument of its
+// Directive, else the string literal returned by its TableName method,
+// if any, else its name in snake case, as cartographer's TableFor would.
+type Struct struct {
+	Name       string  // Name of the struct type.
+	Fields     []Field // Mapped fields, promoted fields included, in declaration order.
+	Table      string  // Table the struct is stored in.
+	Repository bool    // Is a repository generated for the struct?
+}
+
+// Field is a mapped field of a Struct.
+type Field struct {
+	Name    string                  // Selector of the field, such as `Base.ID` for promoted fields.
+	Column  string                  // Database column the field is mapped to.
+	Options cartographer.TagOptions // Options following the column name in the field's tag.
+	Type    string                  // Type of the field as written in source, such as `*time.Time`.
+	expr    ast.Expr                // Type of the field.
+	depth   int                     // Depth of the field within embedded structs.
+	imports map[string]string       // Imports of the file declaring the field, keyed by name.
+}
+
+// parsed is the struct types declared by a package being parsed.
+type parsed struct {
+	tag      string                                // Struct tag fields are mapped through.
+	declared map[string]*ast.StructType            // Struct types declared by the package, keyed by name.
+	imports  map[*ast.StructType]map[string]string // Imports of the file declaring each struct type.
+}
+
+// Parse parses the Go files of the package in directory `dir`, other than
+// tests and files previously generated, returning the structs mappers are
+// generated for, or an error if the package can not be parsed, a type of
+// Config.Types is not declared, or an embedded struct can not be resolved.
+func Parse(dir string, config Config) (pkg *Package, err error) {
+	if 0 == len(config.Tag) {
+		config.Tag = cartographer.DefaultStructTag
+	}
+
+	built, err := build.ImportDir(dir, 0)
+
+	if nil != err {
+		return
+	}
+
+	var (
+		fset      = token.NewFileSet()
+		scope     = &parsed{config.Tag, make(map[string]*ast.StructType), make(map[*ast.StructType]map[string]string)}
+		annotated []string
+		requested = make(map[string]bool)
+		arguments = make(map[string][]string) // Arguments following the Directive of each struct annotated.
+		tables    = make(map[string]string)   // Tables returned by TableName methods, keyed by receiver.
+	)
+
+	for _, name := range config.Types {
+		requested[name] = true
+	}
+
+	for _, name := range built.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+
+		if nil != err {
+			return nil, err
+		}
+
+		if generated(file) {
+			continue
+		}
+
+		var named = fileImports(file)
+
+		for _, decl := range file.Decls {
+			if method, ok := decl.(*ast.FuncDecl); ok {
+				if receiver, table, ok := tableName(method); ok {
+					tables[receiver] = table
+				}
+
+				continue
+			}
+
+			decl := decl.(*ast.GenDecl)
+
+			if token.TYPE != decl.Tok {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				structure, ok := spec.Type.(*ast.StructType)
+
+				if !ok {
+					continue
+				}
+
+				scope.declared[spec.Name.Name] = structure
+				scope.imports[structure] = named
+
+				args, ok := annotation(spec.Doc)
+
+				if !ok && 1 == len(decl.Specs) {
+					args, ok = annotation(decl.Doc)
+				}
+
+				if ok || requested[spec.Name.Name] {
+					annotated = append(annotated, spec.Name.Name)
+					arguments[spec.Name.Name] = args
+					delete(requested, spec.Name.Name)
+				}
+			}
+		}
+	}
+
+	if 0 != len(requested) {
+		var missing []string
+
+		for name, _ := range requested {
+			missing = append(missing, name)
+		}
+
+		sort.Strings(missing)
+
+		return nil, errors.New(fmt.Sprintf("No struct types %s declared in %s", strings.Join(missing, ", "), dir))
+	}
+
+	pkg = &Package{Name: built.Name, Placeholder: config.Placeholder, imports: make(map[string]string)}
+
+	if nil == pkg.Placeholder {
+		pkg.Placeholder = cartographer.Question
+	}
+
+	for _, name := range annotated {
+		var structure = Struct{Name: name, Table: cartographer.SnakeCase(name), Repository: config.Repositories}
+
+		if table, ok := tables[name]; ok {
+			structure.Table = table
+		}
+
+		for _, argument := range arguments[name] {
+			if "repository" == argument {
+				structure.Repository = true
+			} else if strings.HasPrefix(argument, "table=") {
+				structure.Table = strings.TrimPrefix(argument, "table=")
+			} else {
+				return nil, errors.New(fmt.Sprintf("Unknown argument %s of %s on %s", argument, Directive, name))
+			}
+		}
+
+		if structure.Fields, err = scope.collectFields(scope.declared[name], "", 0, map[string]bool{name: true}); nil != err {
+			return nil, errors.New(fmt.Sprintf("Failed to parse %s: %v", name, err))
+		}
+
+		structure.Fields = unshadowed(structure.Fields)
+
+		for _, field := range structure.Fields {
+			if star, ok := field.expr.(*ast.StarExpr); ok && "" == basicKind(star.X) {
+				if err = pkg.require(star.X, field.imports); nil != err {
+					return nil, errors.New(fmt.Sprintf("Failed to parse %s: %v", name, err))
+				}
+			}
+		}
+
+		if structure.Repository {
+			if err = pkg.requireRepository(structure); nil != err {
+				return nil, errors.New(fmt.Sprintf("Failed to parse %s: %v", name, err))
+			}
+		}
+
+		pkg.Structs = append(pkg.Structs, structure)
+	}
+
+	return
+}
+
+// collectFields returns the tagged fields of struct type `structure`,
+// selected through `prefix` at `depth`, promoting the fields of untagged
+// embedded structs declared by the package unless already `visited`.
+func (self *parsed) collectFields(structure *ast.StructType, prefix string, depth int, visited map[string]bool) (fields []Field, err error) {
+	for _, field := range structure.Fields.List {
+		var (
+			value, tagged   = lookupTag(field, self.tag)
+			column, options = cartographer.ParseTag(value)
+		)
+
+		if "-" == column {
+			continue // The field is explicitly not mapped.
+		}
+
+		if 0 == len(field.Names) {
+			if tagged {
+				fields = append(fields, Field{prefix + embeddedName(field.Type), column, options, types.ExprString(field.Type), field.Type, depth, self.imports[structure]})
+				continue
+			}
+
+			var promoted []Field
+
+			if promoted, err = self.promote(field.Type, prefix, depth, visited); nil != err {
+				return
+			}
+
+			fields = append(fields, promoted...)
+			continue
+		}
+
+		if 0 == len(column) {
+			continue
+		}
+
+		for _, name := range field.Names {
+			fields = append(fields, Field{prefix + name.Name, column, options, types.ExprString(field.Type), field.Type, depth, self.imports[structure]})
+		}
+	}
+
+	return
+}
+
+// promote returns the fields of the untagged embedded struct of type `expr`.
+// Embedded structs declared by other packages, or embedded through a
+// pointer, can not be promoted and result in an error.
+func (self *parsed) promote(expr ast.Expr, prefix string, depth int, visited map[string]bool) ([]Field, error) {
+	if _, ok := expr.(*ast.StarExpr); ok {
+		return nil, errors.New(fmt.Sprintf("Embedded pointer %s can not be promoted, embed it by value or tag it `-`", types.ExprString(expr)))
+	}
+
+	ident, ok := expr.(*ast.Ident)
+
+	if !ok || nil == self.declared[ident.Name] {
+		return nil, errors.New(fmt.Sprintf("Embedded %s is not a struct declared by the package, tag it `-` should it map no columns", types.ExprString(expr)))
+	}
+
+	if visited[ident.Name] {
+		return nil, nil
+	}
+
+	visited[ident.Name] = true
+
+	return self.collectFields(self.declared[ident.Name], prefix+ident.Name+".", depth+1, visited)
+}
+
+// unshadowed removes the `fields` hidden by another sharing their name or
+// column at a shallower depth, or at the same depth but declared earlier,
+// as DiscoverType does.
+func unshadowed(fields []Field) (visible []Field) {
+	for position, field := range fields {
+		var hidden bool
+
+		for index, other := range fields {
+			if index == position || (lastName(field.Name) != lastName(other.Name) && field.Column != other.Column) {
+				continue
+			}
+
+			if other.depth < field.depth || (other.depth == field.depth && index < position) {
+				hidden = true
+				break
+			}
+		}
+
+		if !hidden {
+			visible = append(visible, field)
+		}
+	}
+
+	return
+}
+
+// require records the import of each package named by type `expr`,
+// resolved through the `named` imports of the file declaring it.
+func (self *Package) require(expr ast.Expr, named map[string]string) (err error) {
+	ast.Inspect(expr, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+
+		if !ok || nil != err {
+			return nil == err
+		}
+
+		name := selector.X.(*ast.Ident).Name
+		path, ok := named[name]
+
+		if !ok {
+			err = errors.New(fmt.Sprintf("No import of package %s", name))
+		} else {
+			err = self.requirePath(name, path)
+		}
+
+		return false
+	})
+
+	return
+}
+
+// requirePath records the import of the package at `path` as `name`, or
+// returns an error should another package already be imported as `name`.
+func (self *Package) requirePath(name string, path string) error {
+	if existing, ok := self.imports[name]; ok && existing != path {
+		return errors.New(fmt.Sprintf("Package %s imported as both %s and %s", name, existing, path))
+	}
+
+	self.imports[name] = path
+
+	return nil
+}
+
+// Generate returns the gofmt'd source of the mappers generated for
+// the Package's structs, or an error should it fail to format.
+func (self *Package) Generate() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "%s\n\npackage %s\n\nimport (\n", Header, self.Name)
+
+	var names []string
+
+	for name, _ := range self.imports {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if path := self.imports[name]; name == filepath.Base(path) {
+			fmt.Fprintf(&buffer, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&buffer, "\t%s %q\n", name, path)
+		}
+	}
+
+	fmt.Fprintf(&buffer, "\n\t%q\n)\n", "github.com/chuckpreslar/cartographer")
+
+	var repositories bool
+
+	for _, structure := range self.Structs {
+		structure.generate(&buffer)
+
+		if structure.Repository {
+			structure.generateRepository(&buffer, self.Placeholder)
+			repositories = true
+		}
+	}
+
+	if repositories {
+		generateAffected(&buffer)
+	}
+
+	return format.Source(buffer.Bytes())
+}
+
+// generate writes the Struct's mappers to `buffer`.
+func (self Struct) generate(buffer *bytes.Buffer) {
+	fmt.Fprintf(buffer, "\n// ScanColumn sets the field of the %s mapped to `column` from the\n", self.Name)
+	fmt.Fprintf(buffer, "// scanned `value`, reporting whether the column is mapped.\n")
+	fmt.Fprintf(buffer, "func (self *%s) ScanColumn(column string, value interface{}) (bool, error) {\n", self.Name)
+	fmt.Fprintf(buffer, "switch column {\n")
+
+	for _, field := range self.Fields {
+		fmt.Fprintf(buffer, "case %q:\n", field.Column)
+		fmt.Fprintf(buffer, "if nil != value {\n%s}\n", field.setter())
+	}
+
+	fmt.Fprintf(buffer, "default:\nreturn false, nil\n}\n\nreturn true, nil\n}\n")
+
+	fmt.Fprintf(buffer, "\n// ColumnValues returns a map of the %s's columns to their values.\n", self.Name)
+	fmt.Fprintf(buffer, "func (self *%s) ColumnValues() map[interface{}]interface{} {\n", self.Name)
+	fmt.Fprintf(buffer, "return map[interface{}]interface{}{\n")
+
+	for _, field := range self.Fields {
+		fmt.Fprintf(buffer, "%q: self.%s,\n", field.Column, field.Name)
+	}
+
+	fmt.Fprintf(buffer, "}\n}\n")
+
+	fmt.Fprintf(buffer, "\n// Map%s returns a pointer to a new %s for each row of `rows`.\n", self.Name, self.Name)
+	fmt.Fprintf(buffer, "func Map%s(rows cartographer.ScannableRows) ([]*%s, error) {\n", self.Name, self.Name)
+	fmt.Fprintf(buffer, "return cartographer.MapScanned[%s](rows)\n}\n", self.Name)
+
+	fmt.Fprintf(buffer, "\n// Sync%s sets the fields of `item` from the single row of `rows`.\n", self.Name)
+	fmt.Fprintf(buffer, "func Sync%s(rows cartographer.ScannableRows, item *%s) error {\n", self.Name, self.Name)
+	fmt.Fprintf(buffer, "return cartographer.SyncScanned(rows, item)\n}\n")
+}
+
+// setter returns the statements setting the Field from a non-nil `value`
+// within ScanColumn, typed to the field's kind where it is a builtin,
+// else through cartographer.Assign.
+func (self Field) setter() string {
+	var (
+		expr       = self.expr
+		star, ok   = expr.(*ast.StarExpr)
+		conversion string
+	)
+
+	if ok {
+		expr = star.X
+	}
+
+	switch kind := basicKind(expr); kind {
+	case "string":
+		conversion = fmt.Sprintf("converted := %s\n", convert(expr, "string", "cartographer.AsString(value)"))
+	case "int", "uint":
+		conversion = fmt.Sprintf("integer, err := cartographer.AsInt(value)\n\nif nil != err {\nreturn true, err\n}\n\nconverted := %s\n", convert(expr, "int64", "integer"))
+	case "float":
+		conversion = fmt.Sprintf("float, err := cartographer.AsFloat(value)\n\nif nil != err {\nreturn true, err\n}\n\nconverted := %s\n", convert(expr, "float64", "float"))
+	case "bool":
+		conversion = "converted, err := cartographer.AsBool(value)\n\nif nil != err {\nreturn true, err\n}\n"
+	default:
+		if !ok {
+			return fmt.Sprintf("if err := cartographer.Assign(&self.%s, value); nil != err {\nreturn true, err\n}\n", self.Name)
+		}
+
+		conversion = fmt.Sprintf("var converted %s\n\nif err := cartographer.Assign(&converted, value); nil != err {\nreturn true, err\n}\n", types.ExprString(expr))
+	}
+
+	if ok {
+		return fmt.Sprintf("%s\nself.%s = &converted\n", conversion, self.Name)
+	}
+
+	return fmt.Sprintf("%s\nself.%s = converted\n", conversion, self.Name)
+}
+
+// convert returns the expression converting `value`, of builtin type
+// `natural`, to builtin type `expr`.
+func convert(expr ast.Expr, natural string, value string) string {
+	if natural == expr.(*ast.Ident).Name {
+		return value
+	}
+
+	return fmt.Sprintf("%s(%s)", expr.(*ast.Ident).Name, value)
+}
+
+// basicKinds maps the builtin types given typed setters to their kind.
+var basicKinds = map[string]string{
+	"string": "string",
+	"int":    "int", "int8": "int", "int16": "int", "int32": "int", "int64": "int",
+	"uint": "uint", "uint8": "uint", "uint16": "uint", "uint32": "uint", "uint64": "uint",
+	"float32": "float", "float64": "float",
+	"bool": "bool",
+}
+
+// basicKind returns the kind of the builtin type `expr`, or an empty
+// string should it not be a builtin given a typed setter.
+func basicKind(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return basicKinds[ident.Name]
+	}
+
+	return ""
+}
+
+// lookupTag returns the value of `field`'s tag `tag`, and whether the tag is present.
+func lookupTag(field *ast.Field, tag string) (string, bool) {
+	if nil == field.Tag {
+		return "", false
+	}
+
+	literal, err := strconv.Unquote(field.Tag.Value)
+
+	if nil != err {
+		return "", false
+	}
+
+	return reflect.StructTag(literal).Lookup(tag)
+}
+
+// embeddedName returns the name of an embedded field of type `expr`.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.Ident:
+		return expr.Name
+	}
+
+	return types.ExprString(expr)
+}
+
+// lastName returns the name of the field selected by `selector`.
+func lastName(selector string) string {
+	return selector[strings.LastIndex(selector, ".")+1:]
+}
+
+// annotation returns the space separated arguments following Directive
+// within comment group `doc`, and whether it holds the Directive.
+func annotation(doc *ast.CommentGroup) ([]string, bool) {
+	if nil == doc {
+		return nil, false
+	}
+
+	for _, comment := range doc.List {
+		if fields := strings.Fields(comment.Text); 0 != len(fields) && "//"+Directive == fields[0] {
+			return fields[1:], true
+		}
+	}
+
+	return nil, false
+}
+
+// tableName returns the receiver of `method` and the string literal it
+// returns, should it be a TableName method returning only a literal.
+func tableName(method *ast.FuncDecl) (receiver string, table string, ok bool) {
+	if "TableName" != method.Name.Name || nil == method.Recv || nil == method.Body || 1 != len(method.Body.List) {
+		return
+	}
+
+	var expr = method.Recv.List[0].Type
+
+	if star, isStar := expr.(*ast.StarExpr); isStar {
+		expr = star.X
+	}
+
+	ident, isIdent := expr.(*ast.Ident)
+	returned, isReturn := method.Body.List[0].(*ast.ReturnStmt)
+
+	if !isIdent || !isReturn || 1 != len(returned.Results) {
+		return
+	}
+
+	literal, isLiteral := returned.Results[0].(*ast.BasicLit)
+
+	if !isLiteral || token.STRING != literal.Kind {
+		return
+	}
+
+	table, err := strconv.Unquote(literal.Value)
+
+	return ident.Name, table, nil == err
+}
+
+// generated reports whether `file` was generated by Generate.
+func generated(file *ast.File) bool {
+	return 0 != len(file.Comments) && Header == file.Comments[0].List[0].Text
+}
+
+// fileImports returns the import paths of `file`, keyed by the name each
+// is referred to by, assuming a package's name is its path's last element.
+func fileImports(file *ast.File) map[string]string {
+	var named = make(map[string]string)
+
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := filepath.Base(path)
+
+		if nil != spec.Name {
+			name = spec.Name.Name
+		}
+
+		named[name] = path
+	}
+
+	return named
+}
@@ -0,0 +1,260 @@
+package gen
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+	"github.com/chuckpreslar/cartographer/gen/example"
+	"github.com/chuckpreslar/cartographer/maprows"
+)
+
+func TestGenerate(t *testing.T) {
+	pkg, err := Parse("example", Config{})
+
+	if nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(pkg.Structs) || "User" != pkg.Structs[0].Name || "Tag" != pkg.Structs[1].Name {
+		t.Fatalf("Basic Generate test returned unexpected structs: %+v", pkg.Structs)
+	}
+
+	if fields := pkg.Structs[0].Fields; 9 != len(fields) || "Base.ID" != fields[0].Name || "id" != fields[0].Column || !fields[0].Options.Has("pk") || "*time.Time" != fields[7].Type {
+		t.Errorf("Basic Generate test returned unexpected fields: %+v", fields)
+	}
+
+	source, err := pkg.Generate()
+
+	if nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("example", "cartographer_gen.go"))
+
+	if nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	if string(expected) != string(source) {
+		t.Errorf("Basic Generate test generated source differs from example/cartographer_gen.go, run go generate:\n%s", source)
+	}
+}
+
+func TestGeneratedMappers(t *testing.T) {
+	rows := maprows.From([]map[string]interface{}{
+		{"id": int64(1), "name": "ada", "age": "36", "score": 9.5, "admin": true, "bio": "countess", "unmapped": 1},
+		{"id": int64(2), "name": []byte("alan")},
+	})
+
+	users, err := example.MapUser(rows)
+
+	if nil != err {
+		t.Fatalf("Basic generated mapper test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(users) || 1 != users[0].ID || "ada" != users[0].Name || 36 != users[0].Age || 9.5 != *users[0].Score || !users[0].Admin || "countess" != users[0].Bio.String {
+		t.Errorf("Basic generated mapper test returned unexpected first user: %+v", users[0])
+	}
+
+	if "alan" != users[1].Name || nil != users[1].Score || users[1].Bio.Valid {
+		t.Errorf("Basic generated mapper test returned unexpected second user: %+v", users[1])
+	}
+
+	var tag = example.Tag{Name: "kept"}
+
+	if err = example.SyncTag(maprows.From([]map[string]interface{}{{"id": int64(3)}}), &tag); nil != err || 3 != tag.ID || "kept" != tag.Name {
+		t.Errorf("Basic generated mapper test returned unexpected tag: %+v, %v", tag, err)
+	}
+
+	if values := users[0].ColumnValues(); 9 != len(values) || "ada" != values["name"] || int64(1) != values["id"] {
+		t.Errorf("Basic generated mapper test returned unexpected column values: %v", values)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var sources = map[string]string{
+		"missing":  "type Other struct{}",
+		"pointer":  "//cartographer:generate\ntype Item struct {\n*Base\n}\n\ntype Base struct {\nID int `db:\"id\"`\n}",
+		"foreign":  "import \"sync\"\n\n//cartographer:generate\ntype Item struct {\nsync.Mutex\n}",
+		"imported": "//cartographer:generate\ntype Item struct {\nAt *time.Time `db:\"at\"`\n}",
+		"argument": "//cartographer:generate repo\ntype Item struct {\nID int `db:\"id,pk\"`\n}",
+		"keyless":  "//cartographer:generate repository\ntype Item struct {\nID int `db:\"id\"`\n}",
+	}
+
+	for name, source := range sources {
+		dir := t.TempDir()
+
+		if err := os.WriteFile(filepath.Join(dir, "item.go"), []byte("package item\n\n"+source+"\n"), 0644); nil != err {
+			t.Fatalf("Basic Parse test returned an unexpected error: %v", err)
+		}
+
+		var config Config
+
+		if "missing" == name {
+			config.Types = []string{"Item"}
+		}
+
+		if _, err := Parse(dir, config); nil == err {
+			t.Errorf("Basic Parse test expected an error parsing %s source", name)
+		} else if "missing" == name && !strings.Contains(err.Error(), "Item") {
+			t.Errorf("Basic Parse test expected the missing type to be named, received %v", err)
+		}
+	}
+}
+
+// execution is a statement executed through recorder.
+type execution struct {
+	query string
+	args  []interface{}
+}
+
+// recorder is a cartographer.Querier recording the statements executed,
+// reporting `affected` rows and generating key 7.
+type recorder struct {
+	executed []execution
+	affected int64
+}
+
+func (self *recorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("Unexpected query")
+}
+
+func (self *recorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	self.executed = append(self.executed, execution{query, args})
+
+	return self, nil
+}
+
+func (self *recorder) LastInsertId() (int64, error) {
+	return 7, nil
+}
+
+func (self *recorder) RowsAffected() (int64, error) {
+	return self.affected, nil
+}
+
+func TestGeneratedRepository(t *testing.T) {
+	var (
+		db         = &recorder{affected: 1}
+		repository = example.NewUserRepository(db)
+		user       = &example.User{Name: "ada", Revision: 2}
+		ctx        = context.Background()
+	)
+
+	if err := repository.Insert(ctx, user); nil != err || 7 != user.ID || user.Created.IsZero() {
+		t.Fatalf("Basic generated repository test returned unexpected user: %+v, %v", user, err)
+	}
+
+	if !strings.HasPrefix(db.executed[0].query, "INSERT INTO users (created_at, name,") || 8 != len(db.executed[0].args) {
+		t.Errorf("Basic generated repository test returned unexpected insert: %+v", db.executed[0])
+	}
+
+	if err := repository.Update(ctx, user); nil != err || 3 != user.Revision {
+		t.Fatalf("Basic generated repository test returned unexpected user: %+v, %v", user, err)
+	}
+
+	if update := db.executed[1]; !strings.HasSuffix(update.query, "revision = revision + 1 WHERE id = ? AND revision = ?") || 2 != update.args[len(update.args)-1] {
+		t.Errorf("Basic generated repository test returned unexpected update: %+v", update)
+	}
+
+	db.affected = 0
+
+	if err := repository.Update(ctx, user); nil == err || 3 != user.Revision {
+		t.Errorf("Basic generated repository test expected a stale row, received %v", err)
+	} else if _, ok := err.(*cartographer.ErrStaleRow); !ok {
+		t.Errorf("Basic generated repository test expected a stale row, received %v", err)
+	}
+
+	if err := repository.Delete(ctx, user); sql.ErrNoRows != err {
+		t.Errorf("Basic generated repository test expected sql.ErrNoRows, received %v", err)
+	}
+
+	if deletion := db.executed[3]; "DELETE FROM users WHERE id = ?" != deletion.query || int64(7) != deletion.args[0] {
+		t.Errorf("Basic generated repository test returned unexpected delete: %+v", deletion)
+	}
+}
+
+func TestRepositoryConfig(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		source = "package item\n\ntype ItemRecord struct {\nKey string `db:\"key,pk\"`\nKind string `db:\"kind,index\"`\n}\n\n" +
+			"func (ItemRecord) TableName() string { return \"records\" }\n"
+	)
+
+	if err := os.WriteFile(filepath.Join(dir, "item.go"), []byte(source), 0644); nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	pkg, err := Parse(dir, Config{Types: []string{"ItemRecord"}, Repositories: true, Placeholder: cartographer.Dollar})
+
+	if nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	if structure := pkg.Structs[0]; "records" != structure.Table || !structure.Repository {
+		t.Errorf("Basic Generate test returned unexpected struct: %+v", structure)
+	}
+
+	generated, err := pkg.Generate()
+
+	if nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"func (self *ItemRecordRepository) Find(ctx context.Context, key string) (*ItemRecord, error)",
+		"func (self *ItemRecordRepository) FindByKind(ctx context.Context, kind string) ([]*ItemRecord, error)",
+		`"UPDATE records SET kind = $1 WHERE key = $2", item.Kind, item.Key`,
+	} {
+		if !strings.Contains(string(generated), expected) {
+			t.Errorf("Basic Generate test expected generated source to contain %s:\n%s", expected, generated)
+		}
+	}
+}
+
+func TestRepositoryReturning(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		source = "package item\n\ntype Item struct {\nID int64 `db:\"id,pk\"`\nName string `db:\"name\"`\n}\n\n" +
+			"func (Item) TableName() string { return \"items\" }\n"
+	)
+
+	if err := os.WriteFile(filepath.Join(dir, "item.go"), []byte(source), 0644); nil != err {
+		t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+	}
+
+	for placeholder, expected := range map[string][]string{
+		"$1": {`self.db.QueryContext(ctx, "INSERT INTO items (name) VALUES ($1) RETURNING id", item.Name)`, "rows.Scan(&item.ID)"},
+		"?":  {`self.db.ExecContext(ctx, "INSERT INTO items (name) VALUES (?)", item.Name)`, "return nil // The driver can not report generated keys."},
+	} {
+		config := Config{Types: []string{"Item"}, Repositories: true, Placeholder: cartographer.Question}
+
+		if "$1" == placeholder {
+			config.Placeholder = cartographer.Dollar
+		}
+
+		pkg, err := Parse(dir, config)
+
+		if nil != err {
+			t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+		}
+
+		generated, err := pkg.Generate()
+
+		if nil != err {
+			t.Fatalf("Basic Generate test returned an unexpected error: %v", err)
+		}
+
+		for _, expected := range expected {
+			if !strings.Contains(string(generated), expected) {
+				t.Errorf("Basic Generate test expected generated source to contain %s:\n%s", expected, generated)
+			}
+		}
+	}
+}
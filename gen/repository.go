@@ -0,0 +1,392 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// requireRepository records the imports of the repository generated for
+// `structure`, or returns an error should it declare no `pk` field.
+func (self *Package) requireRepository(structure Struct) (err error) {
+	if 0 == len(structure.keys()) {
+		return errors.New("A repository requires a field with the pk option")
+	}
+
+	var paths = map[string]string{"context": "context", "sql": "database/sql"}
+
+	for _, field := range structure.Fields {
+		if field.Options.Has("autocreate") || field.Options.Has("autoupdate") {
+			paths["time"] = "time"
+		}
+
+		if field.Options.Has("version") {
+			paths["reflect"] = "reflect"
+		}
+	}
+
+	for name, path := range paths {
+		if err = self.requirePath(name, path); nil != err {
+			return
+		}
+	}
+
+	for _, field := range structure.Fields {
+		if field.Options.Has("pk") || field.Options.Has("unique") || field.Options.Has("index") {
+			if err = self.require(field.expr, field.imports); nil != err {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// keys returns the Struct's fields with the `pk` option.
+func (self Struct) keys() (keys []Field) {
+	for _, field := range self.Fields {
+		if field.Options.Has("pk") {
+			keys = append(keys, field)
+		}
+	}
+
+	return
+}
+
+// version returns the Struct's first field with the `version` option, and
+// whether it declares one.
+func (self Struct) version() (Field, bool) {
+	for _, field := range self.Fields {
+		if field.Options.Has("version") {
+			return field, true
+		}
+	}
+
+	return Field{}, false
+}
+
+// generateRepository writes the Struct's repository to `buffer`, its SQL
+// rendered with Placeholder `placeholder`.
+func (self Struct) generateRepository(buffer *bytes.Buffer, placeholder cartographer.Placeholder) {
+	var (
+		name    = self.Name + "Repository"
+		keys    = self.keys()
+		columns []string
+	)
+
+	for _, field := range self.Fields {
+		columns = append(columns, field.Column)
+	}
+
+	var selection = fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), self.Table)
+
+	fmt.Fprintf(buffer, "\n// %s performs the common CRUD operations against table %s,\n", name, self.Table)
+	fmt.Fprintf(buffer, "// its SQL rendered when generated rather than built through reflection.\n")
+	fmt.Fprintf(buffer, "type %s struct {\ndb cartographer.Querier\n}\n", name)
+
+	fmt.Fprintf(buffer, "\n// New%s returns a pointer to a %s querying `db`.\n", name, name)
+	fmt.Fprintf(buffer, "func New%s(db cartographer.Querier) *%s {\nreturn &%s{db: db}\n}\n", name, name, name)
+
+	fmt.Fprintf(buffer, "\n// Find returns a pointer to the %s identified by its primary keys, or\n", self.Name)
+	fmt.Fprintf(buffer, "// sql.ErrNoRows if there is no such row.\n")
+	fmt.Fprintf(buffer, "func (self *%s) Find(ctx context.Context, %s) (*%s, error) {\n", name, parameters(keys), self.Name)
+	fmt.Fprintf(buffer, "return self.one(ctx, %q, %s)\n}\n", selection+" WHERE "+conditions(keys, placeholder, 0), arguments(keys, ""))
+
+	fmt.Fprintf(buffer, "\n// FindAll returns a pointer to each %s in table %s.\n", self.Name, self.Table)
+	fmt.Fprintf(buffer, "func (self *%s) FindAll(ctx context.Context) ([]*%s, error) {\n", name, self.Name)
+	fmt.Fprintf(buffer, "return self.query(ctx, %q)\n}\n", selection)
+
+	fmt.Fprintf(buffer, "\n// FindWhere returns a pointer to each %s matching SQL condition `where`\n", self.Name)
+	fmt.Fprintf(buffer, "// bound to `args`.\n")
+	fmt.Fprintf(buffer, "func (self *%s) FindWhere(ctx context.Context, where string, args ...interface{}) ([]*%s, error) {\n", name, self.Name)
+	fmt.Fprintf(buffer, "return self.query(ctx, %q+where, args...)\n}\n", selection+" WHERE ")
+
+	for _, field := range self.Fields {
+		var (
+			method = "FindBy" + lastName(field.Name)
+			query  = selection + " WHERE " + conditions([]Field{field}, placeholder, 0)
+		)
+
+		if field.Options.Has("unique") && !field.Options.Has("pk") {
+			fmt.Fprintf(buffer, "\n// %s returns a pointer to the %s whose %s is `%s`, or\n", method, self.Name, field.Column, parameter(field))
+			fmt.Fprintf(buffer, "// sql.ErrNoRows if there is no such row.\n")
+			fmt.Fprintf(buffer, "func (self *%s) %s(ctx context.Context, %s) (*%s, error) {\n", name, method, parameters([]Field{field}), self.Name)
+			fmt.Fprintf(buffer, "return self.one(ctx, %q, %s)\n}\n", query, parameter(field))
+		} else if field.Options.Has("index") {
+			fmt.Fprintf(buffer, "\n// %s returns a pointer to each %s whose %s is `%s`.\n", method, self.Name, field.Column, parameter(field))
+			fmt.Fprintf(buffer, "func (self *%s) %s(ctx context.Context, %s) ([]*%s, error) {\n", name, method, parameters([]Field{field}), self.Name)
+			fmt.Fprintf(buffer, "return self.query(ctx, %q, %s)\n}\n", query, parameter(field))
+		}
+	}
+
+	self.generateInsert(buffer, name, placeholder)
+	self.generateUpdate(buffer, name, placeholder)
+
+	fmt.Fprintf(buffer, "\n// Delete deletes the row identified by `item`'s primary keys, returning\n")
+	fmt.Fprintf(buffer, "// sql.ErrNoRows should no row be affected.\n")
+	fmt.Fprintf(buffer, "func (self *%s) Delete(ctx context.Context, item *%s) error {\n", name, self.Name)
+	fmt.Fprintf(buffer, "result, err := self.db.ExecContext(ctx, %q, %s)\n\n", fmt.Sprintf("DELETE FROM %s WHERE %s", self.Table, conditions(keys, placeholder, 0)), arguments(keys, "item."))
+	fmt.Fprintf(buffer, "return affected(result, err)\n}\n")
+
+	fmt.Fprintf(buffer, "\n// one returns a pointer to the first %s returned by `query` bound to\n", self.Name)
+	fmt.Fprintf(buffer, "// `args`, or sql.ErrNoRows should none be returned.\n")
+	fmt.Fprintf(buffer, "func (self *%s) one(ctx context.Context, query string, args ...interface{}) (*%s, error) {\n", name, self.Name)
+	fmt.Fprintf(buffer, "items, err := self.query(ctx, query, args...)\n\nif nil != err {\nreturn nil, err\n}\n\n")
+	fmt.Fprintf(buffer, "if 0 == len(items) {\nreturn nil, sql.ErrNoRows\n}\n\nreturn items[0], nil\n}\n")
+
+	fmt.Fprintf(buffer, "\n// query returns a pointer to each %s returned by `query` bound to `args`.\n", self.Name)
+	fmt.Fprintf(buffer, "func (self *%s) query(ctx context.Context, query string, args ...interface{}) ([]*%s, error) {\n", name, self.Name)
+	fmt.Fprintf(buffer, "rows, err := self.db.QueryContext(ctx, query, args...)\n\nif nil != err {\nreturn nil, err\n}\n\ndefer rows.Close()\n\n")
+	fmt.Fprintf(buffer, "items, err := Map%s(rows)\n\nif nil != err {\nreturn nil, err\n}\n\nreturn items, rows.Err()\n}\n", self.Name)
+}
+
+// generateInsert writes the Insert method of the Struct's repository `name`
+// to `buffer`. Should the Struct declare a single integer primary key,
+// the key is left for the database to generate while zero, read with a
+// RETURNING clause for dollar placeholders, as PostgreSQL's drivers do not
+// report LastInsertId, and ignored should the driver not report it otherwise,
+// as cartographer's Repository ignores it.
+func (self Struct) generateInsert(buffer *bytes.Buffer, name string, placeholder cartographer.Placeholder) {
+	var keys = self.keys()
+
+	fmt.Fprintf(buffer, "\n// Insert inserts `item` into table %s", self.Table)
+
+	if generatedKey(keys) {
+		fmt.Fprintf(buffer, ", setting its %s from the\n", lastName(keys[0].Name))
+		fmt.Fprintf(buffer, "// key the database generates should it be zero")
+	}
+
+	fmt.Fprintf(buffer, ".\n")
+	fmt.Fprintf(buffer, "func (self *%s) Insert(ctx context.Context, item *%s) error {\n", name, self.Name)
+	self.generateStamps(buffer, "autocreate", "autoupdate")
+
+	if !generatedKey(keys) {
+		fmt.Fprintf(buffer, "_, err := self.db.ExecContext(ctx, %s)\n\nreturn err\n}\n", self.insertion(placeholder, "", ""))
+		return
+	}
+
+	var key = keys[0]
+
+	fmt.Fprintf(buffer, "if 0 != item.%s {\n_, err := self.db.ExecContext(ctx, %s)\n\nreturn err\n}\n\n", key.Name, self.insertion(placeholder, "", ""))
+
+	if "$1" == placeholder(1) {
+		fmt.Fprintf(buffer, "rows, err := self.db.QueryContext(ctx, %s)\n\nif nil != err {\nreturn err\n}\n\ndefer rows.Close()\n\n", self.insertion(placeholder, key.Column, " RETURNING "+key.Column))
+		fmt.Fprintf(buffer, "if !rows.Next() {\nif err = rows.Err(); nil == err {\nerr = sql.ErrNoRows\n}\n\nreturn err\n}\n\n")
+		fmt.Fprintf(buffer, "if err = rows.Scan(&item.%s); nil != err {\nreturn err\n}\n\nreturn rows.Err()\n}\n", key.Name)
+		return
+	}
+
+	fmt.Fprintf(buffer, "result, err := self.db.ExecContext(ctx, %s)\n\nif nil != err {\nreturn err\n}\n\n", self.insertion(placeholder, key.Column, ""))
+	fmt.Fprintf(buffer, "key, err := result.LastInsertId()\n\nif nil != err {\nreturn nil // The driver can not report generated keys.\n}\n\n")
+	fmt.Fprintf(buffer, "item.%s = %s\n\nreturn nil\n}\n", key.Name, convert(key.expr, "int64", "key"))
+}
+
+// insertion returns the quoted INSERT statement of the Struct, ending with
+// `suffix`, followed by its arguments, omitting column `omitted`.
+func (self Struct) insertion(placeholder cartographer.Placeholder, omitted, suffix string) string {
+	var columns, placeholders, args []string
+
+	for _, field := range self.Fields {
+		if omitted == field.Column {
+			continue
+		}
+
+		columns = append(columns, field.Column)
+		args = append(args, "item."+field.Name)
+		placeholders = append(placeholders, placeholder(len(args)))
+	}
+
+	var query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)%s", self.Table, strings.Join(columns, ", "), strings.Join(placeholders, ", "), suffix)
+
+	return fmt.Sprintf("%q, %s", query, strings.Join(args, ", "))
+}
+
+// generateUpdate writes the Update method of the Struct's repository `name`
+// to `buffer`. Should the Struct declare a `version` field, the row is
+// only updated while its version is unchanged, as cartographer's UpdateSQL.
+func (self Struct) generateUpdate(buffer *bytes.Buffer, name string, placeholder cartographer.Placeholder) {
+	var (
+		keys             = self.keys()
+		version, ok      = self.version()
+		assignments      []string
+		args             []string
+		where            string
+		versionCondition string
+	)
+
+	for _, field := range self.Fields {
+		if field.Options.Has("pk") {
+			continue
+		}
+
+		if ok && field.Column == version.Column {
+			assignments = append(assignments, fmt.Sprintf("%s = %s + 1", field.Column, field.Column))
+			continue
+		}
+
+		args = append(args, "item."+field.Name)
+		assignments = append(assignments, fmt.Sprintf("%s = %s", field.Column, placeholder(len(args))))
+	}
+
+	where = conditions(keys, placeholder, len(args))
+
+	if ok {
+		versionCondition = fmt.Sprintf(" AND %s = %s", version.Column, placeholder(len(args)+len(keys)+1))
+	}
+
+	args = append(args, arguments(keys, "item."))
+
+	if ok {
+		args = append(args, "item."+version.Name)
+	}
+
+	var query = fmt.Sprintf("UPDATE %s SET %s WHERE %s%s", self.Table, strings.Join(assignments, ", "), where, versionCondition)
+
+	fmt.Fprintf(buffer, "\n// Update updates the row identified by `item`'s primary keys, returning\n")
+
+	if ok {
+		fmt.Fprintf(buffer, "// a *cartographer.ErrStaleRow should no row at its %s be affected,\n", version.Column)
+		fmt.Fprintf(buffer, "// else incrementing its %s.\n", lastName(version.Name))
+	} else {
+		fmt.Fprintf(buffer, "// sql.ErrNoRows should no row be affected.\n")
+	}
+
+	fmt.Fprintf(buffer, "func (self *%s) Update(ctx context.Context, item *%s) error {\n", name, self.Name)
+	self.generateStamps(buffer, "autoupdate")
+	fmt.Fprintf(buffer, "result, err := self.db.ExecContext(ctx, %q, %s)\n\n", query, strings.Join(args, ", "))
+
+	if !ok {
+		fmt.Fprintf(buffer, "return affected(result, err)\n}\n")
+		return
+	}
+
+	fmt.Fprintf(buffer, "if err = affected(result, err); sql.ErrNoRows == err {\n")
+	fmt.Fprintf(buffer, "return &cartographer.ErrStaleRow{Type: reflect.TypeOf(*item), Version: item.%s}\n", version.Name)
+	fmt.Fprintf(buffer, "} else if nil != err {\nreturn err\n}\n\nitem.%s++\n\nreturn nil\n}\n", version.Name)
+}
+
+// generateStamps writes the statements setting the Struct's fields with
+// any of the `options` to the current time to `buffer`.
+func (self Struct) generateStamps(buffer *bytes.Buffer, options ...string) {
+	var stamped []string
+
+	for _, field := range self.Fields {
+		for _, option := range options {
+			if field.Options.Has(option) {
+				stamped = append(stamped, field.Name)
+				break
+			}
+		}
+	}
+
+	if 0 == len(stamped) {
+		return
+	}
+
+	fmt.Fprintf(buffer, "now := time.Now()\n")
+
+	for _, name := range stamped {
+		fmt.Fprintf(buffer, "item.%s = now\n", name)
+	}
+
+	fmt.Fprintf(buffer, "\n")
+}
+
+// generateAffected writes the affected function shared by the repositories
+// generated to `buffer`.
+func generateAffected(buffer *bytes.Buffer) {
+	fmt.Fprintf(buffer, "\n// affected returns `err`, or sql.ErrNoRows should `result` report that no\n")
+	fmt.Fprintf(buffer, "// rows were affected.\n")
+	fmt.Fprintf(buffer, "func affected(result sql.Result, err error) error {\n")
+	fmt.Fprintf(buffer, "if nil != err {\nreturn err\n}\n\nrows, err := result.RowsAffected()\n\nif nil != err {\nreturn err\n}\n\n")
+	fmt.Fprintf(buffer, "if 0 == rows {\nreturn sql.ErrNoRows\n}\n\nreturn nil\n}\n")
+}
+
+// generatedKey reports whether `keys` are a single integer primary key,
+// which the database is left to generate while zero.
+func generatedKey(keys []Field) bool {
+	if 1 != len(keys) {
+		return false
+	}
+
+	switch basicKind(keys[0].expr) {
+	case "int", "uint":
+		return true
+	}
+
+	return false
+}
+
+// conditions returns the SQL conditions matching the columns of `fields`
+// to placeholders following the first `bound`, joined by AND.
+func conditions(fields []Field, placeholder cartographer.Placeholder, bound int) string {
+	var conditions []string
+
+	for position, field := range fields {
+		conditions = append(conditions, fmt.Sprintf("%s = %s", field.Column, placeholder(bound+position+1)))
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// parameters returns the parameter list of `fields`, named by parameter.
+func parameters(fields []Field) string {
+	var parameters []string
+
+	for _, field := range fields {
+		parameters = append(parameters, fmt.Sprintf("%s %s", parameter(field), field.Type))
+	}
+
+	return strings.Join(parameters, ", ")
+}
+
+// arguments returns the argument list of `fields`, selected from `prefix`
+// should it be given, else named by parameter.
+func arguments(fields []Field, prefix string) string {
+	var arguments []string
+
+	for _, field := range fields {
+		if 0 == len(prefix) {
+			arguments = append(arguments, parameter(field))
+		} else {
+			arguments = append(arguments, prefix+field.Name)
+		}
+	}
+
+	return strings.Join(arguments, ", ")
+}
+
+// parameter returns the name of the parameter taking the value of `field`,
+// such as `id` for `ID` or `userID` for `UserID`, suffixed with `Value`
+// should it be a keyword.
+func parameter(field Field) string {
+	var (
+		name  = lastName(field.Name)
+		runes = []rune(name)
+	)
+
+	if strings.ToUpper(name) == name {
+		name = strings.ToLower(name)
+	} else {
+		for index, character := range runes {
+			if !unicode.IsUpper(character) || (0 != index && index+1 < len(runes) && unicode.IsLower(runes[index+1])) {
+				break
+			}
+
+			runes[index] = unicode.ToLower(character)
+		}
+
+		name = string(runes)
+	}
+
+	if token.IsKeyword(name) {
+		name += "Value"
+	}
+
+	return name
+}
@@ -0,0 +1,253 @@
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// ScaffoldConfig configures the structs Scaffold generates.
+type ScaffoldConfig struct {
+	Package   string   // Name of the package generated, `models` if empty.
+	Tag       string   // Struct tag fields are mapped through, cartographer.DefaultStructTag if empty.
+	Tables    []string // Tables structs are generated for, every table of the schema if empty.
+	NullTypes bool     // Type nullable columns as sql.NullString and the like rather than as pointers?
+}
+
+// Scaffold returns the gofmt'd source of a tagged struct for each table of
+// `schema`, such as that returned by cartographer's IntrospectSchema or
+// ParseDDL, bootstrapping the mapping of an existing database. Structs are
+// named after their table in the singular, declaring a TableName method
+// should their name not snake case back to it, and their fields are tagged:
+//
+//   - `pk` for columns of the table's primary key;
+//   - `omitempty` for other columns the database generates, such as
+//     serial columns, so that their values are left to the database;
+//   - `nullable` for nullable columns, typed as pointers or, should
+//     ScaffoldConfig.NullTypes be set, as sql.NullString and the like;
+//   - `autocreate` and `autoupdate` for NOT NULL timestamps named as the
+//     creation or update times of their rows, such as `created_at`.
+//
+// Types are suggestions inferred from the columns' types, those unknown
+// being typed as strings; review the structs before relying upon them.
+func Scaffold(schema cartographer.Schema, config ScaffoldConfig) ([]byte, error) {
+	if 0 == len(config.Package) {
+		config.Package = "models"
+	}
+
+	if 0 == len(config.Tag) {
+		config.Tag = cartographer.DefaultStructTag
+	}
+
+	var (
+		tables  = schema.Tables
+		imports = make(map[string]bool)
+		body    bytes.Buffer
+	)
+
+	if 0 != len(config.Tables) {
+		tables = nil
+
+		for _, name := range config.Tables {
+			table, ok := schema.Table(name)
+
+			if !ok {
+				return nil, errors.New(fmt.Sprintf("No table %s within the schema", name))
+			}
+
+			tables = append(tables, table)
+		}
+	}
+
+	for _, table := range tables {
+		var name = singular(camelCase(table.Name))
+
+		fmt.Fprintf(&body, "\n// %s is a row of table %s.\ntype %s struct {\n", name, table.Name, name)
+
+		for _, column := range table.Columns {
+			typ, path := scaffoldType(column, config.NullTypes)
+			options := scaffoldOptions(table, column)
+
+			if 0 != len(path) {
+				imports[path] = true
+			}
+
+			fmt.Fprintf(&body, "%s %s `%s:%q`\n", camelCase(column.Name), typ, config.Tag, strings.Join(append([]string{column.Name}, options...), ","))
+		}
+
+		fmt.Fprintf(&body, "}\n")
+
+		if cartographer.SnakeCase(name) != table.Name {
+			fmt.Fprintf(&body, "\n// TableName returns %s, the table of %s rows.\n", table.Name, name)
+			fmt.Fprintf(&body, "func (%s) TableName() string {\nreturn %q\n}\n", name, table.Name)
+		}
+	}
+
+	var (
+		buffer bytes.Buffer
+		paths  []string
+	)
+
+	for path, _ := range imports {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	fmt.Fprintf(&buffer, "// Package %s was scaffolded by cartographer-scaffold.\npackage %s\n", config.Package, config.Package)
+
+	if 0 != len(paths) {
+		fmt.Fprintf(&buffer, "\nimport (\n")
+
+		for _, path := range paths {
+			fmt.Fprintf(&buffer, "%q\n", path)
+		}
+
+		fmt.Fprintf(&buffer, ")\n")
+	}
+
+	buffer.Write(body.Bytes())
+
+	return format.Source(buffer.Bytes())
+}
+
+// scaffoldKinds maps the leading word of column types to the kind of Go
+// type suggested for them.
+var scaffoldKinds = map[string]string{
+	"tinyint": "int8", "smallint": "int16", "int2": "int16", "smallserial": "int16",
+	"int": "int32", "integer": "int32", "int4": "int32", "mediumint": "int32", "serial": "int32",
+	"bigint": "int64", "int8": "int64", "bigserial": "int64",
+	"real": "float32", "float4": "float32",
+	"float": "float64", "float8": "float64", "double": "float64", "numeric": "float64", "decimal": "float64",
+	"bool": "bool", "boolean": "bool", "bit": "bool",
+	"date": "time", "datetime": "time", "timestamp": "time", "timestamptz": "time", "time": "time",
+	"bytea": "bytes", "blob": "bytes", "binary": "bytes", "varbinary": "bytes", "longblob": "bytes",
+}
+
+// nullTypes maps the Go types suggested for columns to the sql.Null type
+// suggested for them should they be nullable.
+var nullTypes = map[string]string{
+	"string": "sql.NullString", "int8": "sql.NullInt16", "int16": "sql.NullInt16", "int32": "sql.NullInt32",
+	"int64": "sql.NullInt64", "float32": "sql.NullFloat64", "float64": "sql.NullFloat64", "bool": "sql.NullBool",
+	"time.Time": "sql.NullTime",
+}
+
+// scaffoldType returns the Go type suggested for `column`, along with the
+// import path of the package it names, if any.
+func scaffoldType(column cartographer.Column, null bool) (typ string, path string) {
+	var word = strings.FieldsFunc(column.Type, func(character rune) bool {
+		return ' ' == character || '(' == character
+	})
+
+	typ = "string"
+
+	if 0 != len(word) {
+		switch kind := scaffoldKinds[word[0]]; kind {
+		case "":
+		case "time":
+			typ, path = "time.Time", "time"
+		case "bytes":
+			return "[]byte", "" // Slices hold NULL as nil.
+		default:
+			typ = kind
+		}
+	}
+
+	if strings.Contains(column.Type, "unsigned") && strings.HasPrefix(typ, "int") {
+		typ = "u" + typ
+	}
+
+	if !column.Nullable {
+		return
+	}
+
+	if nullType, ok := nullTypes[typ]; ok && null {
+		return nullType, "database/sql"
+	}
+
+	return "*" + typ, path
+}
+
+// scaffoldOptions returns the tag options suggested for `column` of `table`.
+func scaffoldOptions(table cartographer.Table, column cartographer.Column) (options []string) {
+	var keys int
+
+	for _, other := range table.Columns {
+		if other.PrimaryKey {
+			keys++
+		}
+	}
+
+	if column.PrimaryKey {
+		options = append(options, "pk")
+	}
+
+	// A single primary key is left for the database to generate while
+	// zero, other generated columns only when omitted.
+	if column.Generated && (!column.PrimaryKey || 1 != keys) {
+		options = append(options, "omitempty")
+	}
+
+	if column.Nullable {
+		options = append(options, "nullable")
+	} else if typ, _ := scaffoldType(column, false); "time.Time" == typ {
+		switch column.Name {
+		case "created_at", "created", "inserted_at":
+			options = append(options, "autocreate")
+		case "updated_at", "updated", "modified_at":
+			options = append(options, "autoupdate")
+		}
+	}
+
+	return
+}
+
+// initialisms are the words camelCase writes in upper case.
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true, "json": true,
+	"sku": true, "sql": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// camelCase converts `name`, such as `user_id`, to an exported Go
+// identifier, such as `UserID`.
+func camelCase(name string) string {
+	var builder strings.Builder
+
+	for _, word := range strings.FieldsFunc(name, func(character rune) bool {
+		return !('a' <= character && character <= 'z' || 'A' <= character && character <= 'Z' || '0' <= character && character <= '9')
+	}) {
+		if initialisms[strings.ToLower(word)] {
+			builder.WriteString(strings.ToUpper(word))
+		} else {
+			builder.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	if identifier := builder.String(); 0 != len(identifier) && !('0' <= identifier[0] && identifier[0] <= '9') {
+		return identifier
+	}
+
+	return "X" + builder.String()
+}
+
+// singular returns the singular of English noun `plural`, such as `User`
+// for `Users` or `Category` for `Categories`.
+func singular(plural string) string {
+	switch {
+	case strings.HasSuffix(plural, "ies"):
+		return strings.TrimSuffix(plural, "ies") + "y"
+	case strings.HasSuffix(plural, "sses"), strings.HasSuffix(plural, "xes"), strings.HasSuffix(plural, "ches"), strings.HasSuffix(plural, "shes"):
+		return strings.TrimSuffix(plural, "es")
+	case strings.HasSuffix(plural, "ss"), strings.HasSuffix(plural, "us"), strings.HasSuffix(plural, "is"):
+		return plural
+	case strings.HasSuffix(plural, "s"):
+		return strings.TrimSuffix(plural, "s")
+	}
+
+	return plural
+}
@@ -0,0 +1,81 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+const scaffoldDDL = `
+CREATE TABLE categories (
+	id serial PRIMARY KEY,
+	parent_id integer,
+	name varchar(64) NOT NULL,
+	created_at timestamp NOT NULL,
+	updated_at timestamp,
+	thumbnail bytea
+);
+
+CREATE TABLE user_roles (
+	user_id bigint unsigned NOT NULL,
+	role text NOT NULL,
+	PRIMARY KEY (user_id, role)
+);
+`
+
+func TestScaffold(t *testing.T) {
+	schema, err := cartographer.ParseDDL(scaffoldDDL)
+
+	if nil != err {
+		t.Fatalf("Basic Scaffold test returned an unexpected error: %v", err)
+	}
+
+	source, err := Scaffold(schema, ScaffoldConfig{})
+
+	if nil != err {
+		t.Fatalf("Basic Scaffold test returned an unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"package models\n\nimport (\n\t\"time\"\n)",
+		"type Category struct {",
+		"ID        int32      `db:\"id,pk\"`",
+		"ParentID  *int32     `db:\"parent_id,nullable\"`",
+		"CreatedAt time.Time  `db:\"created_at,autocreate\"`",
+		"UpdatedAt *time.Time `db:\"updated_at,nullable\"`",
+		"Thumbnail []byte     `db:\"thumbnail,nullable\"`",
+		"func (Category) TableName() string {\n\treturn \"categories\"\n}",
+		"type UserRole struct {",
+		"UserID uint64 `db:\"user_id,pk\"`",
+		"func (UserRole) TableName() string {\n\treturn \"user_roles\"\n}",
+	} {
+		if !strings.Contains(string(source), expected) {
+			t.Errorf("Basic Scaffold test expected scaffolded source to contain %s:\n%s", expected, source)
+		}
+	}
+
+	source, err = Scaffold(schema, ScaffoldConfig{Package: "legacy", Tables: []string{"categories"}, NullTypes: true})
+
+	if nil != err {
+		t.Fatalf("Basic Scaffold test returned an unexpected error: %v", err)
+	}
+
+	if !strings.Contains(string(source), "ParentID  sql.NullInt32") || !strings.Contains(string(source), "\"database/sql\"") || strings.Contains(string(source), "UserRole") {
+		t.Errorf("Basic Scaffold test returned unexpected scaffolded source:\n%s", source)
+	}
+
+	if _, err = Scaffold(schema, ScaffoldConfig{Tables: []string{"missing"}}); nil == err {
+		t.Errorf("Basic Scaffold test expected an error scaffolding a missing table")
+	}
+}
+
+func TestSingular(t *testing.T) {
+	var nouns = map[string]string{"Users": "User", "Categories": "Category", "Addresses": "Address", "Status": "Status", "Boxes": "Box", "Data": "Data"}
+
+	for plural, expected := range nouns {
+		if received := singular(plural); expected != received {
+			t.Errorf("Basic Singular test expected %s to be singular %s, received %s", plural, expected, received)
+		}
+	}
+}
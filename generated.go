@@ -0,0 +1,169 @@
+package cartographer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ColumnScanner is implemented by types setting their own fields from
+// scanned column values without reflection, such as those generated by
+// cartographer-gen. ScanColumn sets the field mapped to `column` from
+// `value`, reporting whether the column is mapped; nil values should
+// leave the field untouched, as Map does for NULL columns.
+type ColumnScanner interface {
+	ScanColumn(column string, value interface{}) (bool, error)
+}
+
+// MapScanned behaves as Map for the ColumnScanner `*T`, returning a
+// pointer to a new `T` for each row of `rows` without reflecting over
+// its fields. Columns `*T` does not map are skipped.
+func MapScanned[T any, P interface {
+	*T
+	ColumnScanner
+}](rows ScannableRows) (results []*T, err error) {
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	for row := 0; rows.Next(); row++ {
+		var item = new(T)
+
+		if err = scanColumns(rows, columns, P(item), row); nil != err {
+			return nil, err
+		}
+
+		results = append(results, item)
+	}
+
+	return
+}
+
+// SyncScanned behaves as Sync for ColumnScanner `o`, setting its fields
+// from the single row of `rows` without reflection, or returns
+// ErrMultipleRows should a second row be read.
+func SyncScanned(rows ScannableRows, o ColumnScanner) (err error) {
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	for row := 0; rows.Next(); row++ {
+		if 1 == row {
+			return ErrMultipleRows
+		}
+
+		if err = scanColumns(rows, columns, o, row); nil != err {
+			return
+		}
+	}
+
+	return
+}
+
+// scanColumns scans the current row of `rows` into ColumnScanner `o`,
+// column by column.
+func scanColumns(rows ScannableRows, columns []string, o ColumnScanner, row int) (err error) {
+	values, err := populatedRowValues(rows, len(columns))
+
+	if nil != err {
+		return
+	}
+
+	for index, column := range columns {
+		if _, err = o.ScanColumn(column, *values[index].(*interface{})); nil != err {
+			return errors.New(fmt.Sprintf("Failed to scan column %s at row %d: %v", column, row, err))
+		}
+	}
+
+	return
+}
+
+// AsString converts a scanned `value` to a string, as Map does for string fields.
+func AsString(value interface{}) string {
+	return parseString(value)
+}
+
+// AsInt converts a scanned `value` to an integer, as Map does for
+// integer fields, or returns an error if it can not be converted.
+func AsInt(value interface{}) (integer int64, err error) {
+	err = protect(func() (err error) {
+		integer, err = parseInt(value)
+		return
+	}, "converting %T to an integer", value)
+
+	return
+}
+
+// AsFloat converts a scanned `value` to a float, as Map does for
+// float fields, or returns an error if it can not be converted.
+func AsFloat(value interface{}) (float float64, err error) {
+	err = protect(func() (err error) {
+		float, err = parseFloat(value)
+		return
+	}, "converting %T to a float", value)
+
+	return
+}
+
+// AsBool converts a scanned `value` to a boolean, as Map does for
+// boolean fields, or returns an error if it can not be converted.
+func AsBool(value interface{}) (boolean bool, err error) {
+	err = protect(func() (err error) {
+		boolean, err = parseBool(value)
+		return
+	}, "converting %T to a boolean", value)
+
+	return
+}
+
+// Assign sets the value pointed to by `dest` from the scanned `value`,
+// leaving it untouched should `value` be nil. Destinations implementing
+// sql.Scanner scan the value themselves, common destinations are set
+// without reflection, and any other is set as Map would set a field of
+// its type.
+func Assign(dest interface{}, value interface{}) (err error) {
+	if nil == value {
+		return
+	}
+
+	switch dest := dest.(type) {
+	case sql.Scanner:
+		return dest.Scan(value)
+	case *string:
+		*dest = AsString(value)
+	case *int64:
+		*dest, err = AsInt(value)
+	case *float64:
+		*dest, err = AsFloat(value)
+	case *bool:
+		*dest, err = AsBool(value)
+	case *time.Time:
+		if moment, ok := value.(time.Time); ok {
+			*dest = moment
+		} else {
+			err = errors.New(fmt.Sprintf("Failed to assign %T to a time", value))
+		}
+	case *[]byte:
+		if bytes, ok := value.([]byte); ok {
+			*dest = append([]byte(nil), bytes...)
+		} else {
+			*dest = []byte(AsString(value))
+		}
+	default:
+		target := reflect.ValueOf(dest)
+
+		if reflect.Ptr != target.Kind() || target.IsNil() {
+			return errors.New(fmt.Sprintf("Assign expected a pointer to be passed, received %T", dest))
+		}
+
+		err = protect(func() error { return setFieldValue(target.Elem(), value) }, "assigning %T to %T", value, dest)
+	}
+
+	return
+}
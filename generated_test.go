@@ -0,0 +1,119 @@
+package cartographer
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+type scanned struct {
+	Id   int
+	Name string
+}
+
+func (self *scanned) ScanColumn(column string, value interface{}) (bool, error) {
+	switch column {
+	case "id":
+		if nil != value {
+			integer, err := AsInt(value)
+
+			if nil != err {
+				return true, err
+			}
+
+			self.Id = int(integer)
+		}
+	case "name":
+		if nil != value {
+			self.Name = AsString(value)
+		}
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func TestMapScanned(t *testing.T) {
+	rows := &fakeRows{columns: []string{"id", "name", "extra"}, values: [][]interface{}{{int64(1), []byte("ada"), 1}, {"2", nil, 2}}}
+	results, err := MapScanned[scanned](rows)
+
+	if nil != err {
+		t.Fatalf("Basic Map test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(results) || 1 != results[0].Id || "ada" != results[0].Name || 2 != results[1].Id || "" != results[1].Name {
+		t.Errorf("Basic Map test returned unexpected results: %+v", results)
+	}
+
+	rows = &fakeRows{columns: []string{"id"}, values: [][]interface{}{{"not a number"}}}
+
+	if _, err = MapScanned[scanned](rows); nil == err {
+		t.Errorf("Basic Map test expected an error scanning an invalid integer")
+	}
+}
+
+func TestSyncScanned(t *testing.T) {
+	var item = scanned{Name: "kept"}
+
+	if err := SyncScanned(&fakeRows{columns: []string{"id", "name"}, values: [][]interface{}{{int64(5), nil}}}, &item); nil != err {
+		t.Fatalf("Basic Sync test returned an unexpected error: %v", err)
+	}
+
+	if 5 != item.Id || "kept" != item.Name {
+		t.Errorf("Basic Sync test returned unexpected item: %+v", item)
+	}
+
+	if err := SyncScanned(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{1}, {2}}}, &item); ErrMultipleRows != err {
+		t.Errorf("Basic Sync test expected ErrMultipleRows, received %v", err)
+	}
+}
+
+func TestAssign(t *testing.T) {
+	var (
+		text    string
+		integer int16
+		moment  time.Time
+		bytes   []byte
+		null    sql.NullInt64
+		now     = time.Now()
+	)
+
+	if err := Assign(&text, []byte("text")); nil != err || "text" != text {
+		t.Errorf("Basic Assign test returned unexpected string: %q, %v", text, err)
+	}
+
+	if err := Assign(&integer, "12"); nil != err || 12 != integer {
+		t.Errorf("Basic Assign test returned unexpected integer: %d, %v", integer, err)
+	}
+
+	if err := Assign(&moment, now); nil != err || !now.Equal(moment) {
+		t.Errorf("Basic Assign test returned unexpected time: %v, %v", moment, err)
+	}
+
+	if err := Assign(&moment, "now"); nil == err {
+		t.Errorf("Basic Assign test expected an error assigning a string to a time")
+	}
+
+	source := []byte("raw")
+
+	if err := Assign(&bytes, source); nil != err || "raw" != string(bytes) {
+		t.Errorf("Basic Assign test returned unexpected bytes: %q, %v", bytes, err)
+	}
+
+	if source[0] = 'w'; "raw" != string(bytes) {
+		t.Errorf("Basic Assign test expected assigned bytes to be copied")
+	}
+
+	if err := Assign(&null, int64(7)); nil != err || !null.Valid || 7 != null.Int64 {
+		t.Errorf("Basic Assign test returned unexpected sql.NullInt64 %+v, %v", null, err)
+	}
+
+	if err := Assign(&text, nil); nil != err || "text" != text {
+		t.Errorf("Basic Assign test expected nil to leave the destination untouched, received %q", text)
+	}
+
+	if err := Assign(text, "value"); nil == err {
+		t.Errorf("Basic Assign test expected an error assigning to a non-pointer")
+	}
+}
@@ -0,0 +1,294 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ContextHook is a Hook which additionally receives the context passed
+// to MapContext, allowing request-scoped values such as tenants, locales
+// or trace IDs to be read while hydrating each row.
+type ContextHook func(context.Context, reflect.Value) error
+
+// WithContext adapts the Hook to a ContextHook which ignores its context.
+func (self Hook) WithContext() ContextHook {
+	return func(ctx context.Context, value reflect.Value) error {
+		return self(value)
+	}
+}
+
+// Row describes the database row a struct was hydrated from.
+type Row struct {
+	Index   int           // Index of the row within the rows mapped or synced.
+	Columns []string      // Columns of the row.
+	Values  []interface{} // Raw values scanned for each column, before any Transform.
+}
+
+// RowHook is a ContextHook which additionally receives the Row the struct
+// was hydrated from, allowing hooks to capture unmapped columns, compute
+// derived fields or log exactly what the database returned.
+type RowHook func(context.Context, reflect.Value, Row) error
+
+// WithRow adapts the ContextHook to a RowHook which ignores its Row.
+func (self ContextHook) WithRow() RowHook {
+	return func(ctx context.Context, value reflect.Value, row Row) error {
+		return self(ctx, value)
+	}
+}
+
+// contextHooks adapts each of the `hooks` to a ContextHook.
+func contextHooks(hooks []Hook) (adapted []ContextHook) {
+	for _, hook := range hooks {
+		adapted = append(adapted, hook.WithContext())
+	}
+
+	return
+}
+
+// HookFor adapts function `fn`, which works with a pointer to the
+// concrete struct `T`, to a Hook. The returned Hook errors if it is
+// given a value of any other type.
+func HookFor[T any](fn func(*T) error) Hook {
+	return func(value reflect.Value) error {
+		item, ok := value.Interface().(*T)
+
+		if !ok {
+			return errors.New(fmt.Sprintf("Hook expected %T, received %v", item, value.Type()))
+		}
+
+		return fn(item)
+	}
+}
+
+// RegisterHook attaches Hook `hook` to parameter `o`'s type, running it
+// for every struct of the type hydrated by Map or synced by Sync, after
+// the struct's fields have been set, or returns an error if `o` is not
+// a struct. The hook is unnamed and registered with a priority of zero.
+func (self *Cartographer) RegisterHook(o interface{}, hook Hook) error {
+	return self.RegisterNamedHook(o, "", 0, hook)
+}
+
+// RegisterNamedHook behaves as RegisterHook, naming the hook `name` so
+// it may later be replaced or removed. Registered hooks run in ascending
+// order of `priority`, hooks of equal priority in the order they were
+// registered. Registering a name already registered for the type
+// replaces the existing hook.
+func (self *Cartographer) RegisterNamedHook(o interface{}, name string, priority int, hook Hook) error {
+	return self.RegisterNamedRowHook(o, name, priority, hook.WithContext().WithRow())
+}
+
+// RegisterRowHook behaves as RegisterHook for a RowHook, which receives
+// the columns and raw values of the row hydrated alongside the struct.
+func (self *Cartographer) RegisterRowHook(o interface{}, hook RowHook) error {
+	return self.RegisterNamedRowHook(o, "", 0, hook)
+}
+
+// RegisterNamedRowHook behaves as RegisterNamedHook for a RowHook.
+func (self *Cartographer) RegisterNamedRowHook(o interface{}, name string, priority int, hook RowHook) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	self.hooks[typ] = register(self.hooks[typ], registered[RowHook]{name, priority, hook})
+
+	return nil
+}
+
+// RemoveHook removes the hook named `name` registered for parameter
+// `o`'s type, or returns an error if `o` is not a struct or no
+// such hook is registered.
+func (self *Cartographer) RemoveHook(o interface{}, name string) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	hooks, removed := unregister(self.hooks[typ], name)
+
+	if !removed {
+		return errors.New(fmt.Sprintf("No hook named %s on %v", name, typ))
+	}
+
+	self.hooks[typ] = hooks
+
+	return nil
+}
+
+// runRegisteredHooks runs the hooks registered for the type pointed
+// to by `value` with context `ctx`, against row `row`.
+func (self *Cartographer) runRegisteredHooks(ctx context.Context, value reflect.Value, row Row) (err error) {
+	for _, hook := range self.hooks[value.Type().Elem()] {
+		if err = protect(func() error { return hook.hook(ctx, value, row) }, "in hook %q at row %d", hook.name, row.Index); nil != err {
+			self.metricsFor(ctx).HookFailed(value.Type().Elem(), hook.name)
+			return // Hook returned an error, return it to caller to deal with.
+		}
+	}
+
+	return
+}
+
+// BeforeScanner is implemented by types wanting to be notified before
+// Map or Sync populate their fields from a row.
+type BeforeScanner interface {
+	BeforeScan() error
+}
+
+// AfterScanner is implemented by types wanting to be notified after
+// Map or Sync populate their fields from a row, such as to derive
+// fields or transform scanned values.
+type AfterScanner interface {
+	AfterScan() error
+}
+
+// beforeScan calls BeforeScan on `value` if it implements BeforeScanner.
+func beforeScan(value reflect.Value) error {
+	if scanner, ok := value.Interface().(BeforeScanner); ok {
+		return scanner.BeforeScan()
+	}
+
+	return nil
+}
+
+// afterScan calls AfterScan on `value` if it implements AfterScanner.
+func afterScan(value reflect.Value) error {
+	if scanner, ok := value.Interface().(AfterScanner); ok {
+		return scanner.AfterScan()
+	}
+
+	return nil
+}
+
+// WriteHook transforms the map of a struct's fields to their values
+// produced by FieldValueMapFor, and in turn ModifiedColumnsValuesMapFor,
+// such as to hash a password or stamp an updated at time. The map is
+// keyed by field name and may be modified in place; the struct itself
+// is passed as `value` for reference.
+type WriteHook func(value reflect.Value, values map[interface{}]interface{}) error
+
+// RegisterWriteHook attaches WriteHook `hook` to parameter `o`'s type,
+// or returns an error if `o` is not a struct. The hook is unnamed and
+// registered with a priority of zero.
+func (self *Cartographer) RegisterWriteHook(o interface{}, hook WriteHook) error {
+	return self.RegisterNamedWriteHook(o, "", 0, hook)
+}
+
+// RegisterNamedWriteHook behaves as RegisterWriteHook, naming and
+// ordering the hook as RegisterNamedHook does.
+func (self *Cartographer) RegisterNamedWriteHook(o interface{}, name string, priority int, hook WriteHook) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	self.writeHooks[typ] = register(self.writeHooks[typ], registered[WriteHook]{name, priority, hook})
+
+	return nil
+}
+
+// RemoveWriteHook removes the write hook named `name` registered for
+// parameter `o`'s type, or returns an error if `o` is not a struct or
+// no such hook is registered.
+func (self *Cartographer) RemoveWriteHook(o interface{}, name string) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	hooks, removed := unregister(self.writeHooks[typ], name)
+
+	if !removed {
+		return errors.New(fmt.Sprintf("No write hook named %s on %v", name, typ))
+	}
+
+	self.writeHooks[typ] = hooks
+
+	return nil
+}
+
+// registered is a hook registered for a type, along with its name and priority.
+type registered[H any] struct {
+	name     string // Name of the hook, empty for unnamed hooks.
+	priority int    // Priority of the hook, lower priorities run first.
+	hook     H      // The hook itself.
+}
+
+// register inserts `entry` into the ordered `hooks`, after any hooks of
+// equal or lower priority, replacing an existing hook of the same name.
+func register[H any](hooks []registered[H], entry registered[H]) []registered[H] {
+	if 0 != len(entry.name) {
+		hooks, _ = unregister(hooks, entry.name)
+	}
+
+	position := len(hooks)
+
+	for index, hook := range hooks {
+		if entry.priority < hook.priority {
+			position = index
+			break
+		}
+	}
+
+	return append(hooks[:position:position], append([]registered[H]{entry}, hooks[position:]...)...)
+}
+
+// unregister removes the hook named `name` from `hooks`, reporting whether it was found.
+func unregister[H any](hooks []registered[H], name string) ([]registered[H], bool) {
+	for index, hook := range hooks {
+		if 0 != len(name) && name == hook.name {
+			return append(hooks[:index:index], hooks[index+1:]...), true
+		}
+	}
+
+	return hooks, false
+}
+
+// Transform receives a raw value scanned for a column, returning the
+// value to set on the column's field in its place, such as trimming
+// padded CHAR columns or decoding base64.
+type Transform func(value interface{}) (interface{}, error)
+
+// RegisterTransform attaches Transform `transform` to column `column` of
+// parameter `o`'s type, running it against each value scanned for the
+// column by Map or Sync before the value is set, or returns an error if
+// `o` is not a struct or the column is not mapped. Transforms registered
+// for the same column run in the order they were registered.
+func (self *Cartographer) RegisterTransform(o interface{}, column string, transform Transform) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	if _, ok := self.columnsToFields[typ][column]; !ok {
+		return errors.New(fmt.Sprintf("No field for column %s on %v", column, typ))
+	}
+
+	if nil == self.transforms[typ] {
+		self.transforms[typ] = make(map[string][]Transform)
+	}
+
+	self.transforms[typ][column] = append(self.transforms[typ][column], transform)
+
+	return nil
+}
+
+// transform runs the transforms registered for column `column`
+// of type `typ` against the scanned `value`.
+func (self *Cartographer) transform(typ reflect.Type, column string, value interface{}) (interface{}, error) {
+	var err error
+
+	for _, transform := range self.transforms[typ][column] {
+		if value, err = transform(value); nil != err {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
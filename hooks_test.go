@@ -0,0 +1,219 @@
+package cartographer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestMapContext(t *testing.T) {
+	var (
+		ctx  = context.WithValue(context.Background(), tenantKey{}, "acme")
+		seen interface{}
+	)
+
+	hook := ContextHook(func(ctx context.Context, value reflect.Value) error {
+		seen = ctx.Value(tenantKey{})
+		return nil
+	})
+
+	results, err := instance.MapContext(ctx, &scanner{}, faker{}, hook)
+
+	if nil != err || 1 != len(results) {
+		t.Errorf("Basic MapContext test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if "acme" != seen {
+		t.Errorf("Basic MapContext test expected hook to receive context value, received %v", seen)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, err = instance.MapContext(ctx, &scanner{}, faker{}); context.Canceled != err {
+		t.Errorf("Canceled MapContext test returned an unexpected error: %v", err)
+	}
+}
+
+func TestHookFor(t *testing.T) {
+	hook := HookFor(func(item *faker) error {
+		item.Id = 42
+		return nil
+	})
+
+	results, err := instance.Map(&scanner{}, faker{}, hook)
+
+	if nil != err || 1 != len(results) {
+		t.Fatalf("Basic HookFor test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if _, err = instance.Map(&scanner{}, indexed{}, hook); nil == err {
+		t.Errorf("Basic HookFor test expected an error for a mismatched type")
+	}
+}
+
+func TestRegisterHook(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	cartographer.RegisterHook(faker{}, HookFor(func(item *faker) error {
+		item.Id *= 10
+		return nil
+	}))
+
+	results, err := cartographer.Map(&scanner{}, faker{})
+
+	if nil != err || 1 != len(results) || 10 != results[0].(*faker).Id {
+		t.Errorf("Basic RegisterHook test returned an unexpected results: %v, %v", results, err)
+	}
+
+	var item faker
+
+	if err = cartographer.Sync(&scanner{}, &item); nil != err || 10 != item.Id {
+		t.Errorf("Basic RegisterHook Sync test returned an unexpected results: %v, %v", item, err)
+	}
+}
+
+type callbacks struct {
+	Id     int `db:"id"`
+	before int
+	after  int
+}
+
+func (self *callbacks) BeforeScan() error {
+	self.before = self.Id
+	return nil
+}
+
+func (self *callbacks) AfterScan() error {
+	self.after = self.Id
+	return nil
+}
+
+func TestScanCallbacks(t *testing.T) {
+	results, err := instance.Map(&scanner{}, callbacks{})
+
+	if nil != err || 1 != len(results) {
+		t.Fatalf("Basic scan callbacks test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if item := results[0].(*callbacks); 0 != item.before || 1 != item.after {
+		t.Errorf("Basic scan callbacks test invoked callbacks unexpectedly: %v", item)
+	}
+}
+
+func TestRegisterWriteHook(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	cartographer.RegisterWriteHook(faker{}, func(value reflect.Value, values map[interface{}]interface{}) error {
+		values["Id"] = values["Id"].(int) * 10
+		return nil
+	})
+
+	values, err := cartographer.ModifiedColumnsValuesMapFor(map[interface{}]interface{}{"Id": 1}, faker{2})
+
+	if nil != err || 20 != values["id"] {
+		t.Errorf("Basic RegisterWriteHook test returned an unexpected results: %v, %v", values, err)
+	}
+}
+
+func TestRegisterNamedHook(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		order        []string
+	)
+
+	record := func(name string) Hook {
+		return func(value reflect.Value) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	cartographer.RegisterNamedHook(faker{}, "audit", 10, record("audit"))
+	cartographer.RegisterNamedHook(faker{}, "decrypt", -10, record("decrypt"))
+	cartographer.RegisterNamedHook(faker{}, "audit", 0, record("replaced"))
+	cartographer.Map(&scanner{}, faker{})
+
+	if 2 != len(order) || "decrypt" != order[0] || "replaced" != order[1] {
+		t.Errorf("Basic RegisterNamedHook test ran hooks in an unexpected order: %v", order)
+	}
+
+	if err := cartographer.RemoveHook(faker{}, "decrypt"); nil != err {
+		t.Errorf("Basic RemoveHook test returned an unexpected error: %v", err)
+	}
+
+	if err := cartographer.RemoveHook(faker{}, "decrypt"); nil == err {
+		t.Errorf("Basic RemoveHook test expected an error removing an unregistered hook")
+	}
+
+	order = nil
+	cartographer.Map(&scanner{}, faker{})
+
+	if 1 != len(order) || "replaced" != order[0] {
+		t.Errorf("Basic RemoveHook test ran unexpected hooks: %v", order)
+	}
+}
+
+func TestRegisterTransform(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	err := cartographer.RegisterTransform(faker{}, "id", func(value interface{}) (interface{}, error) {
+		return value.(int) + 1, nil
+	})
+
+	if nil != err {
+		t.Errorf("Basic RegisterTransform test returned an unexpected error: %v", err)
+	}
+
+	results, err := cartographer.Map(&scanner{}, faker{})
+
+	if nil != err || 1 != len(results) || 2 != results[0].(*faker).Id {
+		t.Errorf("Basic RegisterTransform test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if err = cartographer.RegisterTransform(faker{}, "missing", nil); nil == err {
+		t.Errorf("Basic RegisterTransform test expected an error for an unmapped column")
+	}
+}
+
+func TestHookPanicRecovery(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	cartographer.RegisterNamedHook(faker{}, "explode", 0, func(value reflect.Value) error {
+		panic("boom")
+	})
+
+	if _, err := cartographer.Map(&scanner{}, faker{}); nil == err {
+		t.Errorf("Hook panic recovery test expected an error")
+	}
+
+	rows := &fakeRows{columns: []string{"id"}, values: [][]interface{}{{"not a number"}}}
+
+	if _, err := instance.Map(rows, faker{}); nil == err {
+		t.Errorf("Conversion panic recovery test expected an error")
+	}
+}
+
+func TestRegisterRowHook(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		captured     Row
+	)
+
+	cartographer.RegisterRowHook(faker{}, func(ctx context.Context, value reflect.Value, row Row) error {
+		captured = row
+		return nil
+	})
+
+	rows := &fakeRows{columns: []string{"id"}, values: [][]interface{}{{1}, {2}}}
+
+	if _, err := cartographer.Map(rows, faker{}); nil != err {
+		t.Errorf("Basic RegisterRowHook test returned an unexpected error: %v", err)
+	}
+
+	if 1 != captured.Index || "id" != captured.Columns[0] || 2 != captured.Values[0] {
+		t.Errorf("Basic RegisterRowHook test captured an unexpected row: %v", captured)
+	}
+}
@@ -0,0 +1,182 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// inheritance describes the variants of a base type sharing its table.
+type inheritance struct {
+	column   string                  // Discriminator column.
+	variants map[string]reflect.Type // Variant types, by discriminator value.
+}
+
+// variant describes a variant type of a base type.
+type variant struct {
+	base  reflect.Type // Base type the variant shares a table with.
+	value string       // Discriminator value of the variant.
+}
+
+// RegisterVariant registers `variant`, a struct embedding the struct
+// `base`, as stored in base's table, rows whose `column` holds `value`
+// being of the variant, for single-table inheritance. Once registered,
+// Map given a `base` hydrates each row into its variant, rows of no
+// registered variant into `base`; SelectSQL of `base` selects the columns
+// of every variant; TableFor of the variant returns base's table; and
+// ColumnValueMapFor and InsertSQL of the variant include the
+// discriminator. An error is returned should either not be a struct, the
+// variant not map every column of `base`, or a different discriminator
+// column already be registered for `base`.
+func (self *Cartographer) RegisterVariant(base interface{}, column string, value string, o interface{}) error {
+	baseType, err := self.DiscoverType(base)
+
+	if nil != err {
+		return err
+	}
+
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	for _, mapped := range self.fields[baseType] {
+		if _, ok := self.byColumn[typ][mapped.column]; !ok {
+			return errors.New(fmt.Sprintf("Variant %v does not map column %s of %v", typ, mapped.column, baseType))
+		}
+	}
+
+	registered, ok := self.inheritance[baseType]
+
+	if !ok {
+		registered = &inheritance{column, make(map[string]reflect.Type)}
+		self.inheritance[baseType] = registered
+	} else if column != registered.column {
+		return errors.New(fmt.Sprintf("Discriminator %s of %v already registered, received %s", registered.column, baseType, column))
+	}
+
+	registered.variants[value] = typ
+	self.variants[typ] = variant{baseType, value}
+
+	return nil
+}
+
+// variantFor returns an example of the variant of `o` the row of `columns`
+// and scanned `values` holds, else `o` itself.
+func (self *Cartographer) variantFor(o interface{}, columns []string, values []interface{}) interface{} {
+	if nil == o {
+		return o
+	}
+
+	registered, ok := self.inheritance[indirectType(reflect.TypeOf(o))]
+
+	if !ok {
+		return o
+	}
+
+	for index, column := range columns {
+		if registered.column != column {
+			continue
+		}
+
+		if key, ok := relationKey(*values[index].(*interface{})); ok {
+			if typ, ok := registered.variants[key]; ok {
+				return reflect.New(typ).Interface()
+			}
+		}
+	}
+
+	return o
+}
+
+// assignBase sets struct value `target` to `result`, should it point to a
+// value of the target's type, else to the columns of the target's type held
+// by `result`, a pointer to a registered variant of the type, returning an
+// error should it be neither.
+func (self *Cartographer) assignBase(target reflect.Value, result interface{}) error {
+	var (
+		source = reflect.ValueOf(result).Elem()
+		typ    = target.Type()
+	)
+
+	if typ == source.Type() {
+		target.Set(source)
+		return nil
+	}
+
+	variantType, err := self.DiscoverType(result)
+
+	if nil != err {
+		return err
+	}
+
+	if variant, ok := self.variants[variantType]; !ok || typ != variant.base {
+		return errors.New(fmt.Sprintf("%v can not hold %T", typ, result))
+	}
+
+	for _, mapped := range self.fields[typ] {
+		held, ok := self.byColumn[variantType][mapped.column]
+
+		if !ok {
+			continue
+		}
+
+		if value := fieldByIndex(source, held.index, false); value.IsValid() && value.Type().AssignableTo(mapped.typ) {
+			fieldByIndex(target, mapped.index, true).Set(value)
+		}
+	}
+
+	return nil
+}
+
+// inheritedColumns returns the columns of the variants of type `typ` not
+// mapped by `typ` itself, the discriminator first and each variant's
+// columns in turn, ordered by discriminator value.
+func (self *Cartographer) inheritedColumns(typ reflect.Type) (columns []string) {
+	registered, ok := self.inheritance[typ]
+
+	if !ok {
+		return
+	}
+
+	var (
+		seen   = make(map[string]bool)
+		values []string
+	)
+
+	for _, mapped := range self.fields[typ] {
+		seen[mapped.column] = true
+	}
+
+	for value, _ := range registered.variants {
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	if !seen[registered.column] {
+		seen[registered.column] = true
+		columns = append(columns, registered.column)
+	}
+
+	for _, value := range values {
+		for _, mapped := range self.fields[registered.variants[value]] {
+			if !seen[mapped.column] {
+				seen[mapped.column] = true
+				columns = append(columns, mapped.column)
+			}
+		}
+	}
+
+	return
+}
+
+// discriminate sets the discriminator of variant type `typ` within
+// `values`, should `typ` be a registered variant.
+func (self *Cartographer) discriminate(typ reflect.Type, values map[interface{}]interface{}) {
+	if registered, ok := self.variants[typ]; ok {
+		values[self.inheritance[registered.base].column] = registered.value
+	}
+}
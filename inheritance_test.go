@@ -0,0 +1,149 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+type vehicle struct {
+	Id     int    `db:"id,pk"`
+	Wheels int    `db:"wheels"`
+	Kind   string `db:"kind"`
+}
+
+type truck struct {
+	vehicle
+	Payload int `db:"payload"`
+}
+
+type bus struct {
+	vehicle
+	Seats int `db:"seats"`
+}
+
+type scooter struct {
+	Id int `db:"id,pk"`
+}
+
+func inheriting(t *testing.T) *Cartographer {
+	c := Initialize("db")
+
+	if err := c.RegisterVariant(vehicle{}, "kind", "truck", truck{}); nil != err {
+		t.Fatalf("Basic RegisterVariant test returned an unexpected error: %v", err)
+	}
+
+	if err := c.RegisterVariant(vehicle{}, "kind", "bus", bus{}); nil != err {
+		t.Fatalf("Basic RegisterVariant test returned an unexpected error: %v", err)
+	}
+
+	return c
+}
+
+func TestRegisterVariantErrors(t *testing.T) {
+	c := inheriting(t)
+
+	if err := c.RegisterVariant(vehicle{}, "kind", "scooter", scooter{}); nil == err {
+		t.Error("Basic RegisterVariant test expected an error registering a variant not embedding its base")
+	}
+
+	if err := c.RegisterVariant(vehicle{}, "type", "other", truck{}); nil == err {
+		t.Error("Basic RegisterVariant test expected an error registering a second discriminator column")
+	}
+}
+
+func TestMapVariants(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "wheels", "kind", "payload", "seats"},
+		values: [][]interface{}{
+			{1, 6, "truck", 900, nil},
+			{2, 4, []byte("bus"), nil, 40},
+			{3, 2, "bicycle", nil, nil},
+		},
+	}
+
+	results, err := inheriting(t).Map(rows, vehicle{})
+
+	if nil != err {
+		t.Fatalf("Basic Map test returned an unexpected error: %v", err)
+	}
+
+	if item, ok := results[0].(*truck); !ok || 900 != item.Payload || 6 != item.Wheels {
+		t.Errorf("Basic Map test returned unexpected first result: %#v", results[0])
+	}
+
+	if item, ok := results[1].(*bus); !ok || 40 != item.Seats || 2 != item.Id {
+		t.Errorf("Basic Map test returned unexpected second result: %#v", results[1])
+	}
+
+	if item, ok := results[2].(*vehicle); !ok || "bicycle" != item.Kind {
+		t.Errorf("Basic Map test returned unexpected third result: %#v", results[2])
+	}
+}
+
+func TestVariantSQL(t *testing.T) {
+	c := inheriting(t)
+
+	if query, _ := c.SelectSQL(vehicle{}); "SELECT id, wheels, kind, seats, payload FROM vehicle" != query {
+		t.Errorf("Basic variant SQL test returned unexpected query: %q", query)
+	}
+
+	if table, _ := c.TableFor(bus{}); "vehicle" != table {
+		t.Errorf("Basic variant SQL test expected the base table, received %q", table)
+	}
+
+	query, args, err := c.InsertSQL(truck{Payload: 5})
+
+	if nil != err {
+		t.Fatalf("Basic variant SQL test returned an unexpected error: %v", err)
+	}
+
+	if "INSERT INTO vehicle (wheels, kind, payload) VALUES (?, ?, ?)" != query || "truck" != args[1] {
+		t.Errorf("Basic variant SQL test returned unexpected statement: %q %v", query, args)
+	}
+}
+
+func TestRepositoryFindAllVariants(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, wheels, kind, seats, payload FROM vehicle": {
+			columns: []string{"id", "wheels", "kind", "seats", "payload"},
+			rows:    [][]driver.Value{{int64(1), int64(6), "truck", nil, int64(900)}},
+		},
+	})
+
+	items, err := RepositoryFor[vehicle](inheriting(t), db).FindAll()
+
+	if nil != err {
+		t.Errorf("Basic Repository variant test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(items) || (vehicle{1, 6, "truck"}) != *items[0] {
+		t.Errorf("Basic Repository variant test expected the base of the variant, received %v", items)
+	}
+
+	items, err = RepositoryFor[vehicle](inheriting(t), db).FindWhere(nil)
+
+	if nil != err || 1 != len(items) || 6 != items[0].Wheels {
+		t.Errorf("Basic Repository variant test returned an unexpected results: %v, %v", items, err)
+	}
+}
+
+func TestRepositoryFindVariant(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, wheels, kind, seats, payload FROM vehicle WHERE id = ?": {
+			columns: []string{"id", "wheels", "kind", "seats", "payload"},
+			rows:    [][]driver.Value{{int64(1), int64(6), "truck", nil, int64(900)}},
+		},
+	})
+
+	item, err := RepositoryFor[vehicle](inheriting(t), db).Find(1)
+
+	if nil != err || (vehicle{1, 6, "truck"}) != *item {
+		t.Errorf("Basic Repository variant test returned unexpected vehicle: %v, %v", item, err)
+	}
+
+	var found vehicle
+
+	if err = inheriting(t).FindByPK(db, &found, 1); nil != err || (vehicle{1, 6, "truck"}) != found {
+		t.Errorf("Basic FindByPK test returned unexpected vehicle: %v, %v", found, err)
+	}
+}
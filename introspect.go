@@ -0,0 +1,139 @@
+package cartographer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// introspectColumns selects the columns within the schema bound to
+	// its placeholder.
+	introspectColumns = "SELECT * FROM information_schema.columns WHERE table_schema = %s"
+
+	// introspectKeys selects the table and column of each column of the
+	// primary keys within the schema bound to its placeholder.
+	introspectKeys = "SELECT kcu.table_name, kcu.column_name FROM information_schema.table_constraints tc " +
+		"JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name " +
+		"AND tc.table_schema = kcu.table_schema AND tc.table_name = kcu.table_name " +
+		"WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = %s"
+)
+
+// IntrospectSchema returns the Schema of the tables within schema `schema`
+// of Querier `db`, such as `public` for PostgreSQL or the database's name
+// for MySQL, as described by its information_schema, ordered by table
+// name. Columns are generated should their default draw from a sequence,
+// as those of PostgreSQL's serial columns do, should they be identity
+// columns, or should their `extra` information name auto_increment, as
+// MySQL's does. Databases without an information_schema, such as SQLite,
+// can not be introspected; read their DDL with ReadDDL instead.
+func (self *Cartographer) IntrospectSchema(db Querier, schema string) (introspected Schema, err error) {
+	columns, err := self.informationSchema(db, fmt.Sprintf(introspectColumns, self.bind(1)), schema)
+
+	if nil != err {
+		return
+	}
+
+	keys, err := self.informationSchema(db, fmt.Sprintf(introspectKeys, self.bind(1)), schema)
+
+	if nil != err {
+		return
+	}
+
+	var primary = make(map[string]bool) // Columns of primary keys, keyed by table and column.
+
+	for _, key := range keys {
+		primary[key["table_name"]+"."+key["column_name"]] = true
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		if columns[i]["table_name"] != columns[j]["table_name"] {
+			return columns[i]["table_name"] < columns[j]["table_name"]
+		}
+
+		return ordinal(columns[i]["ordinal_position"]) < ordinal(columns[j]["ordinal_position"])
+	})
+
+	for _, described := range columns {
+		var (
+			table  = described["table_name"]
+			column = Column{
+				Name:       described["column_name"],
+				Type:       strings.ToLower(described["data_type"]),
+				Nullable:   strings.EqualFold("YES", described["is_nullable"]),
+				PrimaryKey: primary[table+"."+described["column_name"]],
+				Generated: strings.HasPrefix(strings.ToLower(described["column_default"]), "nextval(") ||
+					strings.EqualFold("YES", described["is_identity"]) ||
+					strings.Contains(strings.ToLower(described["extra"]), "auto_increment"),
+			}
+		)
+
+		if index := introspected.table(table); -1 == index {
+			introspected.Tables = append(introspected.Tables, Table{Name: table, Columns: []Column{column}})
+		} else {
+			introspected.Tables[index].Columns = append(introspected.Tables[index].Columns, column)
+		}
+	}
+
+	return
+}
+
+// informationSchema returns the rows of information_schema `query` bound
+// to `args`, each a map of its lower cased columns to their values as
+// strings, NULLs being empty.
+func (self *Cartographer) informationSchema(db Querier, query string, args ...interface{}) (results []map[string]string, err error) {
+	rows, err := db.QueryContext(context.Background(), query, args...)
+
+	if nil != err {
+		return
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	for rows.Next() {
+		var (
+			values   = make([]interface{}, len(columns))
+			pointers = make([]interface{}, len(columns))
+			result   = make(map[string]string, len(columns))
+		)
+
+		for index, _ := range values {
+			pointers[index] = &values[index]
+		}
+
+		if err = rows.Scan(pointers...); nil != err {
+			return nil, err
+		}
+
+		for index, column := range columns {
+			switch value := values[index].(type) {
+			case nil:
+			case []byte:
+				result[strings.ToLower(column)] = string(value)
+			default:
+				result[strings.ToLower(column)] = fmt.Sprint(value)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// ordinal returns the ordinal position `position` of an information_schema
+// column, zero should it not be an integer.
+func ordinal(position string) int64 {
+	integer, _ := AsInt(position)
+
+	return integer
+}
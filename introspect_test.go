@@ -0,0 +1,53 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+)
+
+func TestIntrospectSchema(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		fmt.Sprintf(introspectColumns, "$1"): {
+			columns: []string{"TABLE_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "DATA_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "EXTRA"},
+			rows: [][]driver.Value{
+				{"users", "name", int64(2), "character varying", "YES", nil, ""},
+				{"accounts", "id", int64(1), "INT", "NO", nil, "auto_increment"},
+				{"users", "id", []byte("1"), "bigint", "NO", "nextval('users_id_seq'::regclass)", nil},
+			},
+		},
+		fmt.Sprintf(introspectKeys, "$1"): {
+			columns: []string{"table_name", "column_name"},
+			rows:    [][]driver.Value{{"users", "id"}, {"accounts", "id"}},
+		},
+	})
+
+	c := New(WithPlaceholder(Dollar))
+	schema, err := c.IntrospectSchema(db, "public")
+
+	if nil != err {
+		t.Fatalf("Basic IntrospectSchema test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(schema.Tables) || "accounts" != schema.Tables[0].Name || "users" != schema.Tables[1].Name {
+		t.Fatalf("Basic IntrospectSchema test returned unexpected tables: %+v", schema.Tables)
+	}
+
+	if expected := (Column{"id", "int", false, true, true}); expected != schema.Tables[0].Columns[0] {
+		t.Errorf("Basic IntrospectSchema test expected column %+v, received %+v", expected, schema.Tables[0].Columns[0])
+	}
+
+	users := schema.Tables[1]
+
+	if expected := (Column{"id", "bigint", false, true, true}); expected != users.Columns[0] {
+		t.Errorf("Basic IntrospectSchema test expected column %+v, received %+v", expected, users.Columns[0])
+	}
+
+	if expected := (Column{"name", "character varying", true, false, false}); expected != users.Columns[1] {
+		t.Errorf("Basic IntrospectSchema test expected column %+v, received %+v", expected, users.Columns[1])
+	}
+
+	if "public" != fakeStatements[0].args[0] {
+		t.Errorf("Basic IntrospectSchema test expected the schema to be bound, received %v", fakeStatements[0].args)
+	}
+}
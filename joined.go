@@ -0,0 +1,182 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MapJoined behaves as Map for rows of a one-to-many JOIN, such as
+// `SELECT users.*, posts.id AS "posts.id", ... FROM users LEFT JOIN posts`,
+// collapsing the rows so that each distinct struct of `o`'s type, told
+// apart by its primary keys, is returned once, in order of its first row,
+// with its has_many or many2many relation field `field` filled with the
+// related struct of each of its rows. Columns of the related type must be
+// prefixed by `prefix`, such as "posts.", the remaining columns being
+// those of `o`. Rows whose related columns are all NULL, as a LEFT JOIN
+// returns for structs without related rows, add no related struct, while
+// every row of a struct skipped by a hook is skipped. Should `rows` report
+// an error once read, as *sql.Rows' Err does, it is returned. Any
+// `opts` override the Cartographer's defaults for this call alone, see
+// CallOption, hooks running against the structs of `o`'s type only.
+func (self *Cartographer) MapJoined(rows ScannableRows, o interface{}, field string, prefix string, opts ...CallOption) (results []interface{}, err error) {
+	call := newCall(opts)
+	return self.forCall(call).mapJoined(rows, o, field, prefix, call)
+}
+
+// mapJoined behaves as MapJoined, applying the overrides of `call`.
+func (self *Cartographer) mapJoined(rows ScannableRows, o interface{}, field string, prefix string, call *call) (results []interface{}, err error) {
+	defer self.hold()()
+
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	relation, err := self.RelationFor(o, field)
+
+	if nil != err {
+		return
+	}
+
+	if HasMany != relation.Kind && ManyToMany != relation.Kind {
+		return nil, errors.New(fmt.Sprintf("MapJoined expected a has_many or many2many relation for field %s, found %s", field, relation.Kind))
+	}
+
+	example := reflect.New(relation.Type).Interface()
+
+	if _, err = self.DiscoverType(example); nil != err {
+		return
+	}
+
+	keys, err := self.PrimaryKeysFor(o)
+
+	if nil != err {
+		return
+	}
+
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	columns = call.alias(columns)
+
+	var owned, related, positions []int // Positions of the columns of `o`, the related type and `o`'s keys.
+	var ownedColumns, relatedColumns []string
+
+	for index, column := range columns {
+		if strings.HasPrefix(column, prefix) {
+			related = append(related, index)
+			relatedColumns = append(relatedColumns, strings.TrimPrefix(column, prefix))
+			continue
+		}
+
+		owned = append(owned, index)
+		ownedColumns = append(ownedColumns, column)
+	}
+
+	for _, key := range keys {
+		position := -1
+
+		for index, column := range columns {
+			if key == column {
+				position = index
+			}
+		}
+
+		if -1 == position {
+			return nil, errors.New(fmt.Sprintf("Primary key column %s of %v not selected", key, typ))
+		}
+
+		positions = append(positions, position)
+	}
+
+	var (
+		ctx     = context.Background()
+		parents = make(map[string]reflect.Value) // Structs hydrated, by primary key.
+		skipped = make(map[string]bool)          // Primary keys of the structs skipped.
+	)
+
+	for row := 0; rows.Next(); row++ {
+		values, err := populatedRowValues(rows, len(columns))
+
+		if nil != err {
+			return results, err
+		}
+
+		var parts []string
+
+		for _, position := range positions {
+			part, _ := relationKey(*values[position].(*interface{}))
+			parts = append(parts, part)
+		}
+
+		key := strings.Join(parts, "\x00")
+		parent, ok := parents[key]
+
+		if skipped[key] {
+			continue
+		} else if !ok {
+			if parent, err = self.mapRow(ctx, o, call.hooks, ownedColumns, pick(values, owned), row, call); errRowSkipped == err {
+				skipped[key] = true
+				continue
+			} else if nil != err {
+				return results, err
+			}
+
+			assignRelation(parent.Elem(), relation, reflect.MakeSlice(relationTarget(parent.Elem(), relation).Type(), 0, 0))
+
+			parents[key] = parent
+			results = append(results, parent.Interface())
+		}
+
+		if allNull(pick(values, related)) {
+			continue
+		}
+
+		child, err := self.mapRow(ctx, example, nil, relatedColumns, pick(values, related), row, call)
+
+		if errRowSkipped == err {
+			continue
+		}
+
+		if nil != err {
+			return results, err
+		}
+
+		children := relationValue(parent.Elem(), relation)
+
+		assignRelation(parent.Elem(), relation, reflect.Append(children, relatedValue(child, children.Type().Elem())))
+	}
+
+	if failing, ok := rows.(interface{ Err() error }); ok {
+		err = failing.Err()
+	}
+
+	return
+}
+
+// pick returns the `values` at each of the `positions`.
+func pick(values []interface{}, positions []int) (picked []interface{}) {
+	for _, position := range positions {
+		picked = append(picked, values[position])
+	}
+
+	return
+}
+
+// allNull returns true if every one of the scanned `values` is NULL.
+func allNull(values []interface{}) bool {
+	for _, value := range values {
+		if nil != *value.(*interface{}) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+package cartographer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMapJoined(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "comments.id", "comments.thread_id", "comments.writer_id"},
+		values: [][]interface{}{
+			{1, 10, 1, 7},
+			{2, nil, nil, nil},
+			{1, 11, 1, 8},
+		},
+	}
+
+	results, err := Initialize("db").MapJoined(rows, thread{}, "Comments", "comments.")
+
+	if nil != err {
+		t.Fatalf("Basic MapJoined test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(results) {
+		t.Fatalf("Basic MapJoined test expected 2 threads, received %d", len(results))
+	}
+
+	first, second := results[0].(*thread), results[1].(*thread)
+
+	if 2 != len(first.Comments) || 11 != first.Comments[1].Id || 8 != first.Comments[1].WriterId {
+		t.Errorf("Basic MapJoined test returned unexpected comments: %v", first.Comments)
+	}
+
+	if nil == second.Comments || 0 != len(second.Comments) {
+		t.Errorf("Basic MapJoined test expected no comments, received %v", second.Comments)
+	}
+}
+
+func TestMapJoinedErrors(t *testing.T) {
+	c := Initialize("db")
+
+	if _, err := c.MapJoined(&fakeRows{columns: []string{"comments.id"}}, thread{}, "Comments", "comments."); nil == err {
+		t.Error("Basic MapJoined test expected an error without the primary key column")
+	}
+
+	if _, err := c.MapJoined(&fakeRows{}, comment{}, "Writer", "writer."); nil == err {
+		t.Error("Basic MapJoined test expected an error joining a belongs_to relation")
+	}
+}
+
+type failingRows struct {
+	*fakeRows
+	err error
+}
+
+func (self *failingRows) Err() error {
+	return self.err
+}
+
+func TestMapJoinedSkipsAndFails(t *testing.T) {
+	var (
+		c       = Initialize("db")
+		failed  []int
+		columns = []string{"id", "comments.id", "comments.thread_id", "comments.writer_id"}
+		values  = [][]interface{}{{1, 10, 1, 7}, {2, 12, 2, 7}, {1, 11, 1, 8}}
+		first   = true
+	)
+
+	c.RegisterTransform(thread{}, "id", func(value interface{}) (interface{}, error) {
+		if first {
+			first = false
+			return nil, errors.New("broken")
+		}
+
+		return value, nil
+	})
+
+	results, err := c.MapJoined(&fakeRows{columns: columns, values: values}, thread{}, "Comments", "comments.", WithRowErrorHandler(func(row Row, err error) {
+		failed = append(failed, row.Index)
+	}))
+
+	if nil != err || 1 != len(results) || 2 != results[0].(*thread).Id || 1 != len(failed) {
+		t.Errorf("Basic MapJoined test expected every row of a skipped thread to be skipped, received %v, %v, %v", results, err, failed)
+	}
+
+	expected := errors.New("connection reset")
+
+	if _, err = Initialize("db").MapJoined(&failingRows{&fakeRows{columns: columns, values: values}, expected}, thread{}, "Comments", "comments."); expected != err {
+		t.Errorf("Basic MapJoined test returned %v, expected %v", err, expected)
+	}
+}
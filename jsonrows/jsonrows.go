@@ -0,0 +1,206 @@
+// Package jsonrows adapts JSON documents to cartographer.ScannableRows, so
+// API payloads and fixture files may be mapped through the same code path
+// as database rows. A document may be a JSON array of objects or a stream
+// of newline delimited objects (NDJSON); the keys of the objects are read
+// as columns.
+//
+// Strings, booleans and nulls are scanned as string, bool and nil, numbers
+// as int64 where they are integral and float64 otherwise, and nested
+// objects and arrays as their raw JSON bytes, which cartographer decodes
+// into fields tagged with the `json` option.
+package jsonrows
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Rows is a JSON document read as cartographer.ScannableRows.
+type Rows struct {
+	decoder *json.Decoder            // Decoder of the underlying document.
+	columns []string                 // Columns, the keys of every object, in order of appearance.
+	objects []map[string]interface{} // Objects of the document, read by Columns.
+	row     int                      // Index of the current object, one past it once Next has been called.
+	read    bool                     // Has the document been read?
+	err     error                    // Error encountered while reading, if any.
+}
+
+// New returns a pointer to Rows reading a JSON array of objects, or a
+// stream of newline delimited objects, from `r`.
+func New(r io.Reader) *Rows {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	return &Rows{decoder: decoder}
+}
+
+// Columns returns the keys of the document's objects, in the order they
+// first appear. As a column must be known before any row is scanned, the
+// whole document is read on the first call; keys missing from an object
+// are scanned as nil.
+func (self *Rows) Columns() ([]string, error) {
+	if !self.read {
+		self.read = true
+		self.err = self.readAll()
+	}
+
+	return self.columns, self.err
+}
+
+// Next advances to the next object, returning false once the document
+// is exhausted or an error is encountered, see Err.
+func (self *Rows) Next() bool {
+	if _, err := self.Columns(); nil != err {
+		return false
+	}
+
+	if self.row < len(self.objects) {
+		self.row++
+		return true
+	}
+
+	return false
+}
+
+// Scan copies the values of the current object into `dest`, which must
+// hold a pointer to an interface{} for each column.
+func (self *Rows) Scan(dest ...interface{}) error {
+	if 0 == self.row {
+		return errors.New("Scan called without calling Next")
+	}
+
+	if len(dest) != len(self.columns) {
+		return errors.New(fmt.Sprintf("Expected %d destination arguments in Scan, not %d", len(self.columns), len(dest)))
+	}
+
+	object := self.objects[self.row-1]
+
+	for index, column := range self.columns {
+		pointer, ok := dest[index].(*interface{})
+
+		if !ok {
+			return errors.New(fmt.Sprintf("Unsupported Scan destination %T", dest[index]))
+		}
+
+		*pointer = object[column]
+	}
+
+	return nil
+}
+
+// Err returns the error, if any, encountered while reading the document.
+func (self *Rows) Err() error {
+	return self.err
+}
+
+// readAll reads every object of the document, recording their keys as columns.
+func (self *Rows) readAll() error {
+	token, err := self.decoder.Token()
+
+	if io.EOF == err {
+		return nil
+	} else if nil != err {
+		return err
+	}
+
+	var seen = make(map[string]bool)
+
+	if json.Delim('[') == token {
+		for self.decoder.More() {
+			if token, err = self.decoder.Token(); nil != err {
+				return err
+			}
+
+			if err = self.readObject(token, seen); nil != err {
+				return err
+			}
+		}
+
+		_, err = self.decoder.Token()
+		return err
+	}
+
+	for {
+		if err = self.readObject(token, seen); nil != err {
+			return err
+		}
+
+		if token, err = self.decoder.Token(); io.EOF == err {
+			return nil
+		} else if nil != err {
+			return err
+		}
+	}
+}
+
+// readObject reads the object opened by `token`, recording any
+// keys not yet `seen` as columns.
+func (self *Rows) readObject(token json.Token, seen map[string]bool) error {
+	if json.Delim('{') != token {
+		return errors.New(fmt.Sprintf("Expected a JSON object, received %v", token))
+	}
+
+	var object = make(map[string]interface{})
+
+	for self.decoder.More() {
+		token, err := self.decoder.Token()
+
+		if nil != err {
+			return err
+		}
+
+		key := token.(string)
+
+		var raw json.RawMessage
+
+		if err = self.decoder.Decode(&raw); nil != err {
+			return err
+		}
+
+		if object[key], err = scanned(raw); nil != err {
+			return err
+		}
+
+		if !seen[key] {
+			seen[key] = true
+			self.columns = append(self.columns, key)
+		}
+	}
+
+	if _, err := self.decoder.Token(); nil != err {
+		return err
+	}
+
+	self.objects = append(self.objects, object)
+
+	return nil
+}
+
+// scanned returns the value scanned for the `raw` JSON value.
+func scanned(raw json.RawMessage) (interface{}, error) {
+	if trimmed := bytes.TrimSpace(raw); 0 != len(trimmed) && ('{' == trimmed[0] || '[' == trimmed[0]) {
+		return []byte(trimmed), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+
+	if err := decoder.Decode(&value); nil != err {
+		return nil, err
+	}
+
+	if number, ok := value.(json.Number); ok {
+		if integer, err := number.Int64(); nil == err {
+			return integer, nil
+		}
+
+		return number.Float64()
+	}
+
+	return value, nil
+}
@@ -0,0 +1,52 @@
+package jsonrows
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+type account struct {
+	Name    string            `db:"name"`
+	Age     int               `db:"age"`
+	Balance float64           `db:"balance"`
+	Active  bool              `db:"active"`
+	Labels  map[string]string `db:"labels,json"`
+}
+
+func TestMapArray(t *testing.T) {
+	rows := New(strings.NewReader(`[
+		{"name": "ada", "age": 36, "balance": 10.5, "active": true, "labels": {"role": "admin"}},
+		{"name": "bob", "balance": 2, "extra": "ignored"}
+	]`))
+
+	results, err := cartographer.Initialize("db").Map(rows, account{})
+
+	if nil != err {
+		t.Errorf("Basic jsonrows Map test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(results) || "admin" != results[0].(*account).Labels["role"] || 2 != results[1].(*account).Balance || nil != results[1].(*account).Labels {
+		t.Errorf("Basic jsonrows Map test returned unexpected results: %v", results)
+	}
+
+	if columns, _ := rows.Columns(); 6 != len(columns) || "extra" != columns[5] {
+		t.Errorf("Basic jsonrows Columns test returned unexpected columns: %v", columns)
+	}
+}
+
+func TestMapNDJSON(t *testing.T) {
+	rows := New(strings.NewReader("{\"name\": \"ada\"}\n{\"name\": \"bob\", \"age\": 7}\n"))
+	results, err := cartographer.Initialize("db").Map(rows, account{})
+
+	if nil != err || 2 != len(results) || "bob" != results[1].(*account).Name || 7 != results[1].(*account).Age {
+		t.Errorf("Basic jsonrows NDJSON test returned an unexpected results: %v, %v", results, err)
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	if results, err := cartographer.Initialize("db").Map(New(strings.NewReader(`[1, 2]`)), account{}); nil == err {
+		t.Errorf("Basic jsonrows malformed test returned %v, expected an error mapping an array of numbers", results)
+	}
+}
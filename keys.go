@@ -0,0 +1,52 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// PrimaryKeysFor returns the columns of the fields tagged with the `pk`
+// option, in field declaration order, or an error if `o` is not a struct
+// or declares no primary key. Several fields tagged `pk` form a
+// composite primary key.
+func (self *Cartographer) PrimaryKeysFor(o interface{}) (columns []string, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	for _, mapped := range self.fields[typ] {
+		if mapped.options.Has("pk") {
+			columns = append(columns, mapped.column)
+		}
+	}
+
+	if 0 == len(columns) {
+		err = errors.New(fmt.Sprintf("No primary key for %v", typ))
+	}
+
+	return
+}
+
+// PrimaryKeyValuesFor returns a map of parameter `o`'s primary key
+// columns to their values, or an error if `o` is not a struct or
+// declares no primary key.
+func (self *Cartographer) PrimaryKeyValuesFor(o interface{}) (values map[interface{}]interface{}, err error) {
+	columns, err := self.PrimaryKeysFor(o)
+
+	if nil != err {
+		return
+	}
+
+	values = make(map[interface{}]interface{})
+
+	item := reflect.Indirect(reflect.ValueOf(o))
+
+	for _, column := range columns {
+		values[column] = fieldValue(item, self.byColumn[item.Type()][column])
+	}
+
+	return
+}
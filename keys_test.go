@@ -0,0 +1,46 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type membership struct {
+	UserId  int    `db:"user_id,pk"`
+	GroupId int    `db:"group_id,pk"`
+	Role    string `db:"role"`
+}
+
+func TestPrimaryKeysFor(t *testing.T) {
+	columns, err := instance.PrimaryKeysFor(membership{})
+
+	if nil != err || 2 != len(columns) || "user_id" != columns[0] || "group_id" != columns[1] {
+		t.Errorf("Basic PrimaryKeysFor test returned an unexpected results: %v, %v", columns, err)
+	}
+
+	if _, err = instance.PrimaryKeysFor(faker{}); nil == err {
+		t.Errorf("Basic PrimaryKeysFor test expected an error for a type without a primary key")
+	}
+}
+
+func TestPrimaryKeyValuesFor(t *testing.T) {
+	values, err := instance.PrimaryKeyValuesFor(&membership{1, 2, "owner"})
+
+	if nil != err || 2 != len(values) || 1 != values["user_id"] || 2 != values["group_id"] {
+		t.Errorf("Basic PrimaryKeyValuesFor test returned an unexpected results: %v, %v", values, err)
+	}
+}
+
+func TestSyncCompositeKey(t *testing.T) {
+	var (
+		item = membership{Role: "owner"}
+		rows = &fakeRows{columns: []string{"user_id", "group_id"}, values: [][]interface{}{{int64(3), int64(4)}}}
+	)
+
+	if err := instance.Sync(rows, &item); nil != err {
+		t.Errorf("Composite key Sync test returned an unexpected error: %v", err)
+	}
+
+	if 3 != item.UserId || 4 != item.GroupId || "owner" != item.Role {
+		t.Errorf("Composite key Sync test synced unexpected values: %v", item)
+	}
+}
@@ -0,0 +1,185 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Lazy holds the value of a relation loaded on first access rather than
+// eagerly, for associations most callers never touch. Declare a relation
+// field as Lazy, e.g. `Orders Lazy[[]Order] rel:"has_many,fk=user_id"`,
+// and set a Querier with SetLazyLoading for Map and Sync to install a
+// loader in the field of each struct hydrated. Eager loaders such as
+// LoadMany fill Lazy fields as they would plain ones. A Lazy is not safe
+// for concurrent use.
+type Lazy[T any] struct {
+	value  T                                // Value of the relation, once loaded.
+	loaded bool                             // Has the value been loaded or set?
+	loader func(context.Context) (T, error) // Loader installed by Map or Sync, if any.
+}
+
+// Load returns the value of the relation, loading it on first access, or
+// an error should it fail to load or there be no loader installed.
+func (self *Lazy[T]) Load() (T, error) {
+	return self.LoadContext(context.Background())
+}
+
+// LoadContext behaves as Load, loading the relation with context `ctx`.
+func (self *Lazy[T]) LoadContext(ctx context.Context) (value T, err error) {
+	if !self.loaded && nil != self.loader {
+		if value, err = self.loader(ctx); nil != err {
+			return
+		}
+
+		self.Set(value)
+	}
+
+	if !self.loaded {
+		err = errors.New(fmt.Sprintf("Relation %T is not loaded and has no loader", self.value))
+		return
+	}
+
+	return self.value, nil
+}
+
+// Loaded returns true once the value of the relation has been loaded or set.
+func (self *Lazy[T]) Loaded() bool {
+	return self.loaded
+}
+
+// Set sets the value of the relation, marking it loaded.
+func (self *Lazy[T]) Set(value T) {
+	self.value = value
+	self.loaded = true
+}
+
+// lazyRelation is implemented by pointers to Lazy relation fields.
+type lazyRelation interface {
+	related() reflect.Value
+	relatedType() reflect.Type
+	setRelated(value reflect.Value)
+	setLoader(loader func(context.Context) (reflect.Value, error))
+}
+
+func (self *Lazy[T]) related() reflect.Value {
+	return reflect.ValueOf(&self.value).Elem()
+}
+
+func (self *Lazy[T]) relatedType() reflect.Type {
+	return reflect.TypeOf(&self.value).Elem()
+}
+
+func (self *Lazy[T]) setRelated(value reflect.Value) {
+	self.Set(value.Interface().(T))
+}
+
+func (self *Lazy[T]) setLoader(loader func(context.Context) (reflect.Value, error)) {
+	self.loader = func(ctx context.Context) (value T, err error) {
+		loaded, err := loader(ctx)
+
+		if nil != err {
+			return
+		}
+
+		return loaded.Interface().(T), nil
+	}
+}
+
+// lazyRelationType is the reflect.Type of the lazyRelation interface.
+var lazyRelationType = reflect.TypeOf((*lazyRelation)(nil)).Elem()
+
+// lazyType returns the type held by Lazy field type `typ`, or nil
+// should `typ` not be a Lazy.
+func lazyType(typ reflect.Type) reflect.Type {
+	if !reflect.PtrTo(typ).Implements(lazyRelationType) {
+		return nil
+	}
+
+	return reflect.New(typ).Interface().(lazyRelation).relatedType()
+}
+
+// SetLazyLoading sets the Querier Map and Sync install loaders with in
+// the Lazy relation fields of each struct hydrated, or disables the
+// installation of loaders should `db` be nil.
+func (self *Cartographer) SetLazyLoading(db Querier) {
+	self.lazyQuerier = db
+}
+
+// installLoaders installs loaders in the Lazy relation fields of the
+// struct pointed to by `value`, should lazy loading be enabled. Loaders
+// load the relation into a copy of the struct, returning its value for
+// the Lazy to set on itself, so that copies of the struct load their own.
+func (self *Cartographer) installLoaders(value reflect.Value) {
+	if nil == self.lazyQuerier {
+		return
+	}
+
+	var (
+		db   = self.lazyQuerier
+		item = value.Elem()
+		typ  = item.Type()
+	)
+
+	for _, relation := range self.relations[typ] {
+		if !relation.Lazy {
+			continue
+		}
+
+		item.FieldByName(relation.Field).Addr().Interface().(lazyRelation).setLoader(func(ctx context.Context) (loaded reflect.Value, err error) {
+			var scratch = reflect.New(typ).Elem() // Copy of the struct the relation is loaded into.
+
+			scratch.Set(item)
+
+			switch relation.Kind {
+			case HasMany, ManyToMany:
+				err = self.loadMany(ctx, db, []reflect.Value{scratch}, typ, relation)
+			default:
+				err = self.loadOne(ctx, db, []reflect.Value{scratch}, typ, relation)
+			}
+
+			if nil != err {
+				return
+			}
+
+			return relationValue(scratch, relation), nil
+		})
+	}
+}
+
+// relationTarget returns a value of the type relation `relation` of
+// struct value `item` holds, unwrapping Lazy fields.
+func relationTarget(item reflect.Value, relation Relation) reflect.Value {
+	field := item.FieldByName(relation.Field)
+
+	if relation.Lazy {
+		return reflect.New(lazyType(field.Type())).Elem()
+	}
+
+	return field
+}
+
+// relationValue returns the value of relation `relation` of struct
+// value `item`, unwrapping Lazy fields.
+func relationValue(item reflect.Value, relation Relation) reflect.Value {
+	field := item.FieldByName(relation.Field)
+
+	if relation.Lazy {
+		return field.Addr().Interface().(lazyRelation).related()
+	}
+
+	return field
+}
+
+// assignRelation assigns `value` to relation `relation` of struct value `item`.
+func assignRelation(item reflect.Value, relation Relation, value reflect.Value) {
+	field := item.FieldByName(relation.Field)
+
+	if relation.Lazy {
+		field.Addr().Interface().(lazyRelation).setRelated(value)
+		return
+	}
+
+	field.Set(value)
+}
@@ -0,0 +1,116 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+type shelf struct {
+	Id    int              `db:"id,pk"`
+	Items Lazy[[]*shelved] `rel:"has_many,fk=shelf_id"`
+	Owner Lazy[*librarian] `rel:"belongs_to,fk=owner_id"`
+	Owned int              `db:"owner_id"`
+}
+
+type shelved struct {
+	Id      int `db:"id,pk"`
+	ShelfId int `db:"shelf_id"`
+}
+
+type librarian struct {
+	Id int `db:"id,pk"`
+}
+
+func TestLazyLoading(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, shelf_id FROM shelved WHERE shelf_id IN (?)": {
+			columns: []string{"id", "shelf_id"},
+			rows:    [][]driver.Value{{int64(5), int64(1)}, {int64(6), int64(1)}},
+		},
+		"SELECT id FROM librarian WHERE id IN (?)": {columns: []string{"id"}, rows: [][]driver.Value{{int64(3)}}},
+	})
+
+	c := Initialize("db")
+	c.SetLazyLoading(db)
+
+	results, err := c.Map(&fakeRows{columns: []string{"id", "owner_id"}, values: [][]interface{}{{1, 3}}}, shelf{})
+
+	if nil != err {
+		t.Fatalf("Basic Lazy test returned an unexpected error: %v", err)
+	}
+
+	item := results[0].(*shelf)
+
+	if item.Items.Loaded() || 0 != len(fakeStatements) {
+		t.Fatal("Basic Lazy test expected relation to be loaded lazily")
+	}
+
+	items, err := item.Items.Load()
+
+	if nil != err {
+		t.Fatalf("Basic Lazy test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(items) || 6 != items[1].Id || !item.Items.Loaded() {
+		t.Errorf("Basic Lazy test returned unexpected items: %v", items)
+	}
+
+	if _, err = item.Items.Load(); nil != err || 1 != len(fakeStatements) {
+		t.Errorf("Basic Lazy test expected a loaded relation not to be reloaded, received %v", err)
+	}
+
+	if owner, err := item.Owner.Load(); nil != err || 3 != owner.Id {
+		t.Errorf("Basic Lazy test returned unexpected owner: %v, %v", owner, err)
+	}
+}
+
+func TestLazyLoadingCopies(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, shelf_id FROM shelved WHERE shelf_id IN (?)": {
+			columns: []string{"id", "shelf_id"},
+			rows:    [][]driver.Value{{int64(5), int64(1)}},
+		},
+	})
+
+	c := Initialize("db")
+	c.SetLazyLoading(db)
+
+	results, err := c.Map(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{1}}}, shelf{})
+
+	if nil != err {
+		t.Fatalf("Basic Lazy test returned an unexpected error: %v", err)
+	}
+
+	var (
+		original = results[0].(*shelf)
+		copied   = *original
+	)
+
+	if items, err := copied.Items.Load(); nil != err || 1 != len(items) || !copied.Items.Loaded() {
+		t.Errorf("Basic Lazy test expected a copy to load its own relation, received %v, %v", items, err)
+	}
+
+	if original.Items.Loaded() {
+		t.Error("Basic Lazy test expected loading a copy to leave the original unloaded")
+	}
+}
+
+func TestLazyEager(t *testing.T) {
+	db := openFake(map[string]fakeResult{})
+
+	shelves := []shelf{{Id: 1}}
+
+	if err := Initialize("db").LoadMany(db, shelves, "Items"); nil != err {
+		t.Fatalf("Basic Lazy test returned an unexpected error: %v", err)
+	}
+
+	if items, err := shelves[0].Items.Load(); nil != err || nil == items || 0 != len(items) {
+		t.Errorf("Basic Lazy test expected an empty loaded relation, received %v, %v", items, err)
+	}
+
+	var unloaded Lazy[[]*shelved]
+
+	if _, err := unloaded.Load(); nil == err {
+		t.Error("Basic Lazy test expected an error loading a relation without a loader")
+	}
+}
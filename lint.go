@@ -0,0 +1,171 @@
+package cartographer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// MappingError describes a problem with the mapping of a struct type
+// found by ValidateMappings.
+type MappingError struct {
+	Type    reflect.Type // Struct type at fault.
+	Field   string       // Field at fault, empty for problems of the type as a whole.
+	Problem string       // Description of the problem.
+}
+
+// Error describes the problem.
+func (self MappingError) Error() string {
+	if 0 == len(self.Field) {
+		return fmt.Sprintf("%v: %s", self.Type, self.Problem)
+	}
+
+	return fmt.Sprintf("%v.%s: %s", self.Type, self.Field, self.Problem)
+}
+
+// MappingErrors is the list of problems returned by ValidateMappings.
+type MappingErrors []MappingError
+
+// Error joins the description of each problem.
+func (self MappingErrors) Error() string {
+	var messages = make([]string, 0, len(self))
+
+	for _, problem := range self {
+		messages = append(messages, problem.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// ValidateMappings checks the mappings of each of the `types`, returning
+// MappingErrors listing every problem found, so that mistakes surface when
+// called from TestMain or main() rather than when a row is first scanned.
+// Problems reported are types that can not be mapped, such as non-structs
+// or those declaring invalid relations or rules, columns mapped by several
+// fields at the same depth, of which all but the first are silently hidden,
+// tagged fields that are unexported and so can not be set, fields of types
+// Map can not set without a Transform, types declaring no `pk` field, and
+// conflicting options: `nullable` alongside `notnull` or `pk`, `autocreate`
+// or `autoupdate` on fields other than time.Time, `version` on fields other
+// than integers or on several fields, and sanitizers on non-string fields.
+func (self *Cartographer) ValidateMappings(types ...interface{}) error {
+	var problems MappingErrors
+
+	for _, o := range types {
+		typ, err := self.DiscoverType(o)
+
+		if nil != err {
+			problems = append(problems, MappingError{indirectType(reflect.TypeOf(o)), "", err.Error()})
+			continue
+		}
+
+		problems = append(problems, self.lint(typ)...)
+	}
+
+	if 0 != len(problems) {
+		return problems
+	}
+
+	return nil
+}
+
+// lint returns the problems of struct type `typ`'s mapping.
+func (self *Cartographer) lint(typ reflect.Type) (problems MappingErrors) {
+	var (
+		report = func(mapped *mappedField, format string, args ...interface{}) {
+			problems = append(problems, MappingError{typ, mapped.name, fmt.Sprintf(format, args...)})
+		}
+		columns  = make(map[string]*mappedField) // First field mapping each column at each depth, keyed by depth and column.
+		versions int
+		keyed    bool
+	)
+
+	for _, mapped := range self.collectFields(typ, nil, map[reflect.Type]bool{typ: true}) {
+		var key = fmt.Sprintf("%d/%s", len(mapped.index), mapped.column)
+
+		if first, ok := columns[key]; ok {
+			report(mapped, "column %s is already mapped by field %s", mapped.column, first.name)
+		} else {
+			columns[key] = mapped
+		}
+	}
+
+	for _, mapped := range self.fields[typ] {
+		if !typ.FieldByIndex(mapped.index).IsExported() {
+			report(mapped, "field is tagged but unexported, so can not be set")
+		}
+
+		if !self.settable(typ, mapped) {
+			report(mapped, "type %v can not be set from a column without a Transform", mapped.typ)
+		}
+
+		keyed = keyed || mapped.options.Has("pk")
+
+		if mapped.options.Has("nullable") && mapped.options.Has("notnull") {
+			report(mapped, "options nullable and notnull conflict")
+		}
+
+		if mapped.options.Has("nullable") && mapped.options.Has("pk") {
+			report(mapped, "primary key can not be nullable")
+		}
+
+		if (mapped.options.Has("autocreate") || mapped.options.Has("autoupdate")) && reflect.TypeOf(time.Time{}) != mapped.typ {
+			report(mapped, "autocreate and autoupdate require a time.Time field, found %v", mapped.typ)
+		}
+
+		if mapped.options.Has("version") {
+			if versions++; 1 == versions && !isInteger(mapped.typ) {
+				report(mapped, "version requires an integer field, found %v", mapped.typ)
+			} else if 1 < versions {
+				report(mapped, "only a single field may have the version option")
+			}
+		}
+
+		for _, option := range mapped.options {
+			if _, ok := sanitizers[option.Name]; ok && reflect.String != indirectType(mapped.typ).Kind() {
+				report(mapped, "sanitizer %s requires a string field, found %v", option.Name, mapped.typ)
+			}
+		}
+	}
+
+	if !keyed {
+		problems = append(problems, MappingError{typ, "", "no field has the pk option"})
+	}
+
+	if err := self.checkRules(typ); nil != err {
+		problems = append(problems, MappingError{typ, "", err.Error()})
+	}
+
+	return
+}
+
+// settable reports whether Map can set field `mapped` of type `typ` from
+// a scanned value, as it can fields of builtin scalar kinds, time.Time,
+// pointers to these, fields decoded from JSON and fields whose column has
+// a Transform registered, which may produce a value of the field's type.
+func (self *Cartographer) settable(typ reflect.Type, mapped *mappedField) bool {
+	if self.isJSON(mapped) || 0 != len(self.transforms[typ][mapped.column]) {
+		return true
+	}
+
+	switch field := indirectType(mapped.typ); field.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Struct:
+		return reflect.TypeOf(time.Time{}) == field
+	default:
+		return isInteger(field)
+	}
+}
+
+// isInteger reports whether `typ` is of a signed or unsigned integer kind.
+func isInteger(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
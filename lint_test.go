@@ -0,0 +1,74 @@
+package cartographer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ledgerBase struct {
+	Code string `db:"code"`
+}
+
+type ledger struct {
+	ledgerBase
+	Id       int               `db:"id,pk,nullable"`
+	Code     string            `db:"code"`
+	Alias    string            `db:"code"`
+	balance  int               `db:"balance"`
+	Tags     []string          `db:"tags"`
+	Meta     map[string]string `db:"meta,json"`
+	Opened   string            `db:"opened,autocreate"`
+	Closed   time.Time         `db:"closed,autoupdate"`
+	Revision string            `db:"revision,version"`
+	Lock     int               `db:"lock,version"`
+	Count    int               `db:"count,trim"`
+	Note     *string           `db:"note,nullable,notnull,lower"`
+}
+
+func TestValidateMappings(t *testing.T) {
+	mapper := Initialize("db")
+
+	if err := mapper.ValidateMappings(warehouse{}, &warehouse{}); nil != err {
+		t.Errorf("Basic ValidateMappings test returned unexpected error: %v", err)
+	}
+
+	err := mapper.ValidateMappings(ledger{}, reviewer{}, 1)
+
+	var problems MappingErrors
+
+	if !errors.As(err, &problems) {
+		t.Fatalf("Basic ValidateMappings test expected MappingErrors, received %v", err)
+	}
+
+	var expected = []string{
+		"cartographer.ledger.Alias: column code is already mapped by field Code",
+		"cartographer.ledger.Id: primary key can not be nullable",
+		"cartographer.ledger.balance: field is tagged but unexported, so can not be set",
+		"cartographer.ledger.Tags: type []string can not be set from a column without a Transform",
+		"cartographer.ledger.Opened: autocreate and autoupdate require a time.Time field, found string",
+		"cartographer.ledger.Revision: version requires an integer field, found string",
+		"cartographer.ledger.Lock: only a single field may have the version option",
+		"cartographer.ledger.Count: sanitizer trim requires a string field, found int",
+		"cartographer.ledger.Note: options nullable and notnull conflict",
+		"cartographer.reviewer: no field has the pk option",
+		"int: Expected a struct",
+	}
+
+	if len(expected) != len(problems) {
+		t.Errorf("Basic ValidateMappings test expected %d problems, received %d: %v", len(expected), len(problems), problems)
+	}
+
+	for _, problem := range expected {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("Basic ValidateMappings test expected problem %q, received %v", problem, err)
+		}
+	}
+
+	mapper.RegisterTransform(ledger{}, "tags", func(value interface{}) (interface{}, error) { return value, nil })
+
+	if err = mapper.ValidateMappings(ledger{}); strings.Contains(err.Error(), "Tags") {
+		t.Errorf("Basic ValidateMappings test expected a Transform to make the field settable, received %v", err)
+	}
+}
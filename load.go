@@ -0,0 +1,391 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LoadMany eagerly loads the has_many or many2many relation declared on
+// field `field` of each of the `parents`, a slice of structs or of pointers
+// to structs such as returned by Map. The keys the relation references are
+// collected from the parents and the related rows selected by a single
+// query, WHERE fk IN (...), then assigned into the field of the parent each
+// belongs to. Many-to-many relations first select the pairs of keys held by
+// the join table, then the related rows. Parents without related rows are
+// assigned an empty slice.
+func (self *Cartographer) LoadMany(db Querier, parents interface{}, field string) error {
+	return self.LoadManyContext(context.Background(), db, parents, field)
+}
+
+// LoadManyContext behaves as LoadMany, selecting the related rows with
+// context `ctx`.
+func (self *Cartographer) LoadManyContext(ctx context.Context, db Querier, parents interface{}, field string) (err error) {
+	defer self.hold()()
+
+	items, typ, err := self.loadable(parents)
+
+	if nil != err || 0 == len(items) {
+		return
+	}
+
+	relation, err := self.RelationFor(reflect.New(typ).Interface(), field)
+
+	if nil != err {
+		return
+	}
+
+	return self.loadMany(ctx, db, items, typ, relation)
+}
+
+// loadMany loads has_many or many2many relation `relation` of the
+// `items` of type `typ` with context `ctx`, see LoadMany.
+func (self *Cartographer) loadMany(ctx context.Context, db Querier, items []reflect.Value, typ reflect.Type, relation Relation) (err error) {
+	var (
+		local   string                     // Column of the parents related rows are grouped by.
+		related map[string][]reflect.Value // Related rows, grouped by the parent key they belong to.
+	)
+
+	switch relation.Kind {
+	case HasMany:
+		local = relation.References
+
+		var keys []interface{}
+
+		if keys, err = self.relationKeys(items, local); nil != err {
+			return
+		}
+
+		related, err = self.loadRelated(ctx, db, relation.Type, relation.ForeignKey, keys)
+	case ManyToMany:
+		local = self.keyColumn(typ)
+		related, err = self.loadJoined(ctx, db, items, local, relation)
+	default:
+		return errors.New(fmt.Sprintf("LoadMany expected a has_many or many2many relation for field %s, found %s", relation.Field, relation.Kind))
+	}
+
+	if nil != err {
+		return
+	}
+
+	for _, item := range items {
+		target := relationTarget(item, relation)
+		children := reflect.MakeSlice(target.Type(), 0, 0)
+
+		if key, ok := relationKey(fieldValue(item, self.byColumn[typ][local])); ok {
+			for _, child := range related[key] {
+				children = reflect.Append(children, relatedValue(child, target.Type().Elem()))
+			}
+		}
+
+		assignRelation(item, relation, children)
+	}
+
+	return
+}
+
+// loadJoined selects the rows related to the `items` through the join
+// table of many2many relation `relation` with context `ctx`, grouped by the
+// value of the items' column `local` they are joined to.
+func (self *Cartographer) loadJoined(ctx context.Context, db Querier, items []reflect.Value, local string, relation Relation) (related map[string][]reflect.Value, err error) {
+	keys, err := self.relationKeys(items, local)
+
+	if nil != err || 0 == len(keys) {
+		return
+	}
+
+	var placeholders []string
+
+	for index, _ := range keys {
+		placeholders = append(placeholders, self.bind(index+1))
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s IN (%s)", relation.ForeignKey, relation.References,
+		relation.JoinTable, relation.ForeignKey, strings.Join(placeholders, ", "))
+
+	rows, err := db.QueryContext(ctx, query, keys...)
+
+	if nil != err {
+		return
+	}
+
+	defer rows.Close()
+
+	var (
+		pairs   [][2]interface{}
+		targets []interface{}
+		seen    = make(map[string]bool)
+	)
+
+	for rows.Next() {
+		var pair [2]interface{}
+
+		if err = rows.Scan(&pair[0], &pair[1]); nil != err {
+			return
+		}
+
+		pairs = append(pairs, pair)
+
+		if key, ok := relationKey(pair[1]); ok && !seen[key] {
+			seen[key] = true
+			targets = append(targets, pair[1])
+		}
+	}
+
+	if err = rows.Err(); nil != err {
+		return
+	}
+
+	if _, err = self.DiscoverType(reflect.New(relation.Type).Interface()); nil != err {
+		return
+	}
+
+	joined, err := self.loadRelated(ctx, db, relation.Type, self.keyColumn(relation.Type), targets)
+
+	if nil != err {
+		return
+	}
+
+	related = make(map[string][]reflect.Value)
+
+	for _, pair := range pairs {
+		owner, ok := relationKey(pair[0])
+		target, joins := relationKey(pair[1])
+
+		if ok && joins {
+			related[owner] = append(related[owner], joined[target]...)
+		}
+	}
+
+	return
+}
+
+// keyColumn returns the column rows of type `typ` are identified by
+// in relations, its sole primary key, else `id`.
+func (self *Cartographer) keyColumn(typ reflect.Type) string {
+	var keys []string
+
+	for _, mapped := range self.fields[typ] {
+		if mapped.options.Has("pk") {
+			keys = append(keys, mapped.column)
+		}
+	}
+
+	if 1 == len(keys) {
+		return keys[0]
+	}
+
+	return "id"
+}
+
+// LoadOne eagerly loads the belongs_to or has_one relation declared on
+// field `field` of each of the `items`, a slice of structs or of pointers
+// to structs. The distinct keys are collected from the items and the
+// related rows selected by a single query, then assigned into the field of
+// each item they relate to, a struct or a pointer to one. Items whose
+// foreign key is NULL, or which relate to no row, are assigned the field's
+// zero value, leaving pointer fields nil.
+func (self *Cartographer) LoadOne(db Querier, items interface{}, field string) error {
+	return self.LoadOneContext(context.Background(), db, items, field)
+}
+
+// LoadOneContext behaves as LoadOne, selecting the related rows with
+// context `ctx`.
+func (self *Cartographer) LoadOneContext(ctx context.Context, db Querier, items interface{}, field string) (err error) {
+	defer self.hold()()
+
+	values, typ, err := self.loadable(items)
+
+	if nil != err || 0 == len(values) {
+		return
+	}
+
+	relation, err := self.RelationFor(reflect.New(typ).Interface(), field)
+
+	if nil != err {
+		return
+	}
+
+	return self.loadOne(ctx, db, values, typ, relation)
+}
+
+// loadOne loads belongs_to or has_one relation `relation` of the
+// `items` of type `typ` with context `ctx`, see LoadOne.
+func (self *Cartographer) loadOne(ctx context.Context, db Querier, items []reflect.Value, typ reflect.Type, relation Relation) (err error) {
+	var local, remote string // Columns of the items and related rows matched.
+
+	switch relation.Kind {
+	case BelongsTo:
+		local, remote = relation.ForeignKey, relation.References
+	case HasOne:
+		local, remote = relation.References, relation.ForeignKey
+	default:
+		return errors.New(fmt.Sprintf("LoadOne expected a belongs_to or has_one relation for field %s, found %s", relation.Field, relation.Kind))
+	}
+
+	keys, err := self.relationKeys(items, local)
+
+	if nil != err {
+		return
+	}
+
+	related, err := self.loadRelated(ctx, db, relation.Type, remote, keys)
+
+	if nil != err {
+		return
+	}
+
+	for _, item := range items {
+		target := relationTarget(item, relation)
+		value := reflect.Zero(target.Type())
+
+		if key, ok := relationKey(fieldValue(item, self.byColumn[typ][local])); ok && 0 != len(related[key]) {
+			value = relatedValue(related[key][0], target.Type())
+		}
+
+		assignRelation(item, relation, value)
+	}
+
+	return
+}
+
+// loadable returns the addressable struct values of `items`, a slice of
+// structs or of pointers to structs, along with their struct type, or an
+// error should they differ in type.
+func (self *Cartographer) loadable(items interface{}) (values []reflect.Value, typ reflect.Type, err error) {
+	slice := reflect.ValueOf(items)
+
+	if reflect.Slice != slice.Kind() {
+		err = errors.New(fmt.Sprintf("Expected a slice of structs to be passed, received %T", items))
+		return
+	}
+
+	for index := 0; index < slice.Len(); index++ {
+		item := slice.Index(index)
+
+		for reflect.Interface == item.Kind() || reflect.Ptr == item.Kind() {
+			item = item.Elem()
+		}
+
+		if reflect.Struct != item.Kind() || !item.CanAddr() {
+			err = errors.New(fmt.Sprintf("Expected a struct or pointer to a struct at index %d, received %v", index, slice.Index(index).Type()))
+			return
+		} else if nil == typ {
+			typ = item.Type()
+		} else if typ != item.Type() {
+			err = errors.New(fmt.Sprintf("Expected %v at index %d, received %v", typ, index, item.Type()))
+			return
+		}
+
+		values = append(values, item)
+	}
+
+	if nil != typ {
+		_, err = self.DiscoverType(reflect.New(typ).Interface())
+	}
+
+	return
+}
+
+// relationKeys returns the distinct non-NULL values of column `column`
+// of each of the `items`.
+func (self *Cartographer) relationKeys(items []reflect.Value, column string) (keys []interface{}, err error) {
+	typ := items[0].Type()
+	mapped := self.byColumn[typ][column]
+
+	if nil == mapped {
+		return nil, errors.New(fmt.Sprintf("No field for column %s on %v", column, typ))
+	}
+
+	var seen = make(map[string]bool)
+
+	for _, item := range items {
+		value := fieldValue(item, mapped)
+
+		if key, ok := relationKey(value); ok && !seen[key] {
+			seen[key] = true
+			keys = append(keys, reflect.Indirect(reflect.ValueOf(value)).Interface())
+		}
+	}
+
+	return
+}
+
+// loadRelated selects the rows of type `typ` whose column `column` holds
+// any of the `keys` with context `ctx`, returning pointers to them grouped
+// by their column's value, see relationKey.
+func (self *Cartographer) loadRelated(ctx context.Context, db Querier, typ reflect.Type, column string, keys []interface{}) (related map[string][]reflect.Value, err error) {
+	related = make(map[string][]reflect.Value)
+
+	if 0 == len(keys) {
+		return
+	}
+
+	example := reflect.New(typ).Interface()
+
+	query, err := self.SelectSQL(example)
+
+	if nil != err {
+		return
+	}
+
+	where, args, err := self.WhereSQL(example, Where(map[string]interface{}{column: keys}), nil)
+
+	if nil != err {
+		return
+	}
+
+	results, err := self.QueryContext(ctx, db, query+" WHERE "+where, args, example)
+
+	if nil != err {
+		return
+	}
+
+	mapped := self.byColumn[typ][column]
+
+	for _, result := range results {
+		value := reflect.ValueOf(result)
+
+		if key, ok := relationKey(fieldValue(value.Elem(), mapped)); ok {
+			related[key] = append(related[key], value)
+		}
+	}
+
+	return
+}
+
+// relationKey returns the key `value` is grouped by when matching related
+// rows, dereferencing pointers, or false should `value` be NULL.
+func relationKey(value interface{}) (string, bool) {
+	item := reflect.ValueOf(value)
+
+	for reflect.Ptr == item.Kind() {
+		if item.IsNil() {
+			return "", false
+		}
+
+		item = item.Elem()
+	}
+
+	if !item.IsValid() {
+		return "", false
+	}
+
+	if bytes, ok := item.Interface().([]byte); ok {
+		return string(bytes), true // Drivers may scan keys as bytes.
+	}
+
+	return fmt.Sprint(item.Interface()), true
+}
+
+// relatedValue returns `pointer`, a pointer to a related struct, as a
+// value of type `typ`, either the pointer itself or the struct.
+func relatedValue(pointer reflect.Value, typ reflect.Type) reflect.Value {
+	if reflect.Ptr == typ.Kind() {
+		return pointer
+	}
+
+	return pointer.Elem()
+}
@@ -0,0 +1,161 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestLoadMany(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, author_id FROM book WHERE author_id IN (?, ?)": {
+			columns: []string{"id", "author_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1)}, {int64(11), int64(1)}, {int64(12), int64(2)}},
+		},
+	})
+
+	authors := []*author{{Id: 1}, {Id: 2}, {Id: 1}}
+
+	if err := Initialize("db").LoadMany(db, authors, "Books"); nil != err {
+		t.Fatalf("Basic LoadMany test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(authors[0].Books) || 11 != authors[0].Books[1].Id || 1 != len(authors[1].Books) || 2 != len(authors[2].Books) {
+		t.Errorf("Basic LoadMany test returned unexpected books: %v, %v, %v", authors[0].Books, authors[1].Books, authors[2].Books)
+	}
+
+	if args := fakeStatements[0].args; 2 != len(args) {
+		t.Errorf("Basic LoadMany test expected distinct keys, received %v", args)
+	}
+}
+
+func TestLoadManyMapped(t *testing.T) {
+	db := openFake(map[string]fakeResult{})
+
+	authors := []interface{}{&author{Id: 3}}
+
+	if err := Initialize("db").LoadMany(db, authors, "Books"); nil != err {
+		t.Fatalf("Basic LoadMany test returned an unexpected error: %v", err)
+	}
+
+	if books := authors[0].(*author).Books; nil == books || 0 != len(books) {
+		t.Errorf("Basic LoadMany test expected an empty slice, received %v", books)
+	}
+
+	if err := Initialize("db").LoadMany(db, []book{{}}, "Author"); nil == err {
+		t.Error("Basic LoadMany test expected an error loading a belongs_to relation")
+	}
+
+	if err := Initialize("db").LoadMany(db, []author{}, "Books"); nil != err {
+		t.Errorf("Basic LoadMany test expected no error loading no parents, received %v", err)
+	}
+}
+
+type passport struct {
+	Id       int    `db:"id"`
+	PersonId int    `db:"person_id"`
+	Number   string `db:"number"`
+}
+
+type citizen struct {
+	Id       int       `db:"id"`
+	Passport *passport `rel:"has_one,fk=person_id"`
+}
+
+type reviewer struct {
+	Id int `db:"id"`
+}
+
+type review struct {
+	Id         int       `db:"id"`
+	ReviewerId *int      `db:"reviewer_id"`
+	Reviewer   *reviewer `rel:"belongs_to,fk=reviewer_id"`
+}
+
+func TestLoadOneBelongsTo(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id FROM reviewer WHERE id IN (?)": {columns: []string{"id"}, rows: [][]driver.Value{{int64(4)}}},
+	})
+
+	four := 4
+	reviews := []review{{Id: 1, ReviewerId: &four}, {Id: 2}, {Id: 3, ReviewerId: &four, Reviewer: &reviewer{Id: 9}}}
+
+	if err := Initialize("db").LoadOne(db, reviews, "Reviewer"); nil != err {
+		t.Fatalf("Basic LoadOne test returned an unexpected error: %v", err)
+	}
+
+	if nil == reviews[0].Reviewer || 4 != reviews[0].Reviewer.Id || nil != reviews[1].Reviewer || 4 != reviews[2].Reviewer.Id {
+		t.Errorf("Basic LoadOne test returned unexpected reviewers: %v, %v, %v", reviews[0].Reviewer, reviews[1].Reviewer, reviews[2].Reviewer)
+	}
+}
+
+func TestLoadOneHasOne(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, person_id, number FROM passport WHERE person_id IN (?, ?)": {
+			columns: []string{"id", "person_id", "number"},
+			rows:    [][]driver.Value{{int64(1), int64(7), "X1"}},
+		},
+	})
+
+	citizens := []*citizen{{Id: 7}, {Id: 8}}
+
+	if err := Initialize("db").LoadOne(db, citizens, "Passport"); nil != err {
+		t.Fatalf("Basic LoadOne test returned an unexpected error: %v", err)
+	}
+
+	if nil == citizens[0].Passport || "X1" != citizens[0].Passport.Number || nil != citizens[1].Passport {
+		t.Errorf("Basic LoadOne test returned unexpected passports: %v, %v", citizens[0].Passport, citizens[1].Passport)
+	}
+
+	if err := Initialize("db").LoadOne(db, []author{{}}, "Books"); nil == err {
+		t.Error("Basic LoadOne test expected an error loading a has_many relation")
+	}
+}
+
+type member struct {
+	Id     int     `db:"id,pk"`
+	Groups []group `rel:"many2many,join=memberships,fk=member_id,ref=group_id"`
+}
+
+type group struct {
+	Id      int       `db:"id,pk"`
+	Name    string    `db:"name"`
+	Members []*member `rel:"many2many,join=memberships,fk=group_id,ref=member_id"`
+}
+
+func TestLoadManyToMany(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT member_id, group_id FROM memberships WHERE member_id IN (?, ?, ?)": {
+			columns: []string{"member_id", "group_id"},
+			rows:    [][]driver.Value{{int64(1), int64(10)}, {int64(1), int64(11)}, {int64(2), []byte("10")}},
+		},
+		"SELECT id, name FROM group WHERE id IN (?, ?)": {
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(10), "admins"}, {int64(11), "editors"}},
+		},
+		"SELECT group_id, member_id FROM memberships WHERE group_id IN (?)": {
+			columns: []string{"group_id", "member_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1)}},
+		},
+		"SELECT id FROM member WHERE id IN (?)": {columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+	})
+
+	members := []member{{Id: 1}, {Id: 2}, {Id: 3}}
+
+	if err := Initialize("db").LoadMany(db, members, "Groups"); nil != err {
+		t.Fatalf("Basic LoadMany test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(members[0].Groups) || "editors" != members[0].Groups[1].Name || 1 != len(members[1].Groups) || 0 != len(members[2].Groups) {
+		t.Errorf("Basic LoadMany test returned unexpected groups: %v, %v, %v", members[0].Groups, members[1].Groups, members[2].Groups)
+	}
+
+	groups := []*group{{Id: 10}}
+
+	if err := Initialize("db").LoadMany(db, groups, "Members"); nil != err {
+		t.Fatalf("Basic LoadMany test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(groups[0].Members) || 1 != groups[0].Members[0].Id {
+		t.Errorf("Basic LoadMany test returned unexpected members: %v", groups[0].Members)
+	}
+}
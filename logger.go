@@ -0,0 +1,55 @@
+package cartographer
+
+import (
+	"context"
+)
+
+// Logger receives the diagnostics of a Cartographer, a message followed
+// by alternating keys and values, as the Debug and Warn methods of a
+// *slog.Logger accept them, so that one may be set as is.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+}
+
+// ContextLogger is a Logger receiving the context of the call to MapContext
+// or SyncContext logging, so that handlers may attach the request or tenant
+// IDs it carries, as the DebugContext and WarnContext methods of a
+// *slog.Logger do. Diagnostics of the type cache, which has no context, are
+// passed context.Background().
+type ContextLogger interface {
+	Logger
+	DebugContext(ctx context.Context, msg string, keyvals ...interface{})
+	WarnContext(ctx context.Context, msg string, keyvals ...interface{})
+}
+
+// SetLogger sets the Logger diagnostics are written to, or disables them
+// should `logger` be nil, as by default. Types discovered and evicted, rows
+// mapped and synced, and columns skipped for want of a field, once per call,
+// are logged at debug level, while NULLs scanned into fields unable to hold
+// them, which are left untouched, values failing conversion and several
+// rows read by Sync, where allowed, are logged as warnings. Should `logger`
+// be a ContextLogger, it is passed the context of each call.
+func (self *Cartographer) SetLogger(logger Logger) {
+	self.logger = logger
+}
+
+// debug writes `msg` and `keyvals` to the Logger at debug level, if one is
+// set, with context `ctx` should it be a ContextLogger.
+func (self *Cartographer) debug(ctx context.Context, msg string, keyvals ...interface{}) {
+	if logger, ok := self.logger.(ContextLogger); ok {
+		logger.DebugContext(ctx, msg, keyvals...)
+	} else if nil != self.logger {
+		self.logger.Debug(msg, keyvals...)
+	}
+}
+
+// warn writes `msg` and `keyvals` to the Logger as a warning, if one is
+// set, with context `ctx` should it be a ContextLogger.
+func (self *Cartographer) warn(ctx context.Context, msg string, keyvals ...interface{}) {
+	if logger, ok := self.logger.(ContextLogger); ok {
+		logger.WarnContext(ctx, msg, keyvals...)
+	} else if nil != self.logger {
+		self.logger.Warn(msg, keyvals...)
+	}
+}
@@ -0,0 +1,112 @@
+package cartographer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// recordingLogger records each message logged, prefixed by its level.
+type recordingLogger struct {
+	messages []string
+}
+
+func (self *recordingLogger) Debug(msg string, keyvals ...interface{}) {
+	self.messages = append(self.messages, fmt.Sprintf("debug %s %v", msg, keyvals))
+}
+
+func (self *recordingLogger) Warn(msg string, keyvals ...interface{}) {
+	self.messages = append(self.messages, fmt.Sprintf("warn %s %v", msg, keyvals))
+}
+
+func TestLogger(t *testing.T) {
+	var (
+		logger = new(recordingLogger)
+		c      = New(WithLogger(logger))
+		rows   = &fakeRows{
+			columns: []string{"id", "weight", "carrier", "extra"},
+			values:  [][]interface{}{{int64(1), nil, nil, 1}, {int64(2), "heavy", "post", 2}},
+		}
+	)
+
+	if _, err := c.Map(rows, consignment{}); nil == err {
+		t.Fatal("Basic Logger test expected a conversion error")
+	}
+
+	var expected = []string{
+		"debug cartographer: type discovered [type cartographer.consignment columns 4]",
+		"warn cartographer: NULL scanned into non-nullable field, left unchanged [type cartographer.consignment field Weight column weight row 0]",
+		"debug cartographer: column skipped [type cartographer.consignment column extra]",
+		"warn cartographer: conversion failed [type cartographer.consignment field Weight column weight row 1 error",
+	}
+
+	if len(expected) != len(logger.messages) {
+		t.Fatalf("Basic Logger test expected messages:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(logger.messages, "\n"))
+	}
+
+	for index, message := range expected {
+		if !strings.HasPrefix(logger.messages[index], message) {
+			t.Errorf("Basic Logger test expected message %s, received %s", message, logger.messages[index])
+		}
+	}
+
+	logger.messages = nil
+
+	var item consignment
+
+	if err := c.Sync(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{int64(3)}}}, &item); nil != err || 3 != item.ID {
+		t.Fatalf("Basic Logger test returned unexpected item: %+v, %v", item, err)
+	}
+
+	if 1 != len(logger.messages) || "debug cartographer: struct synced [type cartographer.consignment]" != logger.messages[0] {
+		t.Errorf("Basic Logger test returned unexpected messages: %v", logger.messages)
+	}
+
+	c.SetLogger(nil)
+
+	if _, err := c.Map(&fakeRows{columns: []string{"extra"}, values: [][]interface{}{{1}}}, consignment{}); nil != err {
+		t.Errorf("Basic Logger test returned an unexpected error: %v", err)
+	}
+}
+
+// requestKey keys the request ID carried by contexts in tests.
+type requestKey struct{}
+
+// contextLogger records each message logged along with the request ID
+// carried by its context.
+type contextLogger struct {
+	recordingLogger
+}
+
+func (self *contextLogger) DebugContext(ctx context.Context, msg string, keyvals ...interface{}) {
+	self.messages = append(self.messages, fmt.Sprintf("debug %v %s", ctx.Value(requestKey{}), msg))
+}
+
+func (self *contextLogger) WarnContext(ctx context.Context, msg string, keyvals ...interface{}) {
+	self.messages = append(self.messages, fmt.Sprintf("warn %v %s", ctx.Value(requestKey{}), msg))
+}
+
+func TestContextLogger(t *testing.T) {
+	var (
+		_      ContextLogger = slog.New(slog.DiscardHandler)
+		logger               = new(contextLogger)
+		c                    = New(WithLogger(logger))
+		ctx                  = context.WithValue(context.Background(), requestKey{}, "request-1")
+	)
+
+	if _, err := c.MapContext(ctx, &fakeRows{columns: []string{"id", "weight"}, values: [][]interface{}{{int64(1), nil}}}, consignment{}); nil != err {
+		t.Fatalf("Basic ContextLogger test returned an unexpected error: %v", err)
+	}
+
+	var expected = []string{
+		"debug <nil> cartographer: type discovered",
+		"warn request-1 cartographer: NULL scanned into non-nullable field, left unchanged",
+		"debug request-1 cartographer: rows mapped",
+	}
+
+	if strings.Join(expected, "\n") != strings.Join(logger.messages, "\n") {
+		t.Errorf("Basic ContextLogger test expected messages:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(logger.messages, "\n"))
+	}
+}
@@ -0,0 +1,122 @@
+package cartographer
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// TypeMapping is the cached mapping of a struct type to its database
+// columns, intended for libraries building on top of cartographer.
+type TypeMapping struct {
+	Type      reflect.Type   // The struct type mapped.
+	Fields    []FieldMapping // Mapped fields, in declaration order.
+	Relations []Relation     // Relations declared through `rel` tags.
+}
+
+// FieldMapping describes a single mapped struct field.
+type FieldMapping struct {
+	Name    string       // Name of the struct field.
+	Column  string       // Database column the field is mapped to.
+	Options TagOptions   // Options following the column name in the field's tag.
+	Index   []int        // Index path of the field, as used by reflect.Value.FieldByIndex.
+	Type    reflect.Type // Go type of the field.
+	Rules   []Rule       // Validation rules declared through the field's `validate` tag.
+}
+
+// MappingFor returns the full mapping of parameter `o`'s type, or an
+// error if `o` is not a struct. The returned TypeMapping is a copy and
+// may be modified freely without affecting the cache.
+func (self *Cartographer) MappingFor(o interface{}) (mapping TypeMapping, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	mapping.Type = typ
+
+	for _, mapped := range self.fields[typ] {
+		mapping.Fields = append(mapping.Fields, FieldMapping{
+			Name:    mapped.name,
+			Column:  mapped.column,
+			Options: append(TagOptions(nil), mapped.options...),
+			Index:   append([]int(nil), mapped.index...),
+			Type:    mapped.typ,
+			Rules:   append([]Rule(nil), mapped.rules...),
+		})
+	}
+
+	mapping.Relations = append([]Relation(nil), self.relations[typ]...)
+
+	return
+}
+
+// DumpMappings writes every cached type's mapping to writer `w` as a JSON
+// array ordered by type name, returning any error encountered encoding
+// or writing.
+func (self *Cartographer) DumpMappings(w io.Writer) error {
+	var dumps = make([]typeMappingDump, 0, len(self.typeCache))
+
+	for typ, _ := range self.typeCache {
+		mapping, err := self.MappingFor(reflect.New(typ).Interface())
+
+		if nil != err {
+			return err
+		}
+
+		dumps = append(dumps, mapping.dump())
+	}
+
+	sort.Slice(dumps, func(i, j int) bool {
+		return dumps[i].Type < dumps[j].Type
+	})
+
+	return json.NewEncoder(w).Encode(dumps)
+}
+
+type typeMappingDump struct {
+	Type      string         `json:"type"`
+	Fields    []fieldDump    `json:"fields"`
+	Relations []relationDump `json:"relations,omitempty"`
+}
+
+type fieldDump struct {
+	Name    string   `json:"name"`
+	Column  string   `json:"column"`
+	Type    string   `json:"type"`
+	Index   []int    `json:"index"`
+	Options []string `json:"options,omitempty"`
+}
+
+type relationDump struct {
+	Kind       RelationKind `json:"kind"`
+	Field      string       `json:"field"`
+	Type       string       `json:"type"`
+	ForeignKey string       `json:"foreign_key"`
+	References string       `json:"references"`
+	JoinTable  string       `json:"join_table,omitempty"`
+}
+
+// dump converts the TypeMapping to its JSON serializable form.
+func (self TypeMapping) dump() (dump typeMappingDump) {
+	dump.Type = self.Type.String()
+	dump.Fields = make([]fieldDump, 0, len(self.Fields))
+
+	for _, field := range self.Fields {
+		var options []string
+
+		for _, option := range field.Options {
+			options = append(options, option.String())
+		}
+
+		dump.Fields = append(dump.Fields, fieldDump{field.Name, field.Column, field.Type.String(), field.Index, options})
+	}
+
+	for _, relation := range self.Relations {
+		dump.Relations = append(dump.Relations, relationDump{relation.Kind, relation.Field, relation.Type.String(), relation.ForeignKey, relation.References, relation.JoinTable})
+	}
+
+	return
+}
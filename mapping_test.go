@@ -0,0 +1,49 @@
+package cartographer
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMappingFor(t *testing.T) {
+	mapping, err := instance.MappingFor(&indexed{})
+
+	if nil != err {
+		t.Errorf("Basic MappingFor test returned an unexpected error: %v", err)
+	}
+
+	if reflect.TypeOf(indexed{}) != mapping.Type || 4 != len(mapping.Fields) {
+		t.Fatalf("Basic MappingFor test returned an unexpected mapping: %v", mapping)
+	}
+
+	field := mapping.Fields[1]
+
+	if "Email" != field.Name || "email" != field.Column || !field.Options.Has("unique") ||
+		1 != len(field.Index) || 1 != field.Index[0] || reflect.TypeOf("") != field.Type {
+		t.Errorf("Basic MappingFor test returned an unexpected field: %v", field)
+	}
+}
+
+func TestDumpMappings(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		buffer       bytes.Buffer
+		dumps        []map[string]interface{}
+	)
+
+	cartographer.DiscoverType(indexed{})
+
+	if err := cartographer.DumpMappings(&buffer); nil != err {
+		t.Errorf("Basic DumpMappings test returned an unexpected error: %v", err)
+	}
+
+	if err := json.Unmarshal(buffer.Bytes(), &dumps); nil != err || 1 != len(dumps) {
+		t.Fatalf("Basic DumpMappings test wrote unexpected JSON: %s, %v", buffer.String(), err)
+	}
+
+	if "cartographer.indexed" != dumps[0]["type"] || 4 != len(dumps[0]["fields"].([]interface{})) {
+		t.Errorf("Basic DumpMappings test wrote an unexpected mapping: %v", dumps[0])
+	}
+}
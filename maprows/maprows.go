@@ -0,0 +1,81 @@
+// Package maprows adapts slices of maps to cartographer.ScannableRows,
+// letting in-memory data such as test data, cache entries or message
+// payloads flow through Map without touching a database.
+package maprows
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Rows is a slice of maps read as cartographer.ScannableRows.
+type Rows struct {
+	maps    []map[string]interface{} // Maps read as rows.
+	columns []string                 // Columns, the sorted keys of every map.
+	row     int                      // Index of the current map, one past it once Next has been called.
+}
+
+// From returns a pointer to Rows reading each of the `maps` as a row, the
+// keys of the maps as columns. Values are scanned as they are, keys missing
+// from a map as nil, leaving the fields they are mapped to untouched.
+func From(maps []map[string]interface{}) *Rows {
+	var (
+		columns []string
+		seen    = make(map[string]bool)
+	)
+
+	for _, row := range maps {
+		for key, _ := range row {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	sort.Strings(columns)
+
+	return &Rows{maps: maps, columns: columns}
+}
+
+// Columns returns the keys of every map, sorted.
+func (self *Rows) Columns() ([]string, error) {
+	return self.columns, nil
+}
+
+// Next advances to the next map, returning false once the maps are exhausted.
+func (self *Rows) Next() bool {
+	if self.row < len(self.maps) {
+		self.row++
+		return true
+	}
+
+	return false
+}
+
+// Scan copies the values of the current map into `dest`, which must
+// hold a pointer to an interface{} for each column.
+func (self *Rows) Scan(dest ...interface{}) error {
+	if 0 == self.row {
+		return errors.New("Scan called without calling Next")
+	}
+
+	if len(dest) != len(self.columns) {
+		return errors.New(fmt.Sprintf("Expected %d destination arguments in Scan, not %d", len(self.columns), len(dest)))
+	}
+
+	row := self.maps[self.row-1]
+
+	for index, column := range self.columns {
+		pointer, ok := dest[index].(*interface{})
+
+		if !ok {
+			return errors.New(fmt.Sprintf("Unsupported Scan destination %T", dest[index]))
+		}
+
+		*pointer = row[column]
+	}
+
+	return nil
+}
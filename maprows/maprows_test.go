@@ -0,0 +1,34 @@
+package maprows
+
+import (
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+type entry struct {
+	Key   string `db:"key"`
+	Hits  int    `db:"hits"`
+	Fresh bool   `db:"fresh"`
+}
+
+func TestFrom(t *testing.T) {
+	rows := From([]map[string]interface{}{
+		{"key": "a", "hits": 3, "fresh": true},
+		{"key": "b"},
+	})
+
+	if columns, _ := rows.Columns(); 3 != len(columns) || "fresh" != columns[0] || "key" != columns[2] {
+		t.Errorf("Basic maprows Columns test returned unexpected columns: %v", columns)
+	}
+
+	results, err := cartographer.Initialize("db").Map(rows, entry{})
+
+	if nil != err {
+		t.Errorf("Basic maprows Map test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(results) || (entry{"a", 3, true}) != *results[0].(*entry) || (entry{Key: "b"}) != *results[1].(*entry) {
+		t.Errorf("Basic maprows Map test returned unexpected results: %v", results)
+	}
+}
@@ -0,0 +1,91 @@
+package cartographer
+
+import (
+	"context"
+	"reflect"
+)
+
+// MapToMaps reads each of the `rows` into a map of column to value, for
+// ad-hoc queries, admin tools and dynamic reports with no struct to map
+// into. Should `reference` be nil, values are those scanned, []byte values
+// converted to strings. Otherwise `reference` must be a struct, and columns
+// it maps are instead keyed by field name, their values converted to the
+// field's type as Map would convert them, NULL values remaining nil.
+// Columns `reference` does not map are keyed and valued as they would
+// be without it.
+func (self *Cartographer) MapToMaps(rows ScannableRows, reference interface{}) (results []map[string]interface{}, err error) {
+	var typ reflect.Type
+
+	if nil != reference {
+		if typ, err = self.DiscoverType(reference); nil != err {
+			return
+		}
+	}
+
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	var mapped = make(map[string]bool) // Columns mapped by `reference`.
+
+	if nil != typ {
+		for _, column := range columns {
+			if _, ok := self.byColumn[typ][column]; ok {
+				mapped[column] = true
+			}
+		}
+	}
+
+	for rows.Next() {
+		values, err := populatedRowValues(rows, len(columns))
+
+		if nil != err {
+			return results, err
+		}
+
+		var (
+			result = make(map[string]interface{})
+			raw    = rawValues(values)
+		)
+
+		if 0 != len(mapped) {
+			replica := reflect.New(typ).Elem()
+
+			if err = self.populate(context.Background(), replica, columns, values, len(results), &call{columns: mapped}); nil != err {
+				return results, err
+			}
+
+			for index, column := range columns {
+				if !mapped[column] {
+					continue
+				}
+
+				field := self.byColumn[typ][column]
+
+				if nil == raw[index] {
+					result[field.name] = nil
+				} else {
+					result[field.name] = fieldValue(replica, field)
+				}
+			}
+		}
+
+		for index, column := range columns {
+			if mapped[column] {
+				continue
+			}
+
+			if bytes, ok := raw[index].([]byte); ok {
+				result[column] = string(bytes)
+			} else {
+				result[column] = raw[index]
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return
+}
@@ -0,0 +1,51 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type visit struct {
+	Id    int    `db:"id"`
+	Page  string `db:"page"`
+	Count int    `db:"count"`
+}
+
+func TestMapToMaps(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "page", "total"},
+		values:  [][]interface{}{{int64(1), []byte("/home"), int64(3)}},
+	}
+
+	results, err := Initialize("db").MapToMaps(rows, nil)
+
+	if nil != err {
+		t.Fatalf("Basic MapToMaps test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(results) || int64(1) != results[0]["id"] || "/home" != results[0]["page"] || int64(3) != results[0]["total"] {
+		t.Errorf("Basic MapToMaps test returned unexpected results: %v", results)
+	}
+}
+
+func TestMapToMapsReference(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "page", "count", "total"},
+		values:  [][]interface{}{{int64(1), []byte("/home"), nil, []byte("3")}},
+	}
+
+	results, err := Initialize("db").MapToMaps(rows, visit{})
+
+	if nil != err {
+		t.Fatalf("Basic MapToMaps test returned an unexpected error: %v", err)
+	}
+
+	result := results[0]
+
+	if 1 != result["Id"] || "/home" != result["Page"] || nil != result["Count"] || "3" != result["total"] {
+		t.Errorf("Basic MapToMaps test returned unexpected result: %v", result)
+	}
+
+	if _, ok := result["Count"]; !ok {
+		t.Error("Basic MapToMaps test expected NULL column to be present")
+	}
+}
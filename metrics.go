@@ -0,0 +1,106 @@
+package cartographer
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// MetricsSink receives measurements of a Cartographer's mapping activity,
+// so that its health may be tracked by a metrics system, see SetMetrics.
+// Its methods are called synchronously and should return promptly.
+type MetricsSink interface {
+	// RowsMapped records `rows` hydrated into structs of type `typ` by a
+	// single call to Map or Sync, failed calls included.
+	RowsMapped(typ reflect.Type, rows int)
+	// ConversionError records a value of column `column` failing to be
+	// set on its field of type `typ`.
+	ConversionError(typ reflect.Type, column string)
+	// HookFailed records a hook run against type `typ` returning an
+	// error or panicking, `hook` being its name should it be registered.
+	HookFailed(typ reflect.Type, hook string)
+	// CacheHit records type `typ` being found within the type cache.
+	CacheHit(typ reflect.Type)
+	// CacheMiss records type `typ` being discovered, not yet cached.
+	CacheMiss(typ reflect.Type)
+	// Duration records a single call to Map or Sync, being operation
+	// `map` or `sync`, for type `typ` taking `duration`.
+	Duration(typ reflect.Type, operation string, duration time.Duration)
+}
+
+// ContextMetricsSink is a MetricsSink receiving the context of the call to
+// MapContext or SyncContext measured, such as to label measurements with
+// the tenant carried by it. Measurements are recorded to the MetricsSink
+// it returns for the context; those of the type cache, and of write hooks
+// and SyncAll, which have none, to the ContextMetricsSink itself.
+type ContextMetricsSink interface {
+	MetricsSink
+	WithContext(ctx context.Context) MetricsSink
+}
+
+// NopMetrics is a MetricsSink discarding every measurement, the default.
+type NopMetrics struct{}
+
+func (NopMetrics) RowsMapped(typ reflect.Type, rows int)                               {}
+func (NopMetrics) ConversionError(typ reflect.Type, column string)                     {}
+func (NopMetrics) HookFailed(typ reflect.Type, hook string)                            {}
+func (NopMetrics) CacheHit(typ reflect.Type)                                           {}
+func (NopMetrics) CacheMiss(typ reflect.Type)                                          {}
+func (NopMetrics) Duration(typ reflect.Type, operation string, duration time.Duration) {}
+
+// SetMetrics sets the MetricsSink measurements are recorded to, restoring
+// NopMetrics should `sink` be nil.
+func (self *Cartographer) SetMetrics(sink MetricsSink) {
+	if nil == sink {
+		sink = NopMetrics{}
+	}
+
+	self.metrics = sink
+}
+
+// Metrics returns the MetricsSink measurements are recorded to, such as
+// for a MetricsSink set in its place to pass measurements on to.
+func (self *Cartographer) Metrics() MetricsSink {
+	return self.metrics
+}
+
+// SlowMapping is called with the type, number of rows and duration of a
+// call to Map or Sync taking longer than the threshold given to
+// SetSlowMapping, such as one reading pathologically wide rows or a huge
+// result set.
+type SlowMapping func(typ reflect.Type, rows int, duration time.Duration)
+
+// SetSlowMapping reports each call to Map or Sync taking longer than
+// `threshold` to `callback`, the duration including the time spent reading
+// rows from the database. A nil `callback` stops reporting.
+func (self *Cartographer) SetSlowMapping(threshold time.Duration, callback SlowMapping) {
+	self.slowThreshold = threshold
+	self.slowMapping = callback
+}
+
+// measure records a call to Map or Sync with context `ctx`, being
+// `operation`, hydrating `rows` of type `typ` since `start`, reporting it
+// should it be slow.
+func (self *Cartographer) measure(ctx context.Context, typ reflect.Type, operation string, rows int, start time.Time) {
+	var (
+		duration = time.Since(start)
+		metrics  = self.metricsFor(ctx)
+	)
+
+	metrics.RowsMapped(typ, rows)
+	metrics.Duration(typ, operation, duration)
+
+	if nil != self.slowMapping && self.slowThreshold < duration {
+		self.slowMapping(typ, rows, duration)
+	}
+}
+
+// metricsFor returns the MetricsSink measurements of a call with context
+// `ctx` are recorded to.
+func (self *Cartographer) metricsFor(ctx context.Context) MetricsSink {
+	if sink, ok := self.metrics.(ContextMetricsSink); ok {
+		return sink.WithContext(ctx)
+	}
+
+	return self.metrics
+}
@@ -0,0 +1,198 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingMetrics records each measurement, durations aside.
+type recordingMetrics struct {
+	measurements []string
+	durations    []string
+}
+
+func (self *recordingMetrics) RowsMapped(typ reflect.Type, rows int) {
+	self.measurements = append(self.measurements, fmt.Sprintf("rows %v %d", typ, rows))
+}
+
+func (self *recordingMetrics) ConversionError(typ reflect.Type, column string) {
+	self.measurements = append(self.measurements, fmt.Sprintf("conversion %v %s", typ, column))
+}
+
+func (self *recordingMetrics) HookFailed(typ reflect.Type, hook string) {
+	self.measurements = append(self.measurements, fmt.Sprintf("hook %v %s", typ, hook))
+}
+
+func (self *recordingMetrics) CacheHit(typ reflect.Type) {
+	self.measurements = append(self.measurements, fmt.Sprintf("hit %v", typ))
+}
+
+func (self *recordingMetrics) CacheMiss(typ reflect.Type) {
+	self.measurements = append(self.measurements, fmt.Sprintf("miss %v", typ))
+}
+
+func (self *recordingMetrics) Duration(typ reflect.Type, operation string, duration time.Duration) {
+	self.durations = append(self.durations, fmt.Sprintf("%s %v", operation, typ))
+}
+
+func TestMetrics(t *testing.T) {
+	var (
+		metrics = new(recordingMetrics)
+		c       = New(WithMetrics(metrics))
+		rows    = &fakeRows{
+			columns: []string{"id", "weight"},
+			values:  [][]interface{}{{int64(1), 1.5}, {int64(2), "heavy"}},
+		}
+	)
+
+	if _, err := c.Map(rows, consignment{}); nil == err {
+		t.Fatal("Basic Metrics test expected a conversion error")
+	}
+
+	var item consignment
+
+	if err := c.Sync(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{int64(3)}}}, &item); nil != err {
+		t.Fatalf("Basic Metrics test returned an unexpected error: %v", err)
+	}
+
+	c.RegisterNamedHook(consignment{}, "audit", 0, func(reflect.Value) error { return errors.New("audit failed") })
+
+	if _, err := c.Map(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{int64(4)}}}, consignment{}); nil == err {
+		t.Fatal("Basic Metrics test expected a hook error")
+	}
+
+	var (
+		expected = []string{
+			"conversion cartographer.consignment weight",
+			"rows cartographer.consignment 1",
+			"rows cartographer.consignment 1",
+			"hook cartographer.consignment audit",
+			"rows cartographer.consignment 0",
+		}
+		received     []string
+		misses, hits int
+	)
+
+	for _, measurement := range metrics.measurements {
+		switch {
+		case strings.HasPrefix(measurement, "miss "):
+			misses++
+		case strings.HasPrefix(measurement, "hit "):
+			hits++
+		default:
+			received = append(received, measurement)
+		}
+	}
+
+	if 1 != misses || 0 == hits || "miss cartographer.consignment" != metrics.measurements[0] {
+		t.Errorf("Basic Metrics test expected a single cache miss followed by hits, received %d misses and %d hits", misses, hits)
+	}
+
+	if strings.Join(expected, "\n") != strings.Join(received, "\n") {
+		t.Errorf("Basic Metrics test expected measurements:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(received, "\n"))
+	}
+
+	if "map cartographer.consignment,sync cartographer.consignment,map cartographer.consignment" != strings.Join(metrics.durations, ",") {
+		t.Errorf("Basic Metrics test returned unexpected durations: %v", metrics.durations)
+	}
+
+	c.SetMetrics(nil)
+
+	if _, ok := c.metrics.(NopMetrics); !ok {
+		t.Errorf("Basic Metrics test expected SetMetrics(nil) to restore NopMetrics, received %T", c.metrics)
+	}
+}
+
+func TestSlowMapping(t *testing.T) {
+	var (
+		reported []string
+		c        = New(WithSlowMapping(10*time.Millisecond, func(typ reflect.Type, rows int, duration time.Duration) {
+			reported = append(reported, fmt.Sprintf("%v %d %t", typ, rows, 10*time.Millisecond < duration))
+		}))
+		rows = func() *fakeRows {
+			return &fakeRows{columns: []string{"id"}, values: [][]interface{}{{int64(1)}, {int64(2)}}}
+		}
+	)
+
+	if _, err := c.Map(rows(), consignment{}); nil != err {
+		t.Fatalf("Basic SetSlowMapping test returned an unexpected error: %v", err)
+	}
+
+	if 0 != len(reported) {
+		t.Fatalf("Basic SetSlowMapping test expected a fast call not to be reported, received %v", reported)
+	}
+
+	c.RegisterHook(consignment{}, func(reflect.Value) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+
+	if _, err := c.Map(rows(), consignment{}); nil != err {
+		t.Fatalf("Basic SetSlowMapping test returned an unexpected error: %v", err)
+	}
+
+	var item consignment
+
+	if err := c.Sync(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{int64(3)}}}, &item); nil != err {
+		t.Fatalf("Basic SetSlowMapping test returned an unexpected error: %v", err)
+	}
+
+	if "cartographer.consignment 2 true,cartographer.consignment 1 true" != strings.Join(reported, ",") {
+		t.Errorf("Basic SetSlowMapping test returned unexpected slow calls reported: %v", reported)
+	}
+
+	reported = nil
+	c.SetSlowMapping(time.Hour, nil)
+
+	if _, err := c.Map(rows(), consignment{}); nil != err || 0 != len(reported) {
+		t.Errorf("Basic SetSlowMapping test expected no slow calls reported, received %v, %v", reported, err)
+	}
+}
+
+// contextMetrics records the request ID carried by the context of each
+// call measured.
+type contextMetrics struct {
+	recordingMetrics
+}
+
+func (self *contextMetrics) WithContext(ctx context.Context) MetricsSink {
+	self.measurements = append(self.measurements, fmt.Sprintf("context %v", ctx.Value(requestKey{})))
+	return self
+}
+
+func TestContextMetricsSink(t *testing.T) {
+	var (
+		metrics = new(contextMetrics)
+		c       = New(WithMetrics(metrics))
+		ctx     = context.WithValue(context.Background(), requestKey{}, "request-1")
+	)
+
+	if _, err := c.MapContext(ctx, &fakeRows{columns: []string{"id", "weight"}, values: [][]interface{}{{int64(1), "heavy"}}}, consignment{}); nil == err {
+		t.Fatal("Basic ContextMetricsSink test expected a conversion error")
+	}
+
+	var (
+		expected = []string{
+			"context request-1",
+			"conversion cartographer.consignment weight",
+			"context request-1",
+			"rows cartographer.consignment 0",
+		}
+		received []string
+	)
+
+	for _, measurement := range metrics.measurements {
+		if !strings.HasPrefix(measurement, "miss ") && !strings.HasPrefix(measurement, "hit ") {
+			received = append(received, measurement) // The type cache has no context.
+		}
+	}
+
+	if strings.Join(expected, "\n") != strings.Join(received, "\n") {
+		t.Errorf("Basic ContextMetricsSink test expected measurements:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(received, "\n"))
+	}
+}
@@ -0,0 +1,223 @@
+package cartographer
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// columnTypes maps the types of fields MigrationSQL declares the columns
+// of by their type rather than kind to the SQL types of their columns.
+var columnTypes = map[reflect.Type]string{
+	reflect.TypeOf(time.Time{}):       "timestamp",
+	reflect.TypeOf([]byte{}):          "bytea",
+	reflect.TypeOf(sql.NullString{}):  "text",
+	reflect.TypeOf(sql.NullInt64{}):   "bigint",
+	reflect.TypeOf(sql.NullInt32{}):   "integer",
+	reflect.TypeOf(sql.NullInt16{}):   "smallint",
+	reflect.TypeOf(sql.NullByte{}):    "smallint",
+	reflect.TypeOf(sql.NullFloat64{}): "double precision",
+	reflect.TypeOf(sql.NullBool{}):    "boolean",
+	reflect.TypeOf(sql.NullTime{}):    "timestamp",
+	reflect.TypeOf(sql.RawBytes{}):    "bytea",
+}
+
+// typeFamilies maps the leading word of SQL types to their family, so that
+// columns are only retyped when their field no longer fits their family,
+// as an int64 field still fits an integer column.
+var typeFamilies = map[string]string{
+	"tinyint": "integer", "smallint": "integer", "mediumint": "integer", "int": "integer", "integer": "integer",
+	"bigint": "integer", "int2": "integer", "int4": "integer", "int8": "integer",
+	"smallserial": "integer", "serial": "integer", "bigserial": "integer",
+	"real": "float", "float": "float", "float4": "float", "float8": "float", "double": "float",
+	"numeric": "float", "decimal": "float",
+	"bool": "boolean", "boolean": "boolean", "bit": "boolean",
+	"text": "text", "varchar": "text", "char": "text", "character": "text", "nvarchar": "text", "nchar": "text",
+	"tinytext": "text", "mediumtext": "text", "longtext": "text", "clob": "text", "citext": "text",
+	"uuid": "text", "enum": "text", "json": "text", "jsonb": "text",
+	"date": "timestamp", "datetime": "timestamp", "timestamp": "timestamp", "timestamptz": "timestamp",
+	"bytea": "bytes", "blob": "bytes", "longblob": "bytes", "binary": "bytes", "varbinary": "bytes",
+}
+
+// MigrationSQL returns the statements migrating the tables of `schema`,
+// such as that read with ReadDDL or introspected with IntrospectSchema,
+// to those mapped by the struct types of `types`, as a starting point for
+// a migration following edits to the types. Tables no type was stored in
+// are created, columns newly mapped are added, columns whose type no longer
+// fits that of their field, such as a text column mapped by an int64, are
+// retyped, and columns no longer mapped by any of the types are dropped,
+// unless given by `ignore` as CheckSchema accepts them. Columns are declared
+// with portable SQL types, NOT NULL unless the field may hold NULL, see
+// MarkdownMappings; review the statements before applying them. An error
+// is returned should any of the `types` not be a struct.
+func (self *Cartographer) MigrationSQL(schema Schema, ignore []string, types ...interface{}) (statements []string, err error) {
+	defer self.hold()()
+
+	var (
+		current = schema.clone()
+		mapped  = make(map[string]map[string]bool) // Columns mapped, keyed by table.
+		tables  []string                           // Tables migrated, in the order first met.
+		emit    = func(statement string) error {
+			statements = append(statements, statement)
+			return current.apply(statement)
+		}
+	)
+
+	for _, o := range types {
+		typ, err := self.DiscoverType(o)
+
+		if nil != err {
+			return nil, err
+		}
+
+		name, err := self.TableFor(o)
+
+		if nil != err {
+			return nil, err
+		}
+
+		table, ok := current.Table(name)
+
+		if !ok {
+			if err = emit(self.createTableSQL(typ, name)); nil != err {
+				return nil, err
+			}
+
+			table, _ = current.Table(name)
+		}
+
+		if nil == mapped[table.Name] {
+			mapped[table.Name] = make(map[string]bool)
+			tables = append(tables, table.Name)
+		}
+
+		for _, field := range self.fields[typ] {
+			var declared = self.columnType(field)
+
+			mapped[table.Name][strings.ToLower(field.column)] = true
+
+			if column, ok := table.Column(field.column); !ok {
+				err = emit(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table.Name, self.columnDefinition(typ, field)))
+			} else if family := typeFamily(column.Type); 0 != len(family) && family != typeFamily(declared) {
+				err = emit(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", table.Name, column.Name, declared))
+			}
+
+			if nil != err {
+				return nil, err
+			}
+		}
+
+		for _, column := range self.inheritedColumns(typ) {
+			mapped[table.Name][strings.ToLower(column)] = true
+		}
+	}
+
+	var ignored = make(map[string]bool)
+
+	for _, column := range ignore {
+		ignored[strings.ToLower(column)] = true
+	}
+
+	for _, name := range tables {
+		table, _ := current.Table(name)
+
+		for _, column := range table.Columns {
+			var key = strings.ToLower(column.Name)
+
+			if !mapped[name][key] && !ignored[key] && !ignored[strings.ToLower(name)+"."+key] {
+				statements = append(statements, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", name, column.Name))
+			}
+		}
+	}
+
+	return
+}
+
+// createTableSQL returns the CREATE TABLE statement of table `table` of
+// struct type `typ`, declaring its `pk` columns as its primary key.
+func (self *Cartographer) createTableSQL(typ reflect.Type, table string) string {
+	var definitions, keys []string
+
+	for _, field := range self.fields[typ] {
+		definitions = append(definitions, self.columnDefinition(typ, field))
+
+		if field.options.Has("pk") {
+			keys = append(keys, field.column)
+		}
+	}
+
+	if 0 != len(keys) {
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keys, ", ")))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (%s)", table, strings.Join(definitions, ", "))
+}
+
+// columnDefinition returns the definition of the column of field `mapped`
+// of struct type `typ`, its name and type, NOT NULL unless it is nullable.
+func (self *Cartographer) columnDefinition(typ reflect.Type, mapped *mappedField) string {
+	var definition = mapped.column + " " + self.columnType(mapped)
+
+	if !self.isNullable(typ, mapped) {
+		definition += " NOT NULL"
+	}
+
+	return definition
+}
+
+// columnType returns the SQL type of the column of field `mapped`,
+// `text` should its field's type have no obvious one.
+func (self *Cartographer) columnType(mapped *mappedField) string {
+	if self.isJSON(mapped) {
+		return "json"
+	}
+
+	var field = indirectType(mapped.typ)
+
+	if declared, ok := columnTypes[field]; ok {
+		return declared
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "smallint"
+	case reflect.Int32, reflect.Uint16:
+		return "integer"
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "bigint"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "double precision"
+	}
+
+	return "text"
+}
+
+// typeFamily returns the family of SQL type `declared`, such as `integer`
+// for `bigint`, or an empty string should it be unknown.
+func typeFamily(declared string) string {
+	var words = strings.FieldsFunc(strings.ToLower(declared), func(character rune) bool {
+		return ' ' == character || '(' == character
+	})
+
+	if 0 == len(words) {
+		return ""
+	}
+
+	return typeFamilies[words[0]]
+}
+
+// clone returns a copy of the Schema sharing none of its tables' columns.
+func (self Schema) clone() (cloned Schema) {
+	for _, table := range self.Tables {
+		table.Columns = append([]Column(nil), table.Columns...)
+		cloned.Tables = append(cloned.Tables, table)
+	}
+
+	return
+}
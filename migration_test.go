@@ -0,0 +1,65 @@
+package cartographer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type consignment struct {
+	ID      int64     `db:"id,pk"`
+	Weight  float64   `db:"weight"`
+	Carrier *string   `db:"carrier"`
+	Shipped time.Time `db:"shipped_at"`
+}
+
+func (consignment) TableName() string {
+	return "shipments"
+}
+
+type crate struct {
+	ID    int32  `db:"id,pk"`
+	Label string `db:"label"`
+}
+
+func TestMigrationSQL(t *testing.T) {
+	schema, err := ParseDDL("CREATE TABLE shipments (id bigserial PRIMARY KEY, weight text, shipped_at timestamp, legacy int, notes text)")
+
+	if nil != err {
+		t.Fatalf("Basic MigrationSQL test returned an unexpected error: %v", err)
+	}
+
+	c := New()
+	statements, err := c.MigrationSQL(schema, []string{"shipments.notes"}, consignment{}, crate{})
+
+	if nil != err {
+		t.Fatalf("Basic MigrationSQL test returned an unexpected error: %v", err)
+	}
+
+	var expected = []string{
+		"ALTER TABLE shipments ALTER COLUMN weight TYPE double precision",
+		"ALTER TABLE shipments ADD COLUMN carrier text",
+		"CREATE TABLE crate (id integer NOT NULL, label text NOT NULL, PRIMARY KEY (id))",
+		"ALTER TABLE shipments DROP COLUMN legacy",
+	}
+
+	if strings.Join(expected, "\n") != strings.Join(statements, "\n") {
+		t.Fatalf("Basic MigrationSQL test expected statements:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(statements, "\n"))
+	}
+
+	if err = schema.apply(strings.Join(statements, ";\n")); nil != err {
+		t.Fatalf("Basic MigrationSQL test returned an unexpected error: %v", err)
+	}
+
+	if err = c.CheckSchema(schema, []string{"shipments.notes"}, consignment{}, crate{}); nil != err {
+		t.Errorf("Basic MigrationSQL test expected the migrated schema to match, received %v", err)
+	}
+
+	if statements, err = c.MigrationSQL(schema, []string{"notes"}, consignment{}, crate{}); nil != err || 0 != len(statements) {
+		t.Errorf("Basic MigrationSQL test expected no statements, received %v, %v", statements, err)
+	}
+
+	if _, err = c.MigrationSQL(schema, nil, 1); nil == err {
+		t.Errorf("Basic MigrationSQL test expected an error migrating a non-struct")
+	}
+}
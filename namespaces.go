@@ -0,0 +1,232 @@
+package cartographer
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// mappings holds the mappings of the struct types discovered under a
+// single struct tag, so that structs carrying a tag per dialect, such as
+// `pg:"user_id" mysql:"userId"`, may be mapped under either.
+type mappings struct {
+	fieldsToColumns map[reflect.Type]map[interface{}]interface{}  // Map from an reflect.Type's fields to database columns.
+	columnsToFields map[reflect.Type]map[interface{}]interface{}  // Map from an reflect.Type's database columns to fields.
+	fields          map[reflect.Type][]*mappedField               // Ordered mapped fields of an reflect.Type.
+	byColumn        map[reflect.Type]map[interface{}]*mappedField // Mapped fields of an reflect.Type by column.
+	relations       map[reflect.Type][]Relation                   // Relations declared by an reflect.Type's `rel` tags.
+	typeCache       map[reflect.Type]bool                         // Is the reflect.Type cached?
+	recent          *list.List                                    // Cached reflect.Types, most recently used first.
+	elements        map[reflect.Type]*list.Element                // Elements of `recent` by reflect.Type.
+	holds           int                                           // Calls in progress, deferring evictions until they return.
+	cached          int64                                         // Number of cached types, read atomically.
+	lock            sync.Mutex                                    // Guards the mappings while the cache is bounded by SetCacheSize.
+}
+
+// newMappings returns a pointer to new, empty mappings.
+func newMappings() *mappings {
+	return &mappings{
+		fieldsToColumns: make(map[reflect.Type]map[interface{}]interface{}),
+		columnsToFields: make(map[reflect.Type]map[interface{}]interface{}),
+		fields:          make(map[reflect.Type][]*mappedField),
+		byColumn:        make(map[reflect.Type]map[interface{}]*mappedField),
+		relations:       make(map[reflect.Type][]Relation),
+		typeCache:       make(map[reflect.Type]bool),
+		recent:          list.New(),
+		elements:        make(map[reflect.Type]*list.Element),
+	}
+}
+
+// touch marks the cached type `typ` as the most recently used.
+func (self *mappings) touch(typ reflect.Type) {
+	if element, ok := self.elements[typ]; ok {
+		self.recent.MoveToFront(element)
+	} else {
+		self.elements[typ] = self.recent.PushFront(typ)
+	}
+}
+
+// trim evicts and returns the least recently used types beyond `capacity`,
+// should it be positive and no call hold the mappings.
+func (self *mappings) trim(capacity int) (evicted []reflect.Type) {
+	for 0 == self.holds && 0 < capacity && capacity < self.recent.Len() {
+		oldest := self.recent.Back().Value.(reflect.Type)
+		self.evict(oldest)
+		evicted = append(evicted, oldest)
+	}
+
+	return
+}
+
+// evict removes the mappings of type `typ`.
+func (self *mappings) evict(typ reflect.Type) {
+	delete(self.fieldsToColumns, typ)
+	delete(self.columnsToFields, typ)
+	delete(self.fields, typ)
+	delete(self.byColumn, typ)
+	delete(self.relations, typ)
+
+	if _, ok := self.typeCache[typ]; ok {
+		delete(self.typeCache, typ)
+		atomic.AddInt64(&self.cached, -1)
+	}
+
+	if element, ok := self.elements[typ]; ok {
+		self.recent.Remove(element)
+		delete(self.elements, typ)
+	}
+}
+
+// UseTag makes struct tag `tag` the active namespace, mapping fields to
+// columns through it from then on, such as `pg` or `mysql` for structs
+// read from two databases with different column names. The mappings of
+// each namespace are cached separately, so switching back and forth does
+// not discover types again; hooks, transforms and other registrations
+// are shared by every namespace.
+func (self *Cartographer) UseTag(tag string) {
+	self.structTag = tag
+	self.mappings = self.namespace(tag)
+}
+
+// SetCacheSize bounds the number of types whose mappings are cached for
+// each namespace to `size`, evicting the least recently used beyond it,
+// for servers mapping many types created with reflect.StructOf or through
+// generic instantiation. Evicted types are discovered again when next
+// used, while their registrations, such as hooks, are kept. Types used by
+// a call in progress, such as the related types of LoadMany, are not
+// evicted until it returns, so the cache may briefly exceed the size
+// should a call use more types; a size of zero or less leaves the cache
+// unbounded, sparing calls the bookkeeping. The size should be set before
+// the Cartographer is shared between goroutines.
+func (self *Cartographer) SetCacheSize(size int) {
+	self.cacheSize = size
+}
+
+// hold defers evicting types from the active namespace's cache until the
+// returned function is called, so that the types a call uses stay cached
+// throughout it, whatever the types its hooks and relations discover.
+// Holds nest, the cache being trimmed to its size as the last is released.
+// Nothing is held should the cache be unbounded.
+func (self *Cartographer) hold() (release func()) {
+	var held = self.mappings
+
+	if 0 >= self.cacheSize {
+		return func() {}
+	}
+
+	held.lock.Lock()
+	held.holds++
+	held.lock.Unlock()
+
+	return func() {
+		held.lock.Lock()
+		held.holds--
+		evicted := held.trim(self.cacheSize)
+		held.lock.Unlock()
+
+		self.evicted(evicted)
+	}
+}
+
+// evicted reports the types `evicted` from the cache.
+func (self *Cartographer) evicted(evicted []reflect.Type) {
+	for _, typ := range evicted {
+		self.debug(context.Background(), "cartographer: type evicted", "type", typ.String())
+		self.publish(Event{Kind: OnTypeEvicted, Type: typ})
+	}
+}
+
+// CachedTypes returns the number of types cached within the active
+// namespace, and may be called while other goroutines map rows.
+func (self *Cartographer) CachedTypes() int {
+	return int(atomic.LoadInt64(&self.cached))
+}
+
+// UseTagFor maps the fields of parameter `o`'s type through struct tag
+// `tag` whatever the active namespace, such as the `json` tags of a type
+// from a third-party package, or returns an error if `o` is not a struct.
+// Any mappings of the type already discovered are discarded.
+func (self *Cartographer) UseTagFor(o interface{}, tag string) error {
+	typ := indirectType(reflect.TypeOf(o))
+
+	if reflect.Struct != typ.Kind() {
+		return errors.New(fmt.Sprintf("Expected a struct to be passed, received %T", o))
+	}
+
+	self.detach()
+	self.typeTags[typ] = tag
+
+	for _, namespace := range self.namespaces {
+		namespace.evict(typ)
+	}
+
+	return nil
+}
+
+// detach gives the Cartographer mappings of its own, should they be shared
+// through With, before the way its types are mapped changes, so that the
+// change is seen by neither the Cartographer it was derived from nor those
+// derived from it.
+func (self *Cartographer) detach() {
+	if !self.sharedMappings {
+		return
+	}
+
+	self.namespaces = make(map[string]*mappings)
+	self.mappings = self.namespace(self.structTag)
+	self.sharedMappings = false
+}
+
+// tagFor returns the struct tag the fields of type `typ` are mapped through.
+func (self *Cartographer) tagFor(typ reflect.Type) string {
+	if tag, ok := self.typeTags[typ]; ok {
+		return tag
+	}
+
+	return self.structTag
+}
+
+// namespace returns the mappings discovered under struct tag `tag`.
+func (self *Cartographer) namespace(tag string) *mappings {
+	if _, ok := self.namespaces[tag]; !ok {
+		self.namespaces[tag] = newMappings()
+	}
+
+	return self.namespaces[tag]
+}
+
+// namespaced returns a Cartographer mapping through struct tag `tag`,
+// the Cartographer itself should `tag` be empty or already active, else
+// a copy sharing its registrations and settings.
+func (self *Cartographer) namespaced(tag string) *Cartographer {
+	if 0 == len(tag) || tag == self.structTag {
+		return self
+	}
+
+	var derived = *self
+
+	derived.UseTag(tag)
+
+	return &derived
+}
+
+// forCall returns the Cartographer a call overridden by `call` runs
+// against: one mapping through the namespace chosen with WithNamespace,
+// with mappings of its own should WithoutCache be given.
+func (self *Cartographer) forCall(call *call) *Cartographer {
+	var mapper = self.namespaced(call.namespace)
+
+	if !call.uncached {
+		return mapper
+	}
+
+	var derived = *mapper
+
+	derived.mappings = newMappings()
+
+	return &derived
+}
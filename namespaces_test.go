@@ -0,0 +1,201 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type dialected struct {
+	Id   int    `pg:"id" mysql:"ID"`
+	Name string `pg:"full_name" mysql:"fullName"`
+}
+
+func TestUseTag(t *testing.T) {
+	c := New(WithTag("pg"))
+
+	if column, err := c.ColumnForField(dialected{}, "Name"); nil != err || "full_name" != column {
+		t.Errorf("Basic UseTag test returned unexpected pg column: %v, %v", column, err)
+	}
+
+	c.UseTag("mysql")
+
+	if column, err := c.ColumnForField(dialected{}, "Name"); nil != err || "fullName" != column {
+		t.Errorf("Basic UseTag test returned unexpected mysql column: %v, %v", column, err)
+	}
+
+	c.UseTag("pg")
+
+	if column, err := c.ColumnForField(dialected{}, "Name"); nil != err || "full_name" != column {
+		t.Errorf("Basic UseTag test returned unexpected pg column after switching back: %v, %v", column, err)
+	}
+}
+
+func TestWithNamespace(t *testing.T) {
+	c := New(WithTag("pg"))
+
+	rows := &fakeRows{columns: []string{"ID", "fullName"}, values: [][]interface{}{{1, "ada"}}}
+
+	results, err := c.MapWith(rows, dialected{}, WithNamespace("mysql"))
+
+	if nil != err || 1 != len(results) || "ada" != results[0].(*dialected).Name {
+		t.Fatalf("Basic WithNamespace test returned unexpected results: %v, %v", results, err)
+	}
+
+	if "pg" != c.structTag {
+		t.Errorf("Basic WithNamespace test expected the active namespace to be left untouched, found %s", c.structTag)
+	}
+
+	var item dialected
+
+	rows = &fakeRows{columns: []string{"ID"}, values: [][]interface{}{{2}}}
+
+	if err = c.SyncWith(rows, &item, WithNamespace("mysql")); nil != err || 2 != item.Id {
+		t.Errorf("Basic WithNamespace test returned unexpected sync: %v, %v", item, err)
+	}
+}
+
+func TestSetCacheSize(t *testing.T) {
+	var evicted []reflect.Type
+
+	c := New(WithCacheSize(2))
+	c.Subscribe(OnTypeEvicted, func(event Event) {
+		evicted = append(evicted, event.Type)
+	})
+
+	c.DiscoverType(invoice{})
+	c.DiscoverType(shipment{})
+	c.DiscoverType(invoice{})
+	c.DiscoverType(dialected{})
+
+	if 1 != len(evicted) || reflect.TypeOf(shipment{}) != evicted[0] {
+		t.Errorf("Basic SetCacheSize test expected the least recently used type to be evicted, received %v", evicted)
+	}
+
+	if 2 != c.CachedTypes() || c.typeCache[reflect.TypeOf(shipment{})] || nil != c.fields[reflect.TypeOf(shipment{})] {
+		t.Errorf("Basic SetCacheSize test returned unexpected cache: %v", c.typeCache)
+	}
+
+	results, err := c.Map(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{1}}}, shipment{})
+
+	if nil != err || 1 != len(results) {
+		t.Errorf("Basic SetCacheSize test expected an evicted type to be discovered again, received %v, %v", results, err)
+	}
+}
+
+func TestWithoutCache(t *testing.T) {
+	c := New()
+
+	var item struct {
+		Total int `db:"total"`
+	}
+
+	rows := func() *fakeRows {
+		return &fakeRows{columns: []string{"total"}, values: [][]interface{}{{7}}}
+	}
+
+	if err := c.SyncWith(rows(), &item, WithoutCache()); nil != err || 7 != item.Total {
+		t.Errorf("Basic WithoutCache test returned unexpected sync: %v, %v", item, err)
+	}
+
+	results, err := c.MapWith(rows(), item, WithoutCache(), WithNamespace("db"))
+
+	if nil != err || 1 != len(results) {
+		t.Errorf("Basic WithoutCache test returned unexpected results: %v, %v", results, err)
+	}
+
+	if 0 != len(c.typeCache) {
+		t.Errorf("Basic WithoutCache test expected nothing to be cached, found %v", c.typeCache)
+	}
+}
+
+type vendored struct {
+	Id    int    `json:"id"`
+	Label string `json:"label" db:"ignored"`
+}
+
+func TestUseTagFor(t *testing.T) {
+	c := New()
+
+	if column, err := c.ColumnForField(vendored{}, "Label"); nil != err || "ignored" != column {
+		t.Fatalf("Basic UseTagFor test returned unexpected column: %v, %v", column, err)
+	}
+
+	if err := c.UseTagFor(&vendored{}, "json"); nil != err {
+		t.Fatalf("Basic UseTagFor test returned an unexpected error: %v", err)
+	}
+
+	if column, err := c.ColumnForField(vendored{}, "Label"); nil != err || "label" != column {
+		t.Errorf("Basic UseTagFor test expected the json tag to be used, received %v, %v", column, err)
+	}
+
+	if column, err := c.ColumnForField(invoice{}, "Customer"); nil != err || "customer" != column {
+		t.Errorf("Basic UseTagFor test expected other types to keep the db tag, received %v, %v", column, err)
+	}
+
+	if err := c.UseTagFor(1, "json"); nil == err {
+		t.Error("Basic UseTagFor test expected an error for a non-struct")
+	}
+}
+
+func TestSetCacheSizeHoldsTypesInUse(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, author_id FROM book WHERE author_id IN (?)": {
+			columns: []string{"id", "author_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1)}},
+		},
+	})
+
+	var evicted []reflect.Type
+
+	c := New(WithCacheSize(1))
+	c.Subscribe(OnTypeEvicted, func(event Event) {
+		evicted = append(evicted, event.Type)
+	})
+
+	authors := []*author{{Id: 1}}
+
+	if err := c.LoadMany(db, authors, "Books"); nil != err {
+		t.Fatalf("Basic SetCacheSize test returned an unexpected error loading a relation: %v", err)
+	}
+
+	if 1 != len(authors[0].Books) {
+		t.Errorf("Basic SetCacheSize test loaded unexpected books %v", authors[0].Books)
+	}
+
+	if 1 != c.CachedTypes() || 0 == len(evicted) {
+		t.Errorf("Basic SetCacheSize test expected the cache trimmed once the call returned, holding %d types after evicting %v", c.CachedTypes(), evicted)
+	}
+}
+
+func TestMapConcurrently(t *testing.T) {
+	for _, size := range []int{0, 2} {
+		var (
+			c    = New(WithCacheSize(size))
+			wait sync.WaitGroup
+		)
+
+		c.DiscoverType(shipment{})
+
+		for i := 0; i < 4; i++ {
+			wait.Add(1)
+
+			go func() {
+				defer wait.Done()
+
+				results, err := c.Map(&fakeRows{columns: []string{"id"}, values: [][]interface{}{{1}}}, shipment{})
+
+				if nil != err || 1 != len(results) {
+					t.Errorf("Basic Map test returned unexpected results: %v, %v", results, err)
+				}
+			}()
+		}
+
+		wait.Wait()
+
+		if 1 != c.CachedTypes() {
+			t.Errorf("Basic Map test expected a single cached type, received %d", c.CachedTypes())
+		}
+	}
+}
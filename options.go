@@ -0,0 +1,369 @@
+package cartographer
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// DefaultStructTag is the struct field tag New maps fields to columns
+// with, unless another is given with WithTag.
+const DefaultStructTag = "db"
+
+// Option configures a Cartographer created by New.
+type Option func(*Cartographer)
+
+// New returns a pointer to a new Cartographer mapping fields to columns
+// through their `db` tag, configured by each of the `opts` in turn. Each
+// option corresponds to a setter, such as WithStrictColumns to
+// SetStrictColumns, which may still be called once the Cartographer is
+// created.
+func New(opts ...Option) (cartographer *Cartographer) {
+	cartographer = Initialize(DefaultStructTag)
+
+	for _, opt := range opts {
+		opt(cartographer)
+	}
+
+	return
+}
+
+// With returns a pointer to a new Cartographer derived from this one,
+// configured by each of the `opts` in turn, such as a stricter mapper for
+// a single subsystem. The derived Cartographer shares the mappings of
+// every type discovered by either, so types are not discovered again,
+// while starting with copies of this one's settings and registrations,
+// such as hooks, transforms and variants, which may then be changed
+// independently. Should either change how types are mapped, with SetNamer
+// or UseTagFor, it stops sharing mappings, discovering its types again.
+func (self *Cartographer) With(opts ...Option) (derived *Cartographer) {
+	self.sharedMappings = true
+
+	derived = new(Cartographer)
+	*derived = *self
+
+	derived.hooks = maps.Clone(self.hooks)
+	derived.writeHooks = maps.Clone(self.writeHooks)
+	derived.syncHooks = maps.Clone(self.syncHooks)
+	derived.transforms = make(map[reflect.Type]map[string][]Transform)
+	derived.tables = maps.Clone(self.tables)
+	derived.listeners = maps.Clone(self.listeners)
+	derived.comparators = maps.Clone(self.comparators)
+	derived.writeConverters = maps.Clone(self.writeConverters)
+	derived.typeTags = maps.Clone(self.typeTags)
+	derived.validations = maps.Clone(self.validations)
+	derived.notNull = make(map[reflect.Type]map[string]bool)
+	derived.inheritance = make(map[reflect.Type]*inheritance)
+	derived.variants = maps.Clone(self.variants)
+	derived.tracked = make(map[interface{}]*Snapshot)
+
+	for typ, transforms := range self.transforms {
+		derived.transforms[typ] = make(map[string][]Transform)
+
+		for column, transform := range transforms {
+			derived.transforms[typ][column] = slices.Clip(transform)
+		}
+	}
+
+	for kind, subscribers := range derived.listeners {
+		derived.listeners[kind] = slices.Clip(subscribers)
+	}
+
+	for typ, columns := range self.notNull {
+		derived.notNull[typ] = maps.Clone(columns)
+	}
+
+	for typ, validations := range derived.validations {
+		derived.validations[typ] = slices.Clip(validations)
+	}
+
+	for typ, registered := range self.inheritance {
+		derived.inheritance[typ] = &inheritance{registered.column, maps.Clone(registered.variants)}
+	}
+
+	for _, opt := range opts {
+		opt(derived)
+	}
+
+	return
+}
+
+// WithTag maps fields to columns through struct field tag `tag`.
+func WithTag(tag string) Option {
+	return func(self *Cartographer) {
+		self.UseTag(tag)
+	}
+}
+
+// WithNamer names the columns of untagged fields, see SetNamer.
+func WithNamer(namer Namer) Option {
+	return func(self *Cartographer) {
+		self.SetNamer(namer)
+	}
+}
+
+// WithStrictColumns enables or disables strict columns, see SetStrictColumns.
+func WithStrictColumns(strict bool) Option {
+	return func(self *Cartographer) {
+		self.SetStrictColumns(strict)
+	}
+}
+
+// WithConverters registers each of the `converters` for writing values of
+// the type it is keyed by, see RegisterWriteConverter.
+func WithConverters(converters map[reflect.Type]Converter) Option {
+	return func(self *Cartographer) {
+		for typ, converter := range converters {
+			self.writeConverters[typ] = converter
+		}
+	}
+}
+
+// WithTimeLayouts parses textual time.Time values with `layouts`, see SetTimeLayouts.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(self *Cartographer) {
+		self.SetTimeLayouts(layouts...)
+	}
+}
+
+// WithCacheSize bounds the types cached per namespace to `size`, see SetCacheSize.
+func WithCacheSize(size int) Option {
+	return func(self *Cartographer) {
+		self.SetCacheSize(size)
+	}
+}
+
+// WithValidator validates structs with Validator `validator`, see SetValidator.
+func WithValidator(validator Validator) Option {
+	return func(self *Cartographer) {
+		self.SetValidator(validator)
+	}
+}
+
+// WithValidation validates hydrated structs, see SetValidation.
+func WithValidation(enabled bool) Option {
+	return func(self *Cartographer) {
+		self.SetValidation(enabled)
+	}
+}
+
+// WithPlaceholder generates SQL with Placeholder `placeholder`, see SetPlaceholder.
+func WithPlaceholder(placeholder Placeholder) Option {
+	return func(self *Cartographer) {
+		self.SetPlaceholder(placeholder)
+	}
+}
+
+// WithClock stamps columns with clock `now`, see SetClock.
+func WithClock(now func() time.Time) Option {
+	return func(self *Cartographer) {
+		self.SetClock(now)
+	}
+}
+
+// WithComparator diffs values with Comparator `comparator`, see SetComparator.
+func WithComparator(comparator Comparator) Option {
+	return func(self *Cartographer) {
+		self.SetComparator(comparator)
+	}
+}
+
+// WithSyncMultipleRows allows or disallows Sync reading several rows, see SetSyncMultipleRows.
+func WithSyncMultipleRows(allowed bool) Option {
+	return func(self *Cartographer) {
+		self.SetSyncMultipleRows(allowed)
+	}
+}
+
+// WithJSONCollections stores slice and map fields as JSON, see SetJSONCollections.
+func WithJSONCollections(enabled bool) Option {
+	return func(self *Cartographer) {
+		self.SetJSONCollections(enabled)
+	}
+}
+
+// WithAutoTrack tracks every struct hydrated or synced, see SetAutoTrack.
+func WithAutoTrack(enabled bool) Option {
+	return func(self *Cartographer) {
+		self.SetAutoTrack(enabled)
+	}
+}
+
+// WithLazyLoading loads Lazy relations with Querier `db`, see SetLazyLoading.
+func WithLazyLoading(db Querier) Option {
+	return func(self *Cartographer) {
+		self.SetLazyLoading(db)
+	}
+}
+
+// WithLogger writes diagnostics to Logger `logger`, see SetLogger.
+func WithLogger(logger Logger) Option {
+	return func(self *Cartographer) {
+		self.SetLogger(logger)
+	}
+}
+
+// WithMetrics records measurements to MetricsSink `sink`, see SetMetrics.
+func WithMetrics(sink MetricsSink) Option {
+	return func(self *Cartographer) {
+		self.SetMetrics(sink)
+	}
+}
+
+// WithSlowMapping reports calls to Map or Sync taking longer than
+// `threshold` to `callback`, see SetSlowMapping.
+func WithSlowMapping(threshold time.Duration, callback SlowMapping) Option {
+	return func(self *Cartographer) {
+		self.SetSlowMapping(threshold, callback)
+	}
+}
+
+// CallOption overrides the Cartographer's behavior for a single call to
+// MapWith, SyncWith or any other mapping method accepting them, such as
+// `c.MapWith(rows, o, WithStrict(false), WithHook(h))`, since one
+// strictness or NULL policy rarely fits every query. Hooks and
+// ContextHooks are themselves CallOptions; pass a slice of either with
+// WithHooks or WithContextHooks.
+type CallOption interface {
+	apply(*call)
+}
+
+// callOption adapts a function to a CallOption.
+type callOption func(*call)
+
+// apply applies the option to `call`.
+func (self callOption) apply(call *call) {
+	self(call)
+}
+
+// apply runs the Hook against each struct hydrated by the call.
+func (self Hook) apply(call *call) {
+	call.hooks = append(call.hooks, self.WithContext())
+}
+
+// apply runs the ContextHook against each struct hydrated by the call.
+func (self ContextHook) apply(call *call) {
+	call.hooks = append(call.hooks, self)
+}
+
+// WithStrict enables or disables strict columns for the call, overriding
+// the Cartographer's default set with SetStrictColumns.
+func WithStrict(strict bool) CallOption {
+	return callOption(func(call *call) {
+		call.strict = &strict
+	})
+}
+
+// WithHook passes Hook `hook` to the call, as though it were passed
+// directly, for readability alongside other CallOptions.
+func WithHook(hook Hook) CallOption {
+	return hook
+}
+
+// WithHooks passes each of the `hooks` to the call, so that a slice of
+// Hooks may be passed alongside other CallOptions, such as
+// `c.MapWith(rows, o, WithHooks(hooks...))`.
+func WithHooks(hooks ...Hook) CallOption {
+	return callOption(func(call *call) {
+		for _, hook := range hooks {
+			hook.apply(call)
+		}
+	})
+}
+
+// WithContextHooks behaves as WithHooks for ContextHooks.
+func WithContextHooks(hooks ...ContextHook) CallOption {
+	return callOption(func(call *call) {
+		call.hooks = append(call.hooks, hooks...)
+	})
+}
+
+// WithNamespace maps through struct tag `tag` for the call, rather
+// than the active namespace set with UseTag.
+func WithNamespace(tag string) CallOption {
+	return callOption(func(call *call) {
+		call.namespace = tag
+	})
+}
+
+// WithAliases reads each column returned by the call that is a key of
+// `aliases` as the column it maps to, such as `{"u_id": "id"}`, so that
+// a single query's SELECT aliases need neither new tags nor a second
+// struct. Hooks receiving the Row see the aliased columns.
+func WithAliases(aliases map[string]string) CallOption {
+	return callOption(func(call *call) {
+		if nil == call.aliases {
+			call.aliases = make(map[string]string)
+		}
+
+		for alias, column := range aliases {
+			call.aliases[alias] = column
+		}
+	})
+}
+
+// WithContinueOnInvalid skips rows failing validation, see SetValidation,
+// mapping the remaining rows and returning the valid structs alongside
+// RowErrors listing every invalid row, for import pipelines wanting a
+// full report rather than the first failure.
+func WithContinueOnInvalid() CallOption {
+	return callOption(func(call *call) {
+		call.continueOnInvalid = true
+	})
+}
+
+// RowErrorHandler receives each row failing conversion when passed to
+// WithRowErrorHandler, along with the error converting it.
+type RowErrorHandler func(row Row, err error)
+
+// WithRowErrorHandler passes each row failing conversion to `handler`,
+// with its index and raw values, skipping it rather than stopping at the
+// first, so that bulk imports map every convertible row while recording a
+// manifest of those that are not. Skipped rows are not returned as errors.
+// Sync leaves the fields set before the failure.
+func WithRowErrorHandler(handler RowErrorHandler) CallOption {
+	return callOption(func(call *call) {
+		call.rowErrors = handler
+	})
+}
+
+// WithTracking tracks each struct hydrated or synced by the call, see
+// Track, as SetAutoTrack tracks those of every call, so that only the
+// structs an application goes on to modify are retained. As with Track,
+// each must be passed to Untrack once its changes have been read.
+func WithTracking() CallOption {
+	return callOption(func(call *call) {
+		call.track = true
+	})
+}
+
+// WithoutCache discovers the types used by the call afresh, discarding
+// their mappings once it returns, so that one-off anonymous or dynamically
+// created struct types do not grow the cache, at the cost of discovering
+// them on every call.
+func WithoutCache() CallOption {
+	return callOption(func(call *call) {
+		call.uncached = true
+	})
+}
+
+// WithNullsAsZero sets the fields of columns returned NULL to their zero
+// value for the call, rather than leaving them untouched, such as when
+// syncing into a struct whose fields the database may have cleared.
+func WithNullsAsZero(enabled bool) CallOption {
+	return callOption(func(call *call) {
+		call.nullsAsZero = enabled
+	})
+}
+
+// newCall returns a call applying each of the `opts` in turn.
+func newCall(opts []CallOption) *call {
+	var call = new(call)
+
+	for _, opt := range opts {
+		opt.apply(call)
+	}
+
+	return call
+}
@@ -0,0 +1,342 @@
+package cartographer
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type reading struct {
+	Sensor   string     `sql:"sensor"`
+	Taken    time.Time  `sql:"taken"`
+	Received *time.Time `sql:"received"`
+	Value    float64
+}
+
+func TestNew(t *testing.T) {
+	c := New(WithTag("sql"), WithNamer(strings.ToLower), WithStrictColumns(true), WithPlaceholder(Dollar))
+
+	rows := &fakeRows{
+		columns: []string{"sensor", "value"},
+		values:  [][]interface{}{{"north", 1.5}},
+	}
+
+	results, err := c.Map(rows, reading{})
+
+	if nil != err || 1 != len(results) || "north" != results[0].(*reading).Sensor || 1.5 != results[0].(*reading).Value {
+		t.Fatalf("Basic New test returned unexpected results: %v, %v", results, err)
+	}
+
+	rows = &fakeRows{columns: []string{"unknown"}, values: [][]interface{}{{1}}}
+
+	if _, err = c.Map(rows, reading{}); nil == err {
+		t.Error("Basic New test expected an error mapping an unknown column with strict columns")
+	}
+
+	if "$1" != c.bind(1) {
+		t.Errorf("Basic New test expected dollar placeholders, received %s", c.bind(1))
+	}
+
+	if "db" != New().structTag {
+		t.Errorf("Basic New test expected the default struct tag, received %s", New().structTag)
+	}
+}
+
+func TestNewWithConverters(t *testing.T) {
+	c := New(WithConverters(map[reflect.Type]Converter{
+		reflect.TypeOf(""): func(value interface{}) (interface{}, error) { return strings.ToUpper(value.(string)), nil },
+	}))
+
+	values, err := c.ColumnValueMapFor(invoice{Id: 1, Customer: "acme"})
+
+	if nil != err || "ACME" != values["customer"] {
+		t.Errorf("Basic New test returned unexpected values: %v, %v", values, err)
+	}
+}
+
+func TestNewWithTimeLayouts(t *testing.T) {
+	c := New(WithTag("sql"), WithTimeLayouts(time.RFC3339, time.DateTime))
+
+	rows := &fakeRows{
+		columns: []string{"taken", "received"},
+		values:  [][]interface{}{{[]byte("2024-03-01 12:30:00"), "2024-03-01T12:31:00Z"}},
+	}
+
+	results, err := c.Map(rows, reading{})
+
+	if nil != err {
+		t.Fatalf("Basic New test returned an unexpected error: %v", err)
+	}
+
+	item := results[0].(*reading)
+
+	if !item.Taken.Equal(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)) || nil == item.Received || 31 != item.Received.Minute() {
+		t.Errorf("Basic New test returned unexpected times: %v, %v", item.Taken, item.Received)
+	}
+
+	rows = &fakeRows{columns: []string{"taken"}, values: [][]interface{}{{"yesterday"}}}
+
+	if _, err = c.Map(rows, reading{}); nil == err {
+		t.Error("Basic New test expected an error parsing a time matching no layout")
+	}
+}
+
+func TestCallOptions(t *testing.T) {
+	c := New(WithStrictColumns(true))
+
+	rows := func() *fakeRows {
+		return &fakeRows{columns: []string{"id", "customer", "unknown"}, values: [][]interface{}{{1, nil, 2}}}
+	}
+
+	if _, err := c.Map(rows(), invoice{}); nil == err {
+		t.Error("Basic CallOption test expected an error mapping an unknown column with strict columns")
+	}
+
+	var hooked int
+
+	results, err := c.MapWith(rows(), invoice{}, WithStrict(false), WithHook(func(value reflect.Value) error {
+		hooked++
+		return nil
+	}))
+
+	if nil != err || 1 != len(results) || 1 != hooked {
+		t.Errorf("Basic CallOption test returned unexpected results: %v, %v, %d", results, err, hooked)
+	}
+
+	item := invoice{Customer: "ada"}
+
+	if err = c.SyncWith(rows(), &item, WithStrict(false)); nil != err || "ada" != item.Customer {
+		t.Errorf("Basic CallOption test expected NULL to leave the field untouched, received %q, %v", item.Customer, err)
+	}
+
+	if err = c.SyncWith(rows(), &item, WithStrict(false), WithNullsAsZero(true)); nil != err || "" != item.Customer {
+		t.Errorf("Basic CallOption test expected NULL to zero the field, received %q, %v", item.Customer, err)
+	}
+}
+
+func TestCallOptionsEntryPoints(t *testing.T) {
+	var (
+		c      = New(WithStrictColumns(true))
+		ctx    = context.Background()
+		hooked int
+		hooks  = []Hook{func(value reflect.Value) error { hooked++; return nil }}
+		hook   = ContextHook(func(ctx context.Context, value reflect.Value) error { hooked++; return nil })
+	)
+
+	rows := func() *fakeRows {
+		return &fakeRows{columns: []string{"id", "customer", "unknown"}, values: [][]interface{}{{1, "ada", 2}}}
+	}
+
+	if results, err := c.MapContext(ctx, rows(), invoice{}, WithStrict(false), hook); nil != err || 1 != len(results) {
+		t.Errorf("Basic MapContext test returned an unexpected error: %v", err)
+	}
+
+	var item invoice
+
+	if err := c.SyncContext(ctx, rows(), &item, WithStrict(false), WithHooks(hooks...)); nil != err || 1 != item.Id {
+		t.Errorf("Basic SyncContext test returned an unexpected error: %v", err)
+	}
+
+	var items []invoice
+
+	if err := c.SyncAll(rows(), &items, WithStrict(false), WithContextHooks(hook)); nil != err || 1 != len(items) {
+		t.Errorf("Basic SyncAll test returned an unexpected error: %v", err)
+	}
+
+	if _, err := c.SyncWithReport(rows(), &item, WithStrict(false)); nil != err {
+		t.Errorf("Basic SyncWithReport test returned an unexpected error: %v", err)
+	}
+
+	polymorphic := &fakeRows{columns: []string{"id", "kind", "unknown"}, values: [][]interface{}{{1, "card", 2}}}
+
+	if _, err := c.MapPolymorphic(polymorphic, paymentVariants, "kind", WithStrict(false), WithHooks(hooks...)); nil != err {
+		t.Errorf("Basic MapPolymorphic test returned an unexpected error: %v", err)
+	}
+
+	db := openFake(map[string]fakeResult{
+		"SELECT * FROM invoice": {
+			columns: []string{"id", "customer", "unknown"},
+			rows:    [][]driver.Value{{int64(1), "ada", int64(2)}},
+		},
+	})
+
+	if _, err := c.Query(db, "SELECT * FROM invoice", nil, invoice{}, WithStrict(false), WithHooks(hooks...)); nil != err {
+		t.Errorf("Basic Query test returned an unexpected error: %v", err)
+	}
+
+	if err := c.QueryOne(db, "SELECT * FROM invoice", nil, &item, WithStrict(false)); nil != err {
+		t.Errorf("Basic QueryOne test returned an unexpected error: %v", err)
+	}
+
+	if 5 != hooked {
+		t.Errorf("Basic CallOption test expected 5 hooks to run, ran %d", hooked)
+	}
+
+	if _, err := c.Query(db, "SELECT * FROM invoice", nil, invoice{}); nil == err {
+		t.Error("Basic CallOption test expected options to apply to a single call alone")
+	}
+}
+
+func TestWith(t *testing.T) {
+	c := New()
+
+	if err := c.RegisterTransform(invoice{}, "customer", func(value interface{}) (interface{}, error) { return "parent", nil }); nil != err {
+		t.Fatalf("Basic With test returned an unexpected error: %v", err)
+	}
+
+	derived := c.With(WithStrictColumns(true))
+
+	if !derived.typeCache[reflect.TypeOf(invoice{})] {
+		t.Error("Basic With test expected the derived Cartographer to share discovered types")
+	}
+
+	derived.RegisterTransform(invoice{}, "customer", func(value interface{}) (interface{}, error) { return "derived", nil })
+	derived.RegisterHook(invoice{}, func(value reflect.Value) error { return nil })
+
+	if 1 != len(c.transforms[reflect.TypeOf(invoice{})]["customer"]) || 0 != len(c.hooks[reflect.TypeOf(invoice{})]) || c.strictColumns {
+		t.Error("Basic With test expected the derived Cartographer's changes to leave the original untouched")
+	}
+
+	derived.DiscoverType(shipment{})
+
+	if !c.typeCache[reflect.TypeOf(shipment{})] {
+		t.Error("Basic With test expected types discovered by the derived Cartographer to be shared")
+	}
+
+	if _, err := derived.Map(&fakeRows{columns: []string{"unknown"}, values: [][]interface{}{{1}}}, invoice{}); nil == err {
+		t.Error("Basic With test expected the derived Cartographer to use strict columns")
+	}
+}
+
+func TestWithVariants(t *testing.T) {
+	parent := New()
+
+	if err := parent.RegisterVariant(vehicle{}, "kind", "truck", truck{}); nil != err {
+		t.Fatalf("Basic With test returned an unexpected error: %v", err)
+	}
+
+	derived := parent.With()
+
+	if err := derived.RegisterVariant(vehicle{}, "kind", "bus", bus{}); nil != err {
+		t.Fatalf("Basic With test returned an unexpected error: %v", err)
+	}
+
+	results, err := parent.Map(&fakeRows{columns: []string{"id", "kind"}, values: [][]interface{}{{1, "bus"}}}, vehicle{})
+
+	if nil != err || 1 != len(results) {
+		t.Fatalf("Basic With test returned unexpected results: %v, %v", results, err)
+	}
+
+	if _, ok := results[0].(*vehicle); !ok || 1 != len(parent.inheritance[reflect.TypeOf(vehicle{})].variants) {
+		t.Errorf("Basic With test expected variants registered by the derived Cartographer to leave the parent untouched, received %#v", results[0])
+	}
+
+	if results, err = derived.Map(&fakeRows{columns: []string{"id", "kind"}, values: [][]interface{}{{1, "truck"}}}, vehicle{}); nil != err || 1 != len(results) {
+		t.Fatalf("Basic With test returned unexpected results: %v, %v", results, err)
+	}
+
+	if _, ok := results[0].(*truck); !ok {
+		t.Errorf("Basic With test expected the derived Cartographer to keep the parent's variants, received %#v", results[0])
+	}
+}
+
+type persona struct {
+	Name     string `db:"name" json:"display_name"`
+	Nickname string
+}
+
+func TestWithMappingChanges(t *testing.T) {
+	parent := New(WithNamer(SnakeCase))
+	parent.DiscoverType(persona{})
+
+	if err := parent.With().UseTagFor(persona{}, "json"); nil != err {
+		t.Errorf("Basic With test returned an unexpected error: %v", err)
+	}
+
+	if column, err := parent.ColumnForField(persona{}, "Name"); nil != err || "name" != column {
+		t.Errorf("Basic With test expected UseTagFor on a derived Cartographer to leave the parent's column, received %v, %v", column, err)
+	}
+
+	renamed := parent.With(WithNamer(strings.ToUpper))
+
+	if column, err := renamed.ColumnForField(persona{}, "Nickname"); nil != err || "NICKNAME" != column {
+		t.Errorf("Basic With test expected the derived Namer to name the column, received %v, %v", column, err)
+	}
+
+	if column, err := parent.ColumnForField(persona{}, "Nickname"); nil != err || "nickname" != column {
+		t.Errorf("Basic With test expected a derived Namer to leave the parent's column, received %v, %v", column, err)
+	}
+
+	shared := parent.With()
+	shared.DiscoverType(invoice{})
+
+	if !parent.typeCache[reflect.TypeOf(invoice{})] {
+		t.Error("Basic With test expected a Cartographer left unchanged to keep sharing mappings")
+	}
+}
+
+func TestWithAliases(t *testing.T) {
+	c := New(WithStrictColumns(true))
+
+	rows := func() *fakeRows {
+		return &fakeRows{columns: []string{"invoice_id", "customer"}, values: [][]interface{}{{4, "ada"}}}
+	}
+
+	results, err := c.MapWith(rows(), invoice{}, WithAliases(map[string]string{"invoice_id": "id"}))
+
+	if nil != err || 4 != results[0].(*invoice).Id || "ada" != results[0].(*invoice).Customer {
+		t.Errorf("Basic WithAliases test returned unexpected results: %v, %v", results, err)
+	}
+
+	var item invoice
+
+	if err = c.SyncWith(rows(), &item, WithAliases(map[string]string{"invoice_id": "id"})); nil != err || 4 != item.Id {
+		t.Errorf("Basic WithAliases test returned unexpected sync: %v, %v", item, err)
+	}
+
+	if _, err = c.Map(rows(), invoice{}); nil == err {
+		t.Error("Basic WithAliases test expected aliases to apply to a single call alone")
+	}
+}
+
+func TestWithRowErrorHandler(t *testing.T) {
+	var (
+		c      = New()
+		failed []string
+		handle = WithRowErrorHandler(func(row Row, err error) {
+			failed = append(failed, fmt.Sprintf("%d %v %t", row.Index, row.Values, nil != err))
+		})
+		rows = func() *fakeRows {
+			return &fakeRows{
+				columns: []string{"id", "weight"},
+				values:  [][]interface{}{{int64(1), 1.5}, {int64(2), "heavy"}, {int64(3), 2.5}, {int64(4), "light"}},
+			}
+		}
+	)
+
+	if _, err := c.Map(rows(), consignment{}); nil == err {
+		t.Fatal("Basic WithRowErrorHandler test expected a conversion error without a handler")
+	}
+
+	results, err := c.MapWith(rows(), consignment{}, handle)
+
+	if nil != err || 2 != len(results) || 3 != results[1].(*consignment).ID {
+		t.Fatalf("Basic WithRowErrorHandler test expected the convertible rows to be mapped, received %+v, %v", results, err)
+	}
+
+	if "1 [2 heavy] true,3 [4 light] true" != strings.Join(failed, ",") {
+		t.Errorf("Basic WithRowErrorHandler test returned unexpected rows reported: %v", failed)
+	}
+
+	var item consignment
+
+	failed = nil
+
+	if err = c.SyncWith(&fakeRows{columns: []string{"id", "weight"}, values: [][]interface{}{{int64(5), "heavy"}}}, &item, handle); nil != err || 1 != len(failed) {
+		t.Errorf("Basic WithRowErrorHandler test expected Sync to report the row, received %v, %v", failed, err)
+	}
+}
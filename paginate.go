@@ -0,0 +1,155 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Pagination restricts a query to a single page of its rows, see QueryPage.
+type Pagination interface {
+	// paginate returns `query` and `args` restricted to the page of type
+	// `typ`'s rows, and the number of rows the page holds.
+	paginate(c *Cartographer, typ reflect.Type, query string, args []interface{}) (string, []interface{}, int, error)
+
+	// next returns the Pagination following the page, ending with `last`.
+	next(c *Cartographer, last interface{}) (Pagination, error)
+}
+
+// Page is a Pagination by LIMIT and OFFSET, the query given ordering its rows.
+type Page struct {
+	Number int // Number of the page, counting from one.
+	Size   int // Number of rows on each page.
+}
+
+// Cursor is a Pagination by keyset, selecting the rows following those
+// already read by their key column, rather than skipping rows with an
+// OFFSET, so pages remain stable and fast as rows are inserted.
+type Cursor struct {
+	Column string      // Key column ordering the rows, the type's sole primary key if empty.
+	After  interface{} // Key of the last row already read, nil for the first page.
+	Size   int         // Number of rows on each page.
+}
+
+// Paged is a page of results returned by QueryPage.
+type Paged struct {
+	Items   []interface{} // Results of the page, as returned by Map.
+	HasMore bool          // Are there rows following the page?
+	Next    Pagination    // Pagination of the following page, nil if HasMore is false.
+}
+
+// QueryPage behaves as Query, restricting `query` to the page `page` of its
+// rows. A Page appends LIMIT and OFFSET clauses to `query`, while a Cursor
+// selects from `query` as a subquery, filtering and ordering by its key
+// column. A row beyond the page is read to learn whether more follow.
+func (self *Cartographer) QueryPage(db Querier, query string, args []interface{}, o interface{}, page Pagination, opts ...CallOption) (Paged, error) {
+	return self.QueryPageContext(context.Background(), db, query, args, o, page, opts...)
+}
+
+// QueryPageContext behaves as QueryPage, running the query with context `ctx`.
+func (self *Cartographer) QueryPageContext(ctx context.Context, db Querier, query string, args []interface{}, o interface{}, page Pagination, opts ...CallOption) (paged Paged, err error) {
+	var mapper = self.forCall(newCall(opts))
+
+	typ, err := mapper.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	query, args, size, err := page.paginate(mapper, typ, query, args)
+
+	if nil != err {
+		return
+	}
+
+	if paged.Items, err = self.QueryContext(ctx, db, query, args, o, opts...); nil != err {
+		return
+	}
+
+	if len(paged.Items) > size {
+		paged.Items = paged.Items[:size]
+		paged.HasMore = true
+		paged.Next, err = page.next(mapper, paged.Items[size-1])
+	}
+
+	return
+}
+
+func (self Page) paginate(c *Cartographer, typ reflect.Type, query string, args []interface{}) (string, []interface{}, int, error) {
+	if 1 > self.Number || 1 > self.Size {
+		return "", nil, 0, errors.New(fmt.Sprintf("Invalid page %d of size %d", self.Number, self.Size))
+	}
+
+	query = fmt.Sprintf("%s LIMIT %d OFFSET %d", query, self.Size+1, (self.Number-1)*self.Size)
+
+	return query, args, self.Size, nil
+}
+
+func (self Page) next(c *Cartographer, last interface{}) (Pagination, error) {
+	return Page{self.Number + 1, self.Size}, nil
+}
+
+func (self Cursor) paginate(c *Cartographer, typ reflect.Type, query string, args []interface{}) (string, []interface{}, int, error) {
+	if 1 > self.Size {
+		return "", nil, 0, errors.New(fmt.Sprintf("Invalid cursor size %d", self.Size))
+	}
+
+	column, err := self.column(c, typ)
+
+	if nil != err {
+		return "", nil, 0, err
+	}
+
+	query = fmt.Sprintf("SELECT * FROM (%s) AS page", query)
+
+	if nil != self.After {
+		args = append(args, self.After)
+		query = fmt.Sprintf("%s WHERE %s > %s", query, column, c.bind(len(args)))
+	}
+
+	query = fmt.Sprintf("%s ORDER BY %s LIMIT %d", query, column, self.Size+1)
+
+	return query, args, self.Size, nil
+}
+
+func (self Cursor) next(c *Cartographer, last interface{}) (Pagination, error) {
+	column, err := self.column(c, indirectType(reflect.TypeOf(last)))
+
+	if nil != err {
+		return nil, err
+	}
+
+	values, err := c.columnValues(last, false)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return Cursor{self.Column, values[column], self.Size}, nil
+}
+
+// column returns the key column of the cursor over type `typ`.
+func (self Cursor) column(c *Cartographer, typ reflect.Type) (string, error) {
+	if 0 != len(self.Column) {
+		if _, ok := c.byColumn[typ][self.Column]; !ok {
+			return "", errors.New(fmt.Sprintf("No field for column %s on %v", self.Column, typ))
+		}
+
+		return self.Column, nil
+	}
+
+	var keys []string
+
+	for _, mapped := range c.fields[typ] {
+		if mapped.options.Has("pk") {
+			keys = append(keys, mapped.column)
+		}
+	}
+
+	if 1 != len(keys) {
+		return "", errors.New(fmt.Sprintf("Cursor expected a single primary key or a Column for %v", typ))
+	}
+
+	return keys[0], nil
+}
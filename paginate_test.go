@@ -0,0 +1,84 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+var invoiceColumns = []string{"id", "customer", "total"}
+
+func TestQueryPage(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, customer, total FROM invoice LIMIT 3 OFFSET 2": {
+			columns: invoiceColumns,
+			rows:    [][]driver.Value{{int64(3), "c", int64(0)}, {int64(4), "d", int64(0)}, {int64(5), "e", int64(0)}},
+		},
+	})
+
+	paged, err := Initialize("db").QueryPage(db, "SELECT id, customer, total FROM invoice", nil, invoice{}, Page{2, 2})
+
+	if nil != err {
+		t.Fatalf("Basic QueryPage test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(paged.Items) || !paged.HasMore || (Page{3, 2}) != paged.Next {
+		t.Errorf("Basic QueryPage test returned unexpected page: %+v", paged)
+	}
+
+	if _, err = Initialize("db").QueryPage(db, "", nil, invoice{}, Page{0, 2}); nil == err {
+		t.Error("Basic QueryPage test expected an error given page zero")
+	}
+}
+
+func TestQueryCursor(t *testing.T) {
+	c := Initialize("db")
+	c.SetPlaceholder(Dollar)
+
+	db := openFake(map[string]fakeResult{
+		"SELECT * FROM (SELECT id, customer, total FROM invoice WHERE total > $1) AS page WHERE id > $2 ORDER BY id LIMIT 3": {
+			columns: invoiceColumns,
+			rows:    [][]driver.Value{{int64(8), "h", int64(5)}, {int64(9), "i", int64(5)}, {int64(10), "j", int64(5)}},
+		},
+	})
+
+	paged, err := c.QueryPage(db, "SELECT id, customer, total FROM invoice WHERE total > $1", []interface{}{1}, invoice{}, Cursor{After: 7, Size: 2})
+
+	if nil != err {
+		t.Fatalf("Basic QueryPage test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(paged.Items) || !paged.HasMore {
+		t.Fatalf("Basic QueryPage test returned unexpected page: %+v", paged)
+	}
+
+	if next := paged.Next.(Cursor); 9 != next.After || 2 != next.Size {
+		t.Errorf("Basic QueryPage test returned unexpected next cursor: %+v", next)
+	}
+
+	if args := fakeStatements[0].args; 2 != len(args) || int64(7) != args[1] {
+		t.Errorf("Basic QueryPage test returned unexpected arguments: %v", args)
+	}
+
+	if _, err = c.QueryPage(db, "", nil, lineItem{}, Cursor{Size: 2}); nil == err {
+		t.Error("Basic QueryPage test expected an error paging a composite key without a Column")
+	}
+}
+
+func TestRepositoryFindPage(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, customer, total FROM invoice ORDER BY id LIMIT 3 OFFSET 0": {
+			columns: invoiceColumns,
+			rows:    [][]driver.Value{{int64(1), "a", int64(0)}},
+		},
+	})
+
+	items, next, err := RepositoryFor[invoice](Initialize("db"), db).FindPage(Page{1, 2})
+
+	if nil != err {
+		t.Fatalf("Basic Repository FindPage test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(items) || nil != next {
+		t.Errorf("Basic Repository FindPage test returned unexpected page: %v, next %v", items, next)
+	}
+}
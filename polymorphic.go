@@ -0,0 +1,86 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// MapPolymorphic behaves as Map for rows of several types sharing a table,
+// hydrating each row into a replica of the struct of `variants` keyed by
+// the value of the row's `discriminator` column, such as
+// map[string]interface{}{"card": CardPayment{}, "bank": BankPayment{}}.
+// An error is returned should the rows lack the discriminator column, or a
+// row's discriminator be NULL or have no variant. The discriminator column
+// is skipped by variants not mapping it, unless strict columns are enabled
+// with SetStrictColumns. Any `opts` override the Cartographer's defaults
+// for this call alone, see CallOption.
+func (self *Cartographer) MapPolymorphic(rows ScannableRows, variants map[string]interface{}, discriminator string, opts ...CallOption) (results []interface{}, err error) {
+	call := newCall(opts)
+	return self.forCall(call).mapPolymorphic(rows, variants, discriminator, call)
+}
+
+// mapPolymorphic behaves as MapPolymorphic, applying the overrides of `call`.
+func (self *Cartographer) mapPolymorphic(rows ScannableRows, variants map[string]interface{}, discriminator string, call *call) (results []interface{}, err error) {
+	defer self.hold()()
+
+	for _, variant := range variants {
+		if _, err = self.DiscoverType(variant); nil != err {
+			return
+		}
+	}
+
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	columns = call.alias(columns)
+
+	var position = -1 // Position of the discriminator within the columns.
+
+	for index, column := range columns {
+		if discriminator == column {
+			position = index
+		}
+	}
+
+	if -1 == position {
+		return nil, errors.New(fmt.Sprintf("No discriminator column %s", discriminator))
+	}
+
+	for row := 0; rows.Next(); row++ {
+		values, err := populatedRowValues(rows, len(columns))
+
+		if nil != err {
+			return results, err
+		}
+
+		kind, ok := relationKey(*values[position].(*interface{}))
+
+		if !ok {
+			return results, errors.New(fmt.Sprintf("NULL discriminator column %s at row %d", discriminator, row))
+		}
+
+		variant, ok := variants[kind]
+
+		if !ok {
+			return results, errors.New(fmt.Sprintf("No variant for discriminator %s at row %d", kind, row))
+		}
+
+		replica, err := self.mapRow(context.Background(), variant, call.hooks, columns, values, row, call)
+
+		if errRowSkipped == err {
+			continue
+		}
+
+		if nil != err {
+			return results, err
+		}
+
+		results = append(results, replica.Interface())
+	}
+
+	return
+}
@@ -0,0 +1,55 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type cardPayment struct {
+	Id   int    `db:"id"`
+	Last string `db:"last_four"`
+}
+
+type bankPayment struct {
+	Id   int    `db:"id"`
+	Iban string `db:"iban"`
+}
+
+var paymentVariants = map[string]interface{}{"card": cardPayment{}, "bank": bankPayment{}}
+
+func TestMapPolymorphic(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "kind", "last_four", "iban"},
+		values: [][]interface{}{
+			{1, []byte("card"), "4242", nil},
+			{2, "bank", nil, "DE89"},
+		},
+	}
+
+	results, err := Initialize("db").MapPolymorphic(rows, paymentVariants, "kind")
+
+	if nil != err {
+		t.Fatalf("Basic MapPolymorphic test returned an unexpected error: %v", err)
+	}
+
+	if card, ok := results[0].(*cardPayment); !ok || "4242" != card.Last {
+		t.Errorf("Basic MapPolymorphic test returned unexpected first result: %#v", results[0])
+	}
+
+	if bank, ok := results[1].(*bankPayment); !ok || "DE89" != bank.Iban || 2 != bank.Id {
+		t.Errorf("Basic MapPolymorphic test returned unexpected second result: %#v", results[1])
+	}
+}
+
+func TestMapPolymorphicErrors(t *testing.T) {
+	c := Initialize("db")
+
+	if _, err := c.MapPolymorphic(&fakeRows{columns: []string{"id"}}, paymentVariants, "kind"); nil == err {
+		t.Error("Basic MapPolymorphic test expected an error without a discriminator column")
+	}
+
+	rows := &fakeRows{columns: []string{"id", "kind"}, values: [][]interface{}{{1, "cash"}}}
+
+	if _, err := c.MapPolymorphic(rows, paymentVariants, "kind"); nil == err {
+		t.Error("Basic MapPolymorphic test expected an error given an unknown discriminator")
+	}
+}
@@ -0,0 +1,147 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Preload eagerly loads the relations named by each of the `paths` for
+// each of the `items`, a slice of structs or of pointers to structs, such
+// as "Author" or "Comments.Author", each segment naming a relation field
+// of the type the previous segment loaded. Each distinct path prefix is
+// loaded once, by a single query, as LoadMany or LoadOne loads it, so a
+// graph is loaded in as many queries as it has distinct prefixes. A
+// segment may be repeated to a depth with a `*` suffix, such that
+// "Replies*3" loads "Replies.Replies.Replies" for self-referencing types.
+func (self *Cartographer) Preload(db Querier, items interface{}, paths ...string) error {
+	return self.PreloadContext(context.Background(), db, items, paths...)
+}
+
+// PreloadContext behaves as Preload, selecting the related rows with
+// context `ctx`.
+func (self *Cartographer) PreloadContext(ctx context.Context, db Querier, items interface{}, paths ...string) (err error) {
+	defer self.hold()()
+
+	values, typ, err := self.loadable(items)
+
+	if nil != err || 0 == len(values) {
+		return
+	}
+
+	var loaded = make(map[string][]reflect.Value) // Structs loaded by each path prefix.
+
+	for _, path := range paths {
+		segments, err := expandPath(path)
+
+		if nil != err {
+			return err
+		}
+
+		var (
+			current = values
+			owner   = typ
+			prefix  string
+		)
+
+		for _, segment := range segments {
+			if 0 != len(prefix) {
+				prefix += "."
+			}
+
+			prefix += segment
+
+			relation, err := self.RelationFor(reflect.New(owner).Interface(), segment)
+
+			if nil != err {
+				return err
+			}
+
+			if _, err = self.DiscoverType(reflect.New(relation.Type).Interface()); nil != err {
+				return err
+			}
+
+			if _, ok := loaded[prefix]; !ok {
+				if 0 != len(current) {
+					switch relation.Kind {
+					case HasMany, ManyToMany:
+						err = self.loadMany(ctx, db, current, owner, relation)
+					default:
+						err = self.loadOne(ctx, db, current, owner, relation)
+					}
+
+					if nil != err {
+						return err
+					}
+				}
+
+				loaded[prefix] = relatedStructs(current, relation)
+			}
+
+			current, owner = loaded[prefix], relation.Type
+		}
+	}
+
+	return
+}
+
+// expandPath splits preload path `path` into its segments, repeating
+// segments suffixed with `*` and a depth.
+func expandPath(path string) (segments []string, err error) {
+	for _, segment := range strings.Split(path, ".") {
+		var depth = 1
+
+		if index := strings.Index(segment, "*"); -1 != index {
+			if depth, err = strconv.Atoi(segment[index+1:]); nil != err || 1 > depth {
+				return nil, errors.New(fmt.Sprintf("Invalid depth in preload path %s", path))
+			}
+
+			segment = segment[:index]
+		}
+
+		if 0 == len(segment) {
+			return nil, errors.New(fmt.Sprintf("Empty segment in preload path %s", path))
+		}
+
+		for ; 0 < depth; depth-- {
+			segments = append(segments, segment)
+		}
+	}
+
+	return
+}
+
+// relatedStructs returns the addressable structs held by relation
+// `relation` of each of the `items`, skipping nil pointers.
+func relatedStructs(items []reflect.Value, relation Relation) (structs []reflect.Value) {
+	for _, item := range items {
+		value := relationValue(item, relation)
+
+		var elements = []reflect.Value{value}
+
+		if reflect.Slice == value.Kind() {
+			elements = nil
+
+			for index := 0; index < value.Len(); index++ {
+				elements = append(elements, value.Index(index))
+			}
+		}
+
+		for _, element := range elements {
+			if reflect.Ptr == element.Kind() {
+				if element.IsNil() {
+					continue
+				}
+
+				element = element.Elem()
+			}
+
+			structs = append(structs, element)
+		}
+	}
+
+	return
+}
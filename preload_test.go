@@ -0,0 +1,89 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+type thread struct {
+	Id       int        `db:"id,pk"`
+	Comments []*comment `rel:"has_many,fk=thread_id"`
+}
+
+type comment struct {
+	Id       int        `db:"id,pk"`
+	ThreadId int        `db:"thread_id"`
+	WriterId int        `db:"writer_id"`
+	Writer   *writer    `rel:"belongs_to,fk=writer_id"`
+	Replies  []*comment `rel:"has_many,fk=thread_id"`
+}
+
+type writer struct {
+	Id   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func TestPreload(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, thread_id, writer_id FROM comment WHERE thread_id IN (?)": {
+			columns: []string{"id", "thread_id", "writer_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1), int64(7)}, {int64(11), int64(1), int64(8)}},
+		},
+		"SELECT id, name FROM writer WHERE id IN (?, ?)": {
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(7), "ada"}, {int64(8), "bob"}},
+		},
+	})
+
+	threads := []*thread{{Id: 1}}
+
+	if err := Initialize("db").Preload(db, threads, "Comments", "Comments.Writer"); nil != err {
+		t.Fatalf("Basic Preload test returned an unexpected error: %v", err)
+	}
+
+	comments := threads[0].Comments
+
+	if 2 != len(comments) || "ada" != comments[0].Writer.Name || "bob" != comments[1].Writer.Name {
+		t.Errorf("Basic Preload test returned unexpected comments: %v", comments)
+	}
+
+	if 2 != len(fakeStatements) {
+		t.Errorf("Basic Preload test expected 2 queries, received %d", len(fakeStatements))
+	}
+}
+
+func TestPreloadDepth(t *testing.T) {
+	if segments, err := expandPath("Comments.Replies*2"); nil != err || 3 != len(segments) || "Replies" != segments[2] {
+		t.Errorf("Basic Preload test returned unexpected segments: %v, %v", segments, err)
+	}
+
+	for _, path := range []string{"Replies*0", "Replies*x", "Comments..Writer"} {
+		if _, err := expandPath(path); nil == err {
+			t.Errorf("Basic Preload test expected an error expanding %q", path)
+		}
+	}
+
+	if err := Initialize("db").Preload(openFake(nil), []thread{{}}, "Missing"); nil == err {
+		t.Error("Basic Preload test expected an error preloading an unknown relation")
+	}
+}
+
+func TestRepositoryPreload(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id FROM thread WHERE id = ?": {columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+		"SELECT id, thread_id, writer_id FROM comment WHERE thread_id IN (?)": {
+			columns: []string{"id", "thread_id", "writer_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1), int64(7)}},
+		},
+	})
+
+	item, err := RepositoryFor[thread](Initialize("db"), db).Preload("Comments").Find(1)
+
+	if nil != err {
+		t.Fatalf("Basic Repository Preload test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(item.Comments) || 10 != item.Comments[0].Id {
+		t.Errorf("Basic Repository Preload test returned unexpected comments: %v", item.Comments)
+	}
+}
@@ -0,0 +1,214 @@
+// Package promcollector exposes the metrics of a cartographer.Cartographer
+// to Prometheus, as cartographer_mapped_rows_total and
+// cartographer_conversion_errors_total counters and a
+// cartographer_type_cache_size gauge, with two lines of wiring:
+//
+//	collector := promcollector.New(c)
+//	http.Handle("/metrics", collector)
+//
+// The Collector serves the Prometheus text exposition format itself, so as
+// not to burden cartographer with the Prometheus client library; it is not
+// a prometheus.Collector of github.com/prometheus/client_golang, so it is
+// served on a handler of its own rather than registered with a registry.
+package promcollector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// Names of the metric families exposed.
+const (
+	MappedRowsTotal       = "cartographer_mapped_rows_total"       // Rows hydrated by Map and Sync, by type.
+	ConversionErrorsTotal = "cartographer_conversion_errors_total" // Values failing to be set on their field, by type and column.
+	TypeCacheSize         = "cartographer_type_cache_size"         // Types cached within the active namespace.
+)
+
+// Family is a metric family gathered by a Collector.
+type Family struct {
+	Name    string   // Name of the family, such as MappedRowsTotal.
+	Help    string   // Description of the family.
+	Type    string   // Type of the family, `counter` or `gauge`.
+	Labels  []string // Names of the labels of its samples.
+	Samples []Sample // Samples of the family, ordered by their labels.
+}
+
+// Sample is a single sample of a Family.
+type Sample struct {
+	Labels []string // Values of the family's labels, in order.
+	Value  float64  // Value of the sample.
+}
+
+// Collector is a cartographer.MetricsSink counting the rows mapped and the
+// conversion errors of a Cartographer, safe to gather from while mapping,
+// passing every measurement on to the MetricsSink set before it.
+type Collector struct {
+	cartographer *cartographer.Cartographer // Cartographer measured.
+	counts       *counts                    // Counts, shared by the Collectors returned by WithContext.
+	next         cartographer.MetricsSink   // MetricsSink measurements are passed on to.
+}
+
+// counts holds the counts of a Collector.
+type counts struct {
+	mutex  sync.Mutex            // Guards the counts from concurrent gathering.
+	rows   map[string]float64    // Rows mapped, keyed by type.
+	errors map[[2]string]float64 // Conversion errors, keyed by type and column.
+}
+
+// New returns a pointer to a new Collector measuring Cartographer `c`,
+// set as its MetricsSink in place of the MetricsSink set before, to which
+// the Collector passes every measurement on.
+func New(c *cartographer.Cartographer) *Collector {
+	var collector = &Collector{
+		cartographer: c,
+		counts:       &counts{rows: make(map[string]float64), errors: make(map[[2]string]float64)},
+		next:         c.Metrics(),
+	}
+
+	c.SetMetrics(collector)
+
+	return collector
+}
+
+// WithContext returns a Collector passing measurements on with context
+// `ctx` to the MetricsSink set before, should it be a
+// cartographer.ContextMetricsSink, counting alongside the Collector.
+func (self *Collector) WithContext(ctx context.Context) cartographer.MetricsSink {
+	next, ok := self.next.(cartographer.ContextMetricsSink)
+
+	if !ok {
+		return self
+	}
+
+	var derived = *self
+
+	derived.next = next.WithContext(ctx)
+
+	return &derived
+}
+
+// RowsMapped counts the `rows` mapped into type `typ`.
+func (self *Collector) RowsMapped(typ reflect.Type, rows int) {
+	self.counts.mutex.Lock()
+	self.counts.rows[typ.String()] += float64(rows)
+	self.counts.mutex.Unlock()
+
+	self.next.RowsMapped(typ, rows)
+}
+
+// ConversionError counts the conversion error of column `column` of type `typ`.
+func (self *Collector) ConversionError(typ reflect.Type, column string) {
+	self.counts.mutex.Lock()
+	self.counts.errors[[2]string{typ.String(), column}]++
+	self.counts.mutex.Unlock()
+
+	self.next.ConversionError(typ, column)
+}
+
+// HookFailed passes the measurement on, not being exposed.
+func (self *Collector) HookFailed(typ reflect.Type, hook string) {
+	self.next.HookFailed(typ, hook)
+}
+
+// CacheHit passes the measurement on, not being exposed.
+func (self *Collector) CacheHit(typ reflect.Type) {
+	self.next.CacheHit(typ)
+}
+
+// CacheMiss passes the measurement on, not being exposed.
+func (self *Collector) CacheMiss(typ reflect.Type) {
+	self.next.CacheMiss(typ)
+}
+
+// Duration passes the measurement on, not being exposed.
+func (self *Collector) Duration(typ reflect.Type, operation string, duration time.Duration) {
+	self.next.Duration(typ, operation, duration)
+}
+
+// Gather returns the metric families of the Collector, ordered by name,
+// sampling the size of the type cache as it is gathered, and may be called
+// while other goroutines map rows.
+func (self *Collector) Gather() []Family {
+	var cached = self.cartographer.CachedTypes()
+
+	self.counts.mutex.Lock()
+	defer self.counts.mutex.Unlock()
+
+	var (
+		rows   = Family{MappedRowsTotal, "Rows mapped by Map and Sync.", "counter", []string{"type"}, nil}
+		errors = Family{ConversionErrorsTotal, "Values failing conversion to their field.", "counter", []string{"type", "column"}, nil}
+		gauge  = Family{TypeCacheSize, "Types cached within the active namespace.", "gauge", nil, []Sample{{nil, float64(cached)}}}
+	)
+
+	for typ, count := range self.counts.rows {
+		rows.Samples = append(rows.Samples, Sample{[]string{typ}, count})
+	}
+
+	for key, count := range self.counts.errors {
+		errors.Samples = append(errors.Samples, Sample{[]string{key[0], key[1]}, count})
+	}
+
+	for _, family := range []Family{rows, errors} {
+		sort.Slice(family.Samples, func(i, j int) bool {
+			return strings.Join(family.Samples[i].Labels, "\x00") < strings.Join(family.Samples[j].Labels, "\x00")
+		})
+	}
+
+	return []Family{errors, rows, gauge}
+}
+
+// WriteTo writes the metric families of the Collector to writer `w` in
+// the Prometheus text exposition format, returning the number of bytes
+// written and any error encountered.
+func (self *Collector) WriteTo(w io.Writer) (int64, error) {
+	var builder strings.Builder
+
+	for _, family := range self.Gather() {
+		fmt.Fprintf(&builder, "# HELP %s %s\n# TYPE %s %s\n", family.Name, escape(family.Help, false), family.Name, family.Type)
+
+		for _, sample := range family.Samples {
+			var labels []string
+
+			for index, name := range family.Labels {
+				labels = append(labels, fmt.Sprintf("%s=\"%s\"", name, escape(sample.Labels[index], true)))
+			}
+
+			if 0 == len(labels) {
+				fmt.Fprintf(&builder, "%s %v\n", family.Name, sample.Value)
+			} else {
+				fmt.Fprintf(&builder, "%s{%s} %v\n", family.Name, strings.Join(labels, ","), sample.Value)
+			}
+		}
+	}
+
+	written, err := io.WriteString(w, builder.String())
+
+	return int64(written), err
+}
+
+// ServeHTTP serves the metric families of the Collector for scraping.
+func (self *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	self.WriteTo(w)
+}
+
+// escape escapes backslashes and line feeds within `text`, and double
+// quotes should it be a label value.
+func escape(text string, label bool) string {
+	text = strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(text)
+
+	if label {
+		text = strings.ReplaceAll(text, `"`, `\"`)
+	}
+
+	return text
+}
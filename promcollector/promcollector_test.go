@@ -0,0 +1,152 @@
+package promcollector
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+	"github.com/chuckpreslar/cartographer/maprows"
+)
+
+type account struct {
+	Id    int    `db:"id,pk"`
+	Email string `db:"email"`
+}
+
+func TestCollector(t *testing.T) {
+	var (
+		c         = cartographer.Initialize("db")
+		collector = New(c)
+	)
+
+	if _, err := c.Map(maprows.From([]map[string]interface{}{{"id": 1, "email": "a@example.com"}, {"id": 2, "email": "b@example.com"}}), account{}); nil != err {
+		t.Fatalf("Basic Collector test returned an unexpected error: %v", err)
+	}
+
+	if _, err := c.Map(maprows.From([]map[string]interface{}{{"id": "one"}}), account{}); nil == err {
+		t.Fatal("Basic Collector test expected a conversion error")
+	}
+
+	var (
+		recorder = httptest.NewRecorder()
+		expected = `# HELP cartographer_conversion_errors_total Values failing conversion to their field.
+# TYPE cartographer_conversion_errors_total counter
+cartographer_conversion_errors_total{type="promcollector.account",column="id"} 1
+# HELP cartographer_mapped_rows_total Rows mapped by Map and Sync.
+# TYPE cartographer_mapped_rows_total counter
+cartographer_mapped_rows_total{type="promcollector.account"} 2
+# HELP cartographer_type_cache_size Types cached within the active namespace.
+# TYPE cartographer_type_cache_size gauge
+cartographer_type_cache_size 1
+`
+	)
+
+	collector.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if expected != recorder.Body.String() {
+		t.Errorf("Basic Collector test expected exposition:\n%s\nreceived:\n%s", expected, recorder.Body.String())
+	}
+
+	if "text/plain; version=0.0.4; charset=utf-8" != recorder.Header().Get("Content-Type") {
+		t.Errorf("Basic Collector test returned unexpected content type: %s", recorder.Header().Get("Content-Type"))
+	}
+}
+
+type requestKey struct{}
+
+type previousMetrics struct {
+	cartographer.NopMetrics
+
+	rows     int
+	requests []interface{}
+}
+
+func (self *previousMetrics) RowsMapped(typ reflect.Type, rows int) {
+	self.rows += rows
+}
+
+func (self *previousMetrics) WithContext(ctx context.Context) cartographer.MetricsSink {
+	self.requests = append(self.requests, ctx.Value(requestKey{}))
+	return self
+}
+
+func TestCollectorChains(t *testing.T) {
+	var (
+		c        = cartographer.Initialize("db")
+		previous = new(previousMetrics)
+		ctx      = context.WithValue(context.Background(), requestKey{}, "abc")
+	)
+
+	c.SetMetrics(previous)
+
+	collector := New(c)
+
+	if _, err := c.MapContext(ctx, maprows.From([]map[string]interface{}{{"id": 1}, {"id": 2}}), account{}); nil != err {
+		t.Errorf("Basic Collector chaining test returned an unexpected error: %v", err)
+	}
+
+	if 2 != previous.rows || 1 != len(previous.requests) || "abc" != previous.requests[0] {
+		t.Errorf("Basic Collector chaining test expected measurements to be passed on, received %d rows, %v", previous.rows, previous.requests)
+	}
+
+	if families := collector.Gather(); 2 != families[1].Samples[0].Value {
+		t.Errorf("Basic Collector chaining test expected rows to be counted, received %v", families[1].Samples)
+	}
+}
+
+func TestCollectorSamplesCache(t *testing.T) {
+	var (
+		c         = cartographer.Initialize("db")
+		collector = New(c)
+	)
+
+	if _, err := c.DiscoverType(account{}); nil != err {
+		t.Errorf("Basic Collector sampling test returned an unexpected error: %v", err)
+	}
+
+	if families := collector.Gather(); 1 != families[2].Samples[0].Value {
+		t.Errorf("Basic Collector sampling test expected the cache size to be sampled when gathered, received %v", families[2].Samples)
+	}
+}
+
+func TestCollectorGathersWhileMapping(t *testing.T) {
+	var (
+		c         = cartographer.Initialize("db")
+		collector = New(c)
+		done      = make(chan bool)
+	)
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 50; i++ {
+			typ := reflect.StructOf([]reflect.StructField{{Name: "Id", Type: reflect.TypeOf(i), Tag: reflect.StructTag(fmt.Sprintf(`db:"id%d"`, i))}})
+
+			if _, err := c.DiscoverType(reflect.New(typ).Elem().Interface()); nil != err {
+				t.Errorf("Basic Collector gathering test returned an unexpected error: %v", err)
+			}
+		}
+	}()
+
+	for gathering := true; gathering; {
+		select {
+		case <-done:
+			gathering = false
+		default:
+			collector.Gather()
+		}
+	}
+
+	if families := collector.Gather(); 50 != families[2].Samples[0].Value {
+		t.Errorf("Basic Collector gathering test expected 50 cached types, received %v", families[2].Samples)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if `a\\b\"c\nd` != escape("a\\b\"c\nd", true) || `a\\b"c\nd` != escape("a\\b\"c\nd", false) {
+		t.Error("Basic Escape test returned unexpected escaping")
+	}
+}
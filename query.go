@@ -0,0 +1,120 @@
+package cartographer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Querier is the database every helper touching the database runs its
+// statements against, satisfied by *sql.DB, *sql.Tx and *sql.Conn alike,
+// so that helpers work identically within transactions.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Query runs `query` with `args` against Querier `db`, mapping the rows
+// returned as Map maps them, closing the rows and returning any error
+// encountered while iterating them. Any `opts` are passed to Map.
+func (self *Cartographer) Query(db Querier, query string, args []interface{}, o interface{}, opts ...CallOption) ([]interface{}, error) {
+	return self.QueryContext(context.Background(), db, query, args, o, opts...)
+}
+
+// QueryContext behaves as Query, running `query` and mapping its rows
+// with context `ctx`, see MapContext.
+func (self *Cartographer) QueryContext(ctx context.Context, db Querier, query string, args []interface{}, o interface{}, opts ...CallOption) (results []interface{}, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+
+	if nil != err {
+		return
+	}
+
+	defer rows.Close()
+
+	if results, err = self.MapContext(ctx, rows, o, opts...); nil != err {
+		return
+	}
+
+	err = rows.Err()
+
+	return
+}
+
+// QueryOne runs `query` with `args` against Querier `db`, mapping the
+// single row returned into the struct pointed to by `dest`, a row of a
+// variant registered with RegisterVariant setting the columns of `dest`'s
+// type alone. Should no rows be returned sql.ErrNoRows is returned, should
+// several be returned ErrMultipleRows is.
+func (self *Cartographer) QueryOne(db Querier, query string, args []interface{}, dest interface{}, opts ...CallOption) error {
+	return self.QueryOneContext(context.Background(), db, query, args, dest, opts...)
+}
+
+// QueryOneContext behaves as QueryOne, running `query` with context `ctx`.
+func (self *Cartographer) QueryOneContext(ctx context.Context, db Querier, query string, args []interface{}, dest interface{}, opts ...CallOption) error {
+	results, err := self.QueryContext(ctx, db, query, args, dest, opts...)
+
+	if nil != err {
+		return err
+	}
+
+	switch len(results) {
+	case 0:
+		return sql.ErrNoRows
+	case 1:
+		return self.forCall(newCall(opts)).assignBase(reflect.ValueOf(dest).Elem(), results[0])
+	}
+
+	return ErrMultipleRows
+}
+
+// FindByPK selects the row of the table of the struct pointed to by `dest`
+// identified by primary key values `keys`, given in field declaration
+// order, mapping it into `dest`. Should there be no such row sql.ErrNoRows
+// is returned.
+func (self *Cartographer) FindByPK(db Querier, dest interface{}, keys ...interface{}) error {
+	return self.FindByPKContext(context.Background(), db, dest, keys...)
+}
+
+// FindByPKContext behaves as FindByPK, selecting the row with context `ctx`.
+func (self *Cartographer) FindByPKContext(ctx context.Context, db Querier, dest interface{}, keys ...interface{}) error {
+	typ, err := self.DiscoverType(dest)
+
+	if nil != err {
+		return err
+	}
+
+	var values = make(map[interface{}]interface{})
+
+	for _, mapped := range self.fields[typ] {
+		if !mapped.options.Has("pk") {
+			continue
+		}
+
+		if len(values) == len(keys) {
+			return errors.New(fmt.Sprintf("FindByPK expected more than %d primary key values for %v", len(keys), typ))
+		}
+
+		values[mapped.column] = keys[len(values)]
+	}
+
+	if len(values) != len(keys) {
+		return errors.New(fmt.Sprintf("FindByPK expected %d primary key values for %v, received %d", len(values), typ, len(keys)))
+	}
+
+	query, err := self.SelectSQL(dest)
+
+	if nil != err {
+		return err
+	}
+
+	where, args, err := self.wherePrimaryKeys(typ, values, nil)
+
+	if nil != err {
+		return err
+	}
+
+	return self.QueryOneContext(ctx, db, query+" WHERE "+where, args, dest)
+}
@@ -0,0 +1,132 @@
+package cartographer
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id FROM faker": {columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}, {int64(2)}}},
+	})
+
+	results, err := instance.Query(db, "SELECT id FROM faker", nil, faker{})
+
+	if nil != err || 2 != len(results) || 2 != results[1].(*faker).Id {
+		t.Errorf("Basic Query test returned an unexpected results: %v, %v", results, err)
+	}
+
+	var item faker
+
+	if err = instance.QueryOne(db, "SELECT id FROM faker", nil, &item); ErrMultipleRows != err {
+		t.Errorf("Basic QueryOne test returned an unexpected error: %v", err)
+	}
+
+	if err = instance.QueryOne(db, "SELECT id FROM faker WHERE false", nil, &item); sql.ErrNoRows != err {
+		t.Errorf("Basic QueryOne test returned an unexpected error: %v", err)
+	}
+}
+
+func TestFindByPK(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT invoice_id, line, quantity FROM line_item WHERE invoice_id = ? AND line = ?": {
+			columns: []string{"invoice_id", "line", "quantity"},
+			rows:    [][]driver.Value{{int64(1), int64(2), int64(5)}},
+		},
+	})
+
+	var item lineItem
+
+	if err := Initialize("db").FindByPK(db, &item, 1, 2); nil != err {
+		t.Fatalf("Basic FindByPK test returned an unexpected error: %v", err)
+	}
+
+	if 5 != item.Quantity {
+		t.Errorf("Basic FindByPK test returned unexpected line item: %+v", item)
+	}
+
+	if args := fakeStatements[0].args; 2 != len(args) || int64(2) != args[1] {
+		t.Errorf("Basic FindByPK test returned unexpected arguments: %v", args)
+	}
+
+	if err := Initialize("db").FindByPK(db, &item, 1); nil == err {
+		t.Error("Basic FindByPK test expected an error given too few primary key values")
+	}
+
+	if err := Initialize("db").FindByPK(openFake(nil), &item, 3, 4); sql.ErrNoRows != err {
+		t.Errorf("Basic FindByPK test expected sql.ErrNoRows, received %v", err)
+	}
+}
+
+func TestQueryWithinTransaction(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id FROM faker": {columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}},
+	})
+
+	tx, err := db.Begin()
+
+	if nil != err {
+		t.Fatalf("Basic Query test returned an unexpected error: %v", err)
+	}
+
+	defer tx.Rollback()
+
+	var querier Querier = tx
+
+	results, err := Initialize("db").Query(querier, "SELECT id FROM faker", nil, faker{})
+
+	if nil != err {
+		t.Fatalf("Basic Query test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(results) {
+		t.Errorf("Basic Query test expected 1 result, received %d", len(results))
+	}
+}
+
+type queryKey struct{}
+
+func TestQueryContext(t *testing.T) {
+	var (
+		db     = openFake(map[string]fakeResult{"SELECT id FROM faker": {columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}}})
+		ctx    = context.WithValue(context.Background(), queryKey{}, "abc")
+		passed interface{}
+	)
+
+	hook := ContextHook(func(ctx context.Context, value reflect.Value) error {
+		passed = ctx.Value(queryKey{})
+		return nil
+	})
+
+	if _, err := instance.QueryContext(ctx, db, "SELECT id FROM faker", nil, faker{}, hook); nil != err || "abc" != passed {
+		t.Errorf("Basic QueryContext test returned an unexpected results: %v, %v", passed, err)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := instance.QueryContext(canceled, db, "SELECT id FROM faker", nil, faker{}); context.Canceled != err {
+		t.Errorf("Basic QueryContext test returned an unexpected error: %v", err)
+	}
+
+	if _, err := Initialize("db").CountContext(canceled, db, invoice{}, nil); context.Canceled != err {
+		t.Errorf("Basic CountContext test returned an unexpected error: %v", err)
+	}
+
+	if err := Initialize("db").LoadManyContext(canceled, db, []*author{{Id: 1}}, "Books"); context.Canceled != err {
+		t.Errorf("Basic LoadManyContext test returned an unexpected error: %v", err)
+	}
+
+	repository := RepositoryFor[invoice](Initialize("db"), db).WithContext(canceled)
+
+	if _, err := repository.FindAll(); context.Canceled != err {
+		t.Errorf("Basic Repository WithContext test returned an unexpected error: %v", err)
+	}
+
+	if err := repository.Insert(&invoice{Customer: "ada"}); context.Canceled != err {
+		t.Errorf("Basic Repository WithContext test returned an unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RelationTag is the struct field tag relations are declared with,
+// e.g. `rel:"has_many,fk=user_id"`.
+const RelationTag = "rel"
+
+// RelationKind is the kind of association a Relation describes.
+type RelationKind string
+
+const (
+	HasMany    RelationKind = "has_many"   // The related type holds a foreign key to the owner, many rows per owner.
+	HasOne     RelationKind = "has_one"    // The related type holds a foreign key to the owner, one row per owner.
+	BelongsTo  RelationKind = "belongs_to" // The owner holds a foreign key to the related type.
+	ManyToMany RelationKind = "many2many"  // A join table holds foreign keys to both the owner and the related type.
+)
+
+// Relation describes an association declared on a struct field through
+// its `rel` tag. The `fk` option names the foreign key column, which lives
+// on the related type for has_many and has_one relations and on the owner
+// for belongs_to relations. The `ref` option names the column the foreign
+// key references, defaulting to `id`.
+//
+// Many-to-many relations are instead declared through a join table, e.g.
+// `rel:"many2many,join=user_roles,fk=user_id,ref=role_id"`, the `join`
+// option naming the join table, `fk` its column referencing the owner and
+// `ref` its column referencing the related type. Each side's column is
+// its sole primary key, else `id`.
+type Relation struct {
+	Kind       RelationKind // Kind of the relation.
+	Field      string       // Name of the field holding the related value(s).
+	Type       reflect.Type // Struct type of the related value(s).
+	ForeignKey string       // Foreign key column, or the join table's column referencing the owner.
+	References string       // Column referenced by the foreign key, or the join table's column referencing the related type.
+	JoinTable  string       // Join table of many2many relations.
+	Lazy       bool         // Is the field a Lazy, loaded on first access?
+}
+
+// RelationFor returns the relation declared on parameter `o`'s field `field`,
+// or an error if `o` is not a struct or the field declares no relation.
+func (self *Cartographer) RelationFor(o interface{}, field string) (Relation, error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return Relation{}, err
+	}
+
+	for _, relation := range self.relations[typ] {
+		if field == relation.Field {
+			return relation, nil
+		}
+	}
+
+	return Relation{}, errors.New(fmt.Sprintf("No relation for field %s on %v", field, typ))
+}
+
+// RelationsFor returns the relations declared on parameter `o`'s fields,
+// in field declaration order, or an error if `o` is not a struct. The
+// returned slice is a copy and may be modified freely.
+func (self *Cartographer) RelationsFor(o interface{}) ([]Relation, error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return nil, err
+	}
+
+	return append([]Relation(nil), self.relations[typ]...), nil
+}
+
+// RelationGraph returns the relations of parameter `o`'s type and of every
+// type reachable from it through relations, keyed by type, or an error if
+// `o` or any related type can not be mapped. Types declaring no relations
+// are included with an empty slice, so that the graph's keys are exactly
+// the types reachable from `o`.
+func (self *Cartographer) RelationGraph(o interface{}) (graph map[reflect.Type][]Relation, err error) {
+	defer self.hold()()
+
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	graph = make(map[reflect.Type][]Relation)
+
+	for pending := []reflect.Type{typ}; 0 != len(pending); pending = pending[1:] {
+		if _, ok := graph[pending[0]]; ok {
+			continue
+		}
+
+		relations, err := self.RelationsFor(reflect.New(pending[0]).Interface())
+
+		if nil != err {
+			return nil, err
+		}
+
+		graph[pending[0]] = relations
+
+		for _, relation := range relations {
+			pending = append(pending, relation.Type)
+		}
+	}
+
+	return
+}
+
+// parseRelation parses the `rel` tag of struct field `field`, returning
+// an error if the kind is unknown, the foreign key is missing, or the
+// field's type can not hold the related value(s).
+func parseRelation(field reflect.StructField, tag string) (relation Relation, err error) {
+	kind, options := parseTag(tag)
+
+	relation.Kind = RelationKind(kind)
+	relation.Field = field.Name
+	relation.ForeignKey = options.Get("fk")
+	relation.References = options.Get("ref")
+	relation.JoinTable = options.Get("join")
+
+	if ManyToMany == relation.Kind && (0 == len(relation.JoinTable) || 0 == len(relation.References)) {
+		err = errors.New(fmt.Sprintf("Missing join or ref option for %s relation %s", kind, field.Name))
+		return
+	}
+
+	if 0 == len(relation.References) {
+		relation.References = "id"
+	}
+
+	typ := field.Type
+
+	if inner := lazyType(typ); nil != inner {
+		typ = inner
+		relation.Lazy = true
+	}
+
+	switch relation.Kind {
+	case HasMany, ManyToMany:
+		if reflect.Slice != typ.Kind() {
+			err = errors.New(fmt.Sprintf("Expected a slice for %s relation %s, received %v", kind, field.Name, typ))
+			return
+		}
+
+		typ = typ.Elem()
+	case HasOne, BelongsTo:
+	default:
+		err = errors.New(fmt.Sprintf("Unknown relation %s for %s", kind, field.Name))
+		return
+	}
+
+	if reflect.Ptr == typ.Kind() {
+		typ = typ.Elem()
+	}
+
+	if reflect.Struct != typ.Kind() {
+		err = errors.New(fmt.Sprintf("Expected a struct for %s relation %s, received %v", kind, field.Name, typ))
+		return
+	}
+
+	if 0 == len(relation.ForeignKey) {
+		err = errors.New(fmt.Sprintf("Missing fk option for %s relation %s", kind, field.Name))
+		return
+	}
+
+	relation.Type = typ
+
+	return
+}
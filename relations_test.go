@@ -0,0 +1,87 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+type author struct {
+	Id    int    `db:"id"`
+	Books []book `rel:"has_many,fk=author_id"`
+}
+
+type book struct {
+	Id       int     `db:"id"`
+	AuthorId int     `db:"author_id"`
+	Author   *author `rel:"belongs_to,fk=author_id"`
+}
+
+type orphan struct {
+	Parent author `rel:"belongs_to"`
+}
+
+func TestRelationFor(t *testing.T) {
+	relation, err := instance.RelationFor(author{}, "Books")
+
+	if nil != err {
+		t.Errorf("Basic RelationFor test returned an unexpected error: %v", err)
+	}
+
+	if HasMany != relation.Kind || "author_id" != relation.ForeignKey || "id" != relation.References || reflect.TypeOf(book{}) != relation.Type {
+		t.Errorf("Basic RelationFor test returned an unexpected relation: %v", relation)
+	}
+
+	relation, err = instance.RelationFor(book{}, "Author")
+
+	if nil != err || BelongsTo != relation.Kind || reflect.TypeOf(author{}) != relation.Type {
+		t.Errorf("Basic RelationFor test returned an unexpected results: %v, %v", relation, err)
+	}
+
+	if _, err = instance.RelationFor(orphan{}, "Parent"); nil == err {
+		t.Errorf("Basic RelationFor test expected an error for a relation without a foreign key")
+	}
+}
+
+type unjoined struct {
+	Groups []group `rel:"many2many,fk=member_id,ref=group_id"`
+}
+
+func TestRelationManyToMany(t *testing.T) {
+	relation, err := Initialize("db").RelationFor(member{}, "Groups")
+
+	if nil != err || ManyToMany != relation.Kind || "memberships" != relation.JoinTable || "group_id" != relation.References {
+		t.Errorf("Basic RelationFor test returned unexpected relation: %+v, %v", relation, err)
+	}
+
+	if _, err = Initialize("db").DiscoverType(unjoined{}); nil == err {
+		t.Error("Basic RelationFor test expected an error discovering a many2many relation without a join table")
+	}
+}
+
+func TestRelationsFor(t *testing.T) {
+	relations, err := Initialize("db").RelationsFor(member{})
+
+	if nil != err || 1 != len(relations) || "Groups" != relations[0].Field || "memberships" != relations[0].JoinTable {
+		t.Errorf("Basic RelationsFor test returned unexpected relations: %+v, %v", relations, err)
+	}
+
+	if relations, err = Initialize("db").RelationsFor(shipment{}); nil != err || 0 != len(relations) {
+		t.Errorf("Basic RelationsFor test expected no relations, received %+v, %v", relations, err)
+	}
+}
+
+func TestRelationGraph(t *testing.T) {
+	graph, err := Initialize("db").RelationGraph(author{})
+
+	if nil != err {
+		t.Fatalf("Basic RelationGraph test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(graph) || 1 != len(graph[reflect.TypeOf(author{})]) || 1 != len(graph[reflect.TypeOf(book{})]) {
+		t.Errorf("Basic RelationGraph test returned unexpected graph: %v", graph)
+	}
+
+	if _, err = Initialize("db").RelationGraph(orphan{}); nil == err {
+		t.Error("Basic RelationGraph test expected an error for a type with an invalid relation")
+	}
+}
@@ -0,0 +1,344 @@
+package cartographer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Repository performs the common CRUD operations against the table of
+// struct type `T`, driven by the Cartographer's cached mappings, `pk`
+// tag options and SQL generators. Rows of variants of `T` registered with
+// RegisterVariant are found as the `T` they embed, holding only the
+// columns of `T`; Map reads the variants themselves.
+type Repository[T any] struct {
+	mapper  *Cartographer   // Cartographer mapping `T`.
+	db      Querier         // Database queried, such as a *sql.DB or *sql.Tx.
+	upsert  bool            // Does Save upsert rather than insert or update?
+	preload []string        // Relation paths preloaded for every `T` found, see Preload.
+	ctx     context.Context // Context statements are run with, see WithContext.
+}
+
+// RepositoryFor returns a pointer to a Repository of struct type `T`
+// stored through Cartographer `c` in Querier `db`.
+func RepositoryFor[T any](c *Cartographer, db Querier) *Repository[T] {
+	return &Repository[T]{mapper: c, db: db, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the Repository running its statements with
+// context `ctx`, such as that of the request being served, so that they
+// are canceled alongside it.
+func (self *Repository[T]) WithContext(ctx context.Context) *Repository[T] {
+	repository := *self
+	repository.ctx = ctx
+
+	return &repository
+}
+
+// Find returns a pointer to the `T` identified by primary key values
+// `keys`, given in field declaration order, or sql.ErrNoRows if there
+// is no such row.
+func (self *Repository[T]) Find(keys ...interface{}) (item *T, err error) {
+	item = new(T)
+
+	if err = self.mapper.FindByPKContext(self.ctx, self.db, item, keys...); nil != err {
+		return nil, err
+	}
+
+	if err = self.mapper.PreloadContext(self.ctx, self.db, []*T{item}, self.preload...); nil != err {
+		return nil, err
+	}
+
+	return
+}
+
+// FindAll returns pointers to every `T` stored in the table.
+func (self *Repository[T]) FindAll() (items []*T, err error) {
+	query, err := self.mapper.SelectSQL(new(T))
+
+	if nil != err {
+		return
+	}
+
+	results, err := self.mapper.QueryContext(self.ctx, self.db, query, nil, new(T))
+
+	if nil != err {
+		return
+	}
+
+	return self.collect(results)
+}
+
+// Insert inserts `item`. Should the database generate its primary key,
+// see InsertSQL, the key is read through a RETURNING clause should the
+// Cartographer bind Dollar placeholders, else from the result's
+// LastInsertId, and set on `item`; an error is returned should the driver
+// be unable to report it.
+func (self *Repository[T]) Insert(item *T) (err error) {
+	query, args, err := self.mapper.InsertSQL(item)
+
+	if nil != err {
+		return
+	}
+
+	return self.insert(item, query, args)
+}
+
+// Save inserts `item` should its primary keys all be zero, else updates
+// it, setting a generated primary key as Insert does. Should upserts be
+// enabled with SetUpsert, `item` is instead always upserted, see UpsertSQL.
+func (self *Repository[T]) Save(item *T) (err error) {
+	if self.upsert {
+		return self.Upsert(item)
+	}
+
+	keys, err := self.mapper.PrimaryKeyValuesFor(item)
+
+	if nil != err {
+		return
+	}
+
+	for _, key := range keys {
+		if !isZero(key) {
+			return self.Update(item)
+		}
+	}
+
+	return self.Insert(item)
+}
+
+// Upsert inserts `item`, or updates the row sharing its primary keys
+// should one exist, see UpsertSQL, setting a generated primary key as
+// Insert does.
+func (self *Repository[T]) Upsert(item *T) (err error) {
+	query, args, err := self.mapper.UpsertSQL(item)
+
+	if nil != err {
+		return
+	}
+
+	return self.insert(item, query, args)
+}
+
+// SetUpsert enables or disables Save upserting rather than choosing
+// between inserting and updating.
+func (self *Repository[T]) SetUpsert(enabled bool) {
+	self.upsert = enabled
+}
+
+// Update updates the row identified by `item`'s primary keys, returning
+// sql.ErrNoRows should no row be affected. Should `T` declare a column
+// with the `version` option, see UpdateSQL, an *ErrStaleRow is returned
+// instead, and the version of `item` is incremented upon success.
+func (self *Repository[T]) Update(item *T) (err error) {
+	query, args, err := self.mapper.UpdateSQL(item)
+
+	if nil != err {
+		return
+	}
+
+	err = affectingRows(self.db.ExecContext(self.ctx, query, args...))
+
+	return self.mapper.checkVersion(item, err)
+}
+
+// Delete deletes the row identified by `item`'s primary keys, returning
+// sql.ErrNoRows should no row be affected.
+func (self *Repository[T]) Delete(item *T) (err error) {
+	query, args, err := self.mapper.DeleteSQL(item)
+
+	if nil != err {
+		return
+	}
+
+	return affectingRows(self.db.ExecContext(self.ctx, query, args...))
+}
+
+// base returns a pointer to a new `T` holding the columns of `T` held by
+// `result`, a pointer to a variant of `T` registered with RegisterVariant.
+func (self *Repository[T]) base(result interface{}) (item *T, err error) {
+	item = new(T)
+
+	if err = self.mapper.assignBase(reflect.ValueOf(item).Elem(), result); nil != err {
+		return nil, err
+	}
+
+	return
+}
+
+// affectingRows returns `err`, or sql.ErrNoRows should `result` report
+// that no rows were affected.
+func affectingRows(result sql.Result, err error) error {
+	if nil != err {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+
+	if nil != err {
+		return err
+	}
+
+	if 0 == affected {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// insert runs INSERT statement `query` of `item` with `args`, setting the
+// primary key of `item` the database generates, if any, as Insert does.
+func (self *Repository[T]) insert(item *T, query string, args []interface{}) (err error) {
+	mapped, err := self.mapper.generatedKey(item)
+
+	if nil != err {
+		return
+	} else if nil == mapped {
+		_, err = self.db.ExecContext(self.ctx, query, args...)
+		return
+	}
+
+	var id interface{}
+
+	if "$1" == self.mapper.bind(1) {
+		if id, err = self.returning(query+" RETURNING "+mapped.column, args); nil != err {
+			return
+		}
+	} else {
+		result, err := self.db.ExecContext(self.ctx, query, args...)
+
+		if nil != err {
+			return err
+		}
+
+		if id, err = result.LastInsertId(); nil != err {
+			return errors.New(fmt.Sprintf("Generated key %s of %T could not be read: %v", mapped.column, item, err))
+		}
+	}
+
+	return protect(func() error {
+		return setFieldValue(fieldByIndex(reflect.ValueOf(item).Elem(), mapped.index, true), id)
+	}, "setting generated key %s", mapped.name)
+}
+
+// returning runs INSERT statement `query` with `args`, returning the value
+// of the single column of the row its RETURNING clause returns.
+func (self *Repository[T]) returning(query string, args []interface{}) (id interface{}, err error) {
+	rows, err := self.db.QueryContext(self.ctx, query, args...)
+
+	if nil != err {
+		return
+	}
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err = rows.Err(); nil == err {
+			err = sql.ErrNoRows
+		}
+
+		return
+	}
+
+	if err = rows.Scan(&id); nil != err {
+		return
+	}
+
+	return id, rows.Err()
+}
+
+// FindPage returns pointers to the `T` stored on page `page` of the table,
+// along with the Pagination of the following page, nil should there be
+// none. Pages by Page are ordered by the table's primary keys.
+func (self *Repository[T]) FindPage(page Pagination) (items []*T, next Pagination, err error) {
+	query, err := self.mapper.SelectSQL(new(T))
+
+	if nil != err {
+		return
+	}
+
+	if _, ok := page.(Page); ok {
+		var keys []string
+
+		if keys, err = self.mapper.PrimaryKeysFor(new(T)); nil != err {
+			return
+		}
+
+		query += " ORDER BY " + strings.Join(keys, ", ")
+	}
+
+	paged, err := self.mapper.QueryPageContext(self.ctx, self.db, query, nil, new(T), page)
+
+	if nil != err {
+		return
+	}
+
+	next = paged.Next
+	items, err = self.collect(paged.Items)
+
+	return
+}
+
+// FindWhere returns pointers to the `T` stored in the table matching
+// Condition `where`, every `T` should it be nil.
+func (self *Repository[T]) FindWhere(where *Condition) (items []*T, err error) {
+	query, err := self.mapper.SelectSQL(new(T))
+
+	if nil != err {
+		return
+	}
+
+	condition, args, err := self.mapper.WhereSQL(new(T), where, nil)
+
+	if nil != err {
+		return
+	}
+
+	if 0 != len(condition) {
+		query += " WHERE " + condition
+	}
+
+	results, err := self.mapper.QueryContext(self.ctx, self.db, query, args, new(T))
+
+	if nil != err {
+		return
+	}
+
+	return self.collect(results)
+}
+
+// Preload returns a copy of the Repository preloading the relations named
+// by `paths`, as Cartographer's Preload does, for every `T` found by Find,
+// FindAll, FindPage and FindWhere.
+func (self *Repository[T]) Preload(paths ...string) *Repository[T] {
+	repository := *self
+	repository.preload = append(append([]string(nil), self.preload...), paths...)
+
+	return &repository
+}
+
+// collect asserts each of the `results` of Map to be a pointer to `T`,
+// copying the `T` of those hydrated into a variant of `T` registered with
+// RegisterVariant, then preloads the relations of the Repository.
+func (self *Repository[T]) collect(results []interface{}) (items []*T, err error) {
+	for _, result := range results {
+		item, ok := result.(*T)
+
+		if !ok {
+			if item, err = self.base(result); nil != err {
+				return nil, err
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	if err = self.mapper.PreloadContext(self.ctx, self.db, items, self.preload...); nil != err {
+		return nil, err
+	}
+
+	return
+}
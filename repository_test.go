@@ -0,0 +1,166 @@
+package cartographer
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestRepositoryFind(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, customer, total FROM invoice WHERE id = ?": {
+			columns: []string{"id", "customer", "total"},
+			rows:    [][]driver.Value{{int64(1), "ada", int64(10)}},
+		},
+	})
+
+	item, err := RepositoryFor[invoice](Initialize("db"), db).Find(1)
+
+	if nil != err {
+		t.Fatalf("Basic Repository Find test returned an unexpected error: %v", err)
+	}
+
+	if 1 != item.Id || "ada" != item.Customer || 10 != item.Total {
+		t.Errorf("Basic Repository Find test returned unexpected invoice: %+v", item)
+	}
+
+	if _, err = RepositoryFor[invoice](Initialize("db"), db).Find(1, 2); nil == err {
+		t.Error("Basic Repository Find test expected an error given too many primary key values")
+	}
+}
+
+func TestRepositoryFindMissing(t *testing.T) {
+	db := openFake(map[string]fakeResult{})
+
+	if _, err := RepositoryFor[invoice](Initialize("db"), db).Find(1); sql.ErrNoRows != err {
+		t.Errorf("Basic Repository Find test expected sql.ErrNoRows, received %v", err)
+	}
+}
+
+func TestRepositoryFindAll(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, customer, total FROM invoice": {
+			columns: []string{"id", "customer", "total"},
+			rows:    [][]driver.Value{{int64(1), "ada", int64(10)}, {int64(2), "bob", int64(20)}},
+		},
+	})
+
+	items, err := RepositoryFor[invoice](Initialize("db"), db).FindAll()
+
+	if nil != err {
+		t.Fatalf("Basic Repository FindAll test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(items) || "bob" != items[1].Customer {
+		t.Errorf("Basic Repository FindAll test returned unexpected invoices: %v", items)
+	}
+}
+
+func TestRepositoryInsert(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"INSERT INTO invoice (customer, total) VALUES (?, ?)": {affected: 1, insertId: 7},
+	})
+
+	item := &invoice{Customer: "ada", Total: 10}
+
+	if err := RepositoryFor[invoice](Initialize("db"), db).Insert(item); nil != err {
+		t.Fatalf("Basic Repository Insert test returned an unexpected error: %v", err)
+	}
+
+	if 7 != item.Id {
+		t.Errorf("Basic Repository Insert test expected generated key 7, received %d", item.Id)
+	}
+
+	if 1 != len(fakeStatements) || 2 != len(fakeStatements[0].args) {
+		t.Errorf("Basic Repository Insert test returned unexpected statements: %v", fakeStatements)
+	}
+}
+
+func TestRepositoryUpdateAndDelete(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"UPDATE invoice SET customer = ?, total = ? WHERE id = ?": {affected: 1},
+	})
+
+	repository := RepositoryFor[invoice](Initialize("db"), db)
+
+	if err := repository.Update(&invoice{1, "ada", 10}); nil != err {
+		t.Errorf("Basic Repository Update test returned an unexpected error: %v", err)
+	}
+
+	if err := repository.Delete(&invoice{Id: 1}); sql.ErrNoRows != err {
+		t.Errorf("Basic Repository Update test expected sql.ErrNoRows deleting a missing row, received %v", err)
+	}
+}
+
+func TestRepositorySave(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"INSERT INTO invoice (customer, total) VALUES (?, ?)":     {affected: 1, insertId: 9},
+		"UPDATE invoice SET customer = ?, total = ? WHERE id = ?": {affected: 1},
+	})
+
+	repository := RepositoryFor[invoice](Initialize("db"), db)
+	item := &invoice{Customer: "ada"}
+
+	if err := repository.Save(item); nil != err {
+		t.Fatalf("Basic Repository Save test returned an unexpected error: %v", err)
+	}
+
+	if 9 != item.Id {
+		t.Errorf("Basic Repository Save test expected generated key 9, received %d", item.Id)
+	}
+
+	if err := repository.Save(item); nil != err {
+		t.Fatalf("Basic Repository Save test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(fakeStatements) || "UPDATE invoice SET customer = ?, total = ? WHERE id = ?" != fakeStatements[1].query {
+		t.Errorf("Basic Repository Save test returned unexpected statements: %v", fakeStatements)
+	}
+}
+
+func TestRepositorySaveReturning(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"INSERT INTO invoice (customer, total) VALUES ($1, $2) RETURNING id": {columns: []string{"id"}, rows: [][]driver.Value{{int64(11)}}},
+		"UPDATE invoice SET customer = $1, total = $2 WHERE id = $3":         {affected: 1},
+	})
+
+	repository := RepositoryFor[invoice](New(WithPlaceholder(Dollar)), db)
+	item := &invoice{Customer: "ada"}
+
+	if err := repository.Save(item); nil != err {
+		t.Fatalf("Basic Repository Save test returned an unexpected error: %v", err)
+	}
+
+	if 11 != item.Id {
+		t.Errorf("Basic Repository Save test expected generated key 11, received %d", item.Id)
+	}
+
+	if err := repository.Save(item); nil != err || 2 != len(fakeStatements) || "UPDATE invoice SET customer = $1, total = $2 WHERE id = $3" != fakeStatements[1].query {
+		t.Errorf("Basic Repository Save test returned unexpected statements: %v, %v", fakeStatements, err)
+	}
+}
+
+func TestRepositoryInsertWithoutGeneratedKey(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"INSERT INTO invoice (customer, total) VALUES (?, ?)": {affected: 1},
+	})
+
+	if err := RepositoryFor[invoice](Initialize("db"), db).Insert(&invoice{Customer: "ada"}); nil == err {
+		t.Error("Basic Repository Insert test expected an error given a driver unable to report the generated key")
+	}
+}
+
+func TestRepositorySaveUpsert(t *testing.T) {
+	const query = "INSERT INTO invoice (id, customer, total) VALUES (?, ?, ?) ON CONFLICT (id) DO UPDATE SET customer = EXCLUDED.customer, total = EXCLUDED.total"
+
+	repository := RepositoryFor[invoice](Initialize("db"), openFake(map[string]fakeResult{query: {affected: 1}}))
+	repository.SetUpsert(true)
+
+	if err := repository.Save(&invoice{3, "ada", 10}); nil != err {
+		t.Fatalf("Basic Repository Save test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(fakeStatements) || query != fakeStatements[0].query {
+		t.Errorf("Basic Repository Save test returned unexpected statements: %v", fakeStatements)
+	}
+}
@@ -0,0 +1,114 @@
+package cartographer
+
+import (
+	"reflect"
+	"strings"
+)
+
+// sanitizers are the sanitizers string fields may opt in to through the
+// options of their tag, e.g. `db:"email,trim,lower"`:
+//
+//	trim     Removes leading and trailing white space.
+//	squash   Collapses runs of white space into a single space, trimming the ends.
+//	lower    Converts the string to lower case.
+//	upper    Converts the string to upper case.
+//
+// Sanitizers run in the order the options are written, against values
+// scanned by Map and Sync before they are set, and against fields written
+// by ColumnValueMapFor, InsertSQL, UpdateSQL and UpsertSQL before they are
+// validated.
+var sanitizers = map[string]func(string) string{
+	"trim":   strings.TrimSpace,
+	"squash": func(text string) string { return strings.Join(strings.Fields(text), " ") },
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+}
+
+// sanitizes reports whether field `mapped` opts in to any sanitizer.
+func sanitizes(mapped *mappedField) bool {
+	if reflect.String != indirectType(mapped.typ).Kind() {
+		return false
+	}
+
+	for _, option := range mapped.options {
+		if _, ok := sanitizers[option.Name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sanitizeString runs the sanitizers field `mapped` opts in to against `text`.
+func sanitizeString(mapped *mappedField, text string) string {
+	for _, option := range mapped.options {
+		if sanitizer, ok := sanitizers[option.Name]; ok {
+			text = sanitizer(text)
+		}
+	}
+
+	return text
+}
+
+// sanitizeValue runs the sanitizers field `mapped` opts in to against
+// the textual `value` scanned for it, returning any other value as is.
+func sanitizeValue(mapped *mappedField, value interface{}) interface{} {
+	if !sanitizes(mapped) {
+		return value
+	}
+
+	switch value.(type) {
+	case []uint8, string:
+		return sanitizeString(mapped, parseString(value))
+	default:
+		return value
+	}
+}
+
+// sanitize runs the sanitizers of parameter `o`'s fields against them,
+// setting the fields in place should `o` be a pointer, else returning a
+// pointer to a sanitized copy of `o`. Parameter `o` is returned as is
+// should none of its fields opt in to a sanitizer.
+func (self *Cartographer) sanitize(o interface{}) (interface{}, error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return nil, err
+	}
+
+	var item reflect.Value
+
+	for _, mapped := range self.fields[typ] {
+		if !sanitizes(mapped) {
+			continue
+		}
+
+		if !item.IsValid() {
+			if item = reflect.ValueOf(o); reflect.Ptr != item.Kind() {
+				item = reflect.New(typ)
+				item.Elem().Set(reflect.ValueOf(o))
+			}
+		}
+
+		field := fieldByIndex(item.Elem(), mapped.index, false)
+
+		if !field.IsValid() || !field.CanSet() || (reflect.Ptr == field.Kind() && field.IsNil()) {
+			continue
+		}
+
+		if reflect.Ptr == field.Kind() {
+			text := reflect.New(field.Type().Elem()) // Replaces the pointer, as copies share it.
+			text.Elem().SetString(sanitizeString(mapped, field.Elem().String()))
+			field.Set(text)
+			continue
+		}
+
+		field.SetString(sanitizeString(mapped, field.String()))
+	}
+
+	if !item.IsValid() {
+		return o, nil
+	}
+
+	return item.Interface(), nil
+}
@@ -0,0 +1,51 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type reader struct {
+	Id    int     `db:"id,pk"`
+	Email string  `db:"email,trim,lower" validate:"email"`
+	Name  *string `db:"name,squash"`
+	Code  string  `db:"code,upper"`
+}
+
+func TestSanitizeOnScan(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"email", "name", "code"},
+		values:  [][]interface{}{{" Ada@Example.COM ", []byte("  Ada   Lovelace "), "ab"}},
+	}
+
+	results, err := New(WithValidation(true)).Map(rows, reader{})
+
+	if nil != err {
+		t.Fatalf("Basic sanitizer test returned an unexpected error: %v", err)
+	}
+
+	item := results[0].(*reader)
+
+	if "ada@example.com" != item.Email || "Ada Lovelace" != *item.Name || "AB" != item.Code {
+		t.Errorf("Basic sanitizer test returned unexpected sanitized values: %q, %q, %q", item.Email, *item.Name, item.Code)
+	}
+}
+
+func TestSanitizeOnWrite(t *testing.T) {
+	c := New(WithValidation(true))
+	name := " Ada  Lovelace"
+	item := reader{Email: " ADA@example.com", Name: &name}
+
+	values, err := c.ColumnValueMapFor(item)
+
+	if nil != err || "ada@example.com" != values["email"] || "Ada Lovelace" != *values["name"].(*string) {
+		t.Fatalf("Basic sanitizer test returned unexpected values: %v, %v", values, err)
+	}
+
+	if " ADA@example.com" != item.Email || " Ada  Lovelace" != name {
+		t.Errorf("Basic sanitizer test expected a struct passed by value to be left untouched, found %q, %q", item.Email, name)
+	}
+
+	if _, _, err = c.InsertSQL(&item); nil != err || "ada@example.com" != item.Email {
+		t.Errorf("Basic sanitizer test expected a struct passed by pointer to be sanitized, found %q, %v", item.Email, err)
+	}
+}
@@ -0,0 +1,113 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Snapshot records the values of a struct's mapped fields at a point in
+// time, so that changes made to the struct since may later be detected.
+type Snapshot struct {
+	cartographer *Cartographer               // Cartographer the snapshot was taken with.
+	typ          reflect.Type                // Type of the struct snapshotted.
+	values       map[interface{}]interface{} // Map of fields to their values at the time of the snapshot.
+}
+
+// Snapshot records the current values of parameter `o`'s mapped fields,
+// or returns an error if `o` is not a struct. Values are deep copied, so
+// that in place modifications of slice and map fields are detected.
+func (self *Cartographer) Snapshot(o interface{}) (snapshot *Snapshot, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	values, err := self.fieldValues(typ, reflect.Indirect(reflect.ValueOf(o)), false)
+
+	if nil != err {
+		return
+	}
+
+	for key, value := range values {
+		if nil != value {
+			values[key] = deepCopy(reflect.ValueOf(value)).Interface()
+		}
+	}
+
+	snapshot = &Snapshot{self, typ, values}
+
+	return
+}
+
+// Values returns the map of fields to their values recorded by the snapshot,
+// as accepted by ModifiedColumnsValuesMapFor.
+func (self *Snapshot) Values() map[interface{}]interface{} {
+	return self.values
+}
+
+// Changed returns a map of the columns of parameter `o`'s fields modified
+// since the snapshot was taken to their current values, or an error if
+// `o` is not of the type snapshotted.
+func (self *Snapshot) Changed(o interface{}) (values map[interface{}]interface{}, err error) {
+	if err = self.check(o); nil != err {
+		return
+	}
+
+	return self.cartographer.ModifiedColumnsValuesMapFor(self.values, o)
+}
+
+// ChangedColumns returns the columns of parameter `o`'s fields modified
+// since the snapshot was taken, in field declaration order, or an error
+// if `o` is not of the type snapshotted.
+func (self *Snapshot) ChangedColumns(o interface{}) (columns []string, err error) {
+	values, err := self.Changed(o)
+
+	if nil != err {
+		return
+	}
+
+	for _, mapped := range self.cartographer.fields[self.typ] {
+		if _, ok := values[mapped.column]; ok {
+			columns = append(columns, mapped.column)
+		}
+	}
+
+	return
+}
+
+// Diff returns the changes made to parameter `o`'s fields since the
+// snapshot was taken, in field declaration order, or an error if `o`
+// is not of the type snapshotted.
+func (self *Snapshot) Diff(o interface{}) (changes []Change, err error) {
+	if err = self.check(o); nil != err {
+		return
+	}
+
+	return self.cartographer.diff(self.typ, self.values, o)
+}
+
+// IsDirty returns true if any of parameter `o`'s fields have been modified
+// since the snapshot was taken, or an error if `o` is not of the type
+// snapshotted.
+func (self *Snapshot) IsDirty(o interface{}) (bool, error) {
+	values, err := self.Changed(o)
+
+	return 0 != len(values), err
+}
+
+// check returns an error if `o` is not of the type snapshotted.
+func (self *Snapshot) check(o interface{}) error {
+	typ, err := self.cartographer.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	if self.typ != typ {
+		return errors.New(fmt.Sprintf("Snapshot of %v can not be compared with %v", self.typ, typ))
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	var item = indexed{Id: 1, Email: "before@example.com"}
+
+	snapshot, err := instance.Snapshot(&item)
+
+	if nil != err {
+		t.Fatalf("Basic Snapshot test returned an unexpected error: %v", err)
+	}
+
+	if dirty, err := snapshot.IsDirty(&item); nil != err || dirty {
+		t.Errorf("Basic Snapshot test reported an unmodified struct as dirty: %v, %v", dirty, err)
+	}
+
+	item.Email = "after@example.com"
+	item.LastName = "Doe"
+
+	columns, err := snapshot.ChangedColumns(&item)
+
+	if nil != err || 2 != len(columns) || "email" != columns[0] || "last_name" != columns[1] {
+		t.Errorf("Basic Snapshot test returned unexpected changed columns: %v, %v", columns, err)
+	}
+
+	if _, err = snapshot.Changed(faker{}); nil == err {
+		t.Errorf("Basic Snapshot test expected an error comparing a different type")
+	}
+}
+
+type tagged struct {
+	Id   int               `db:"id"`
+	Tags []string          `db:"tags"`
+	Meta map[string]string `db:"meta"`
+}
+
+func TestSnapshotInPlaceModification(t *testing.T) {
+	var item = tagged{1, []string{"a"}, map[string]string{"k": "v"}}
+
+	snapshot, err := instance.Snapshot(&item)
+
+	if nil != err {
+		t.Fatalf("In place Snapshot test returned an unexpected error: %v", err)
+	}
+
+	if dirty, err := snapshot.IsDirty(&item); nil != err || dirty {
+		t.Errorf("In place Snapshot test reported an unmodified struct as dirty: %v, %v", dirty, err)
+	}
+
+	item.Tags[0] = "b"
+	item.Meta["k"] = "w"
+
+	columns, err := snapshot.ChangedColumns(&item)
+
+	if nil != err || 2 != len(columns) || "tags" != columns[0] || "meta" != columns[1] {
+		t.Errorf("In place Snapshot test returned unexpected changed columns: %v, %v", columns, err)
+	}
+}
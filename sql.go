@@ -0,0 +1,354 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Placeholder returns the placeholder of the `n`th parameter, counting
+// from one, of SQL generated by the Cartographer.
+type Placeholder func(n int) string
+
+// Question is the Placeholder style of MySQL and SQLite, `?`.
+func Question(n int) string {
+	return "?"
+}
+
+// Dollar is the Placeholder style of PostgreSQL, `$1`, `$2` and so on.
+func Dollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// SetPlaceholder sets the Placeholder style of generated SQL, Question by default.
+func (self *Cartographer) SetPlaceholder(placeholder Placeholder) {
+	self.placeholder = placeholder
+}
+
+// bind returns the placeholder of the `n`th parameter.
+func (self *Cartographer) bind(n int) string {
+	if nil == self.placeholder {
+		return Question(n)
+	}
+
+	return self.placeholder(n)
+}
+
+// SelectSQL returns a SELECT statement of every column mapped by parameter
+// `o`'s type, in field declaration order, from its table, or an error if
+// `o` is not a struct. Should variants of the type be registered with
+// RegisterVariant, their discriminator and columns are selected too.
+func (self *Cartographer) SelectSQL(o interface{}) (query string, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	table, err := self.TableFor(o)
+
+	if nil != err {
+		return
+	}
+
+	var columns []string
+
+	for _, mapped := range self.fields[typ] {
+		columns = append(columns, mapped.column)
+	}
+
+	columns = append(columns, self.inheritedColumns(typ)...)
+
+	query = fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), table)
+
+	return
+}
+
+// InsertSQL returns an INSERT statement of parameter `o` into its table,
+// along with its arguments taken from ColumnValueMapFor, or an error if `o`
+// is not a struct. Should the type declare a single primary key, the key's
+// column is left for the database to generate while the key is zero.
+// Columns with the `autocreate` or `autoupdate` option are stamped with
+// the current time, see SetClock. Should validation be enabled, `o` is
+// validated with the rules of InsertGroup, see SetValidation.
+func (self *Cartographer) InsertSQL(o interface{}) (query string, args []interface{}, err error) {
+	defer self.hold()()
+
+	return self.insertSQL(o, InsertGroup)
+}
+
+// insertSQL behaves as InsertSQL, validating `o` with the rules of group `group`.
+func (self *Cartographer) insertSQL(o interface{}, group string) (query string, args []interface{}, err error) {
+	typ, table, values, err := self.statementValues(o, group, "autocreate", "autoupdate")
+
+	if nil != err {
+		return
+	}
+
+	var columns, placeholders []string
+
+	for _, mapped := range self.fields[typ] {
+		value, ok := values[mapped.column]
+
+		if !ok || self.generated(typ, mapped, value) {
+			continue
+		}
+
+		args = append(args, value)
+		columns = append(columns, mapped.column)
+		placeholders = append(placeholders, self.bind(len(args)))
+	}
+
+	if variant, ok := self.variants[typ]; ok {
+		if column := self.inheritance[variant.base].column; nil == self.byColumn[typ][column] {
+			args = append(args, values[column])
+			columns = append(columns, column)
+			placeholders = append(placeholders, self.bind(len(args)))
+		}
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+
+	return
+}
+
+// UpsertSQL behaves as InsertSQL, adding an ON CONFLICT clause, as
+// understood by PostgreSQL and SQLite, updating the inserted columns
+// other than the primary keys and `autocreate` columns should a row
+// with the same primary keys already exist.
+func (self *Cartographer) UpsertSQL(o interface{}) (query string, args []interface{}, err error) {
+	defer self.hold()()
+
+	if query, args, err = self.insertSQL(o, ""); nil != err {
+		return
+	}
+
+	keys, err := self.PrimaryKeysFor(o)
+
+	if nil != err {
+		return
+	}
+
+	typ, _ := self.DiscoverType(o)
+	values, err := self.columnValues(o, false)
+
+	if nil != err {
+		return
+	}
+
+	var assignments []string
+
+	for _, mapped := range self.fields[typ] {
+		if _, ok := values[mapped.column]; !ok || mapped.options.Has("pk") || mapped.options.Has("autocreate") {
+			continue
+		}
+
+		assignments = append(assignments, mapped.column+" = EXCLUDED."+mapped.column)
+	}
+
+	if 0 == len(assignments) {
+		query = fmt.Sprintf("%s ON CONFLICT (%s) DO NOTHING", query, strings.Join(keys, ", "))
+	} else {
+		query = fmt.Sprintf("%s ON CONFLICT (%s) DO UPDATE SET %s", query, strings.Join(keys, ", "), strings.Join(assignments, ", "))
+	}
+
+	return
+}
+
+// UpdateSQL returns an UPDATE statement setting the columns of parameter
+// `o` other than its primary keys, restricted to the row its primary keys
+// identify, along with its arguments, or an error if `o` is not a struct,
+// declares no primary key or no column besides its primary keys. Should `o` declare a column with the
+// `version` option, the statement increments the column rather than
+// setting it, and is further restricted to the row whose version equals
+// `o`'s, so that an update made since `o` was read affects no rows.
+// Columns with the `autoupdate` option are stamped as InsertSQL stamps them.
+func (self *Cartographer) UpdateSQL(o interface{}) (query string, args []interface{}, err error) {
+	defer self.hold()()
+
+	typ, table, values, err := self.statementValues(o, UpdateGroup, "autoupdate")
+
+	if nil != err {
+		return
+	}
+
+	var assignments []string
+
+	for _, mapped := range self.fields[typ] {
+		value, ok := values[mapped.column]
+
+		if mapped.options.Has("version") {
+			assignments = append(assignments, mapped.column+" = "+mapped.column+" + 1")
+			continue
+		}
+
+		if !ok || mapped.options.Has("pk") {
+			continue
+		}
+
+		args = append(args, value)
+		assignments = append(assignments, mapped.column+" = "+self.bind(len(args)))
+	}
+
+	if 0 == len(assignments) {
+		return "", nil, errors.New(fmt.Sprintf("No columns to update for %v besides its primary keys", typ))
+	}
+
+	where, args, err := self.wherePrimaryKeys(typ, values, args)
+
+	if nil != err {
+		return
+	}
+
+	if version := self.versionField(typ); nil != version {
+		args = append(args, values[version.column])
+		where += " AND " + version.column + " = " + self.bind(len(args))
+	}
+
+	query = fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, strings.Join(assignments, ", "), where)
+
+	return
+}
+
+// versionField returns the first field of type `typ` with the
+// `version` option, or nil should there be none.
+func (self *Cartographer) versionField(typ reflect.Type) *mappedField {
+	for _, mapped := range self.fields[typ] {
+		if mapped.options.Has("version") {
+			return mapped
+		}
+	}
+
+	return nil
+}
+
+// DeleteSQL returns a DELETE statement of the row parameter `o`'s primary
+// keys identify, along with its arguments, or an error if `o` is not a
+// struct or declares no primary key.
+func (self *Cartographer) DeleteSQL(o interface{}) (query string, args []interface{}, err error) {
+	defer self.hold()()
+
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	table, err := self.TableFor(o)
+
+	if nil != err {
+		return
+	}
+
+	values, err := self.columnValues(o, false)
+
+	if nil != err {
+		return
+	}
+
+	where, args, err := self.wherePrimaryKeys(typ, values, nil)
+
+	if nil != err {
+		return
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE %s", table, where)
+
+	return
+}
+
+// statementValues returns the type, table and converted column values of
+// parameter `o`, stamping the columns with any of the `stamped` options
+// before validating it with the rules of group `group`, so that rules such
+// as `required` see the stamped times.
+func (self *Cartographer) statementValues(o interface{}, group string, stamped ...string) (typ reflect.Type, table string, values map[interface{}]interface{}, err error) {
+	if typ, err = self.DiscoverType(o); nil != err {
+		return
+	}
+
+	if table, err = self.TableFor(o); nil != err {
+		return
+	}
+
+	if item := reflect.ValueOf(o); 0 != len(stamped) && reflect.Ptr != item.Kind() {
+		copied := reflect.New(item.Type()) // Stamped in place of `o`, whose fields can not be set.
+		copied.Elem().Set(item)
+		o = copied.Interface()
+	}
+
+	if err = self.stamp(o, stamped); nil != err {
+		return
+	}
+
+	values, err = self.writeValues(o, group)
+
+	return
+}
+
+// wherePrimaryKeys returns a condition matching the primary keys of type
+// `typ` to their `values`, along with `args` followed by the values.
+func (self *Cartographer) wherePrimaryKeys(typ reflect.Type, values map[interface{}]interface{}, args []interface{}) (where string, bound []interface{}, err error) {
+	var conditions []string
+
+	bound = args
+
+	for _, mapped := range self.fields[typ] {
+		if !mapped.options.Has("pk") {
+			continue
+		}
+
+		bound = append(bound, values[mapped.column])
+		conditions = append(conditions, mapped.column+" = "+self.bind(len(bound)))
+	}
+
+	if 0 == len(conditions) {
+		err = errors.New(fmt.Sprintf("No primary key for %v", typ))
+		return
+	}
+
+	where = strings.Join(conditions, " AND ")
+
+	return
+}
+
+// generated returns true if field `mapped` of type `typ` is the type's sole
+// primary key and its converted `value` is zero, leaving the database to
+// generatedKey returns the primary key of the struct pointed to by `o` the
+// database generates on insert, nil should it declare several or the key
+// be set.
+func (self *Cartographer) generatedKey(o interface{}) (key *mappedField, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	item := reflect.ValueOf(o).Elem()
+
+	for _, mapped := range self.fields[typ] {
+		if self.generated(typ, mapped, fieldValue(item, mapped)) {
+			return mapped, nil
+		}
+	}
+
+	return
+}
+
+// generate it.
+func (self *Cartographer) generated(typ reflect.Type, mapped *mappedField, value interface{}) bool {
+	if !mapped.options.Has("pk") || !isZero(value) {
+		return false
+	}
+
+	var keys int
+
+	for _, field := range self.fields[typ] {
+		if field.options.Has("pk") {
+			keys++
+		}
+	}
+
+	return 1 == keys
+}
@@ -0,0 +1,98 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+)
+
+type invoice struct {
+	Id       int    `db:"id,pk"`
+	Customer string `db:"customer"`
+	Total    int    `db:"total"`
+}
+
+type lineItem struct {
+	InvoiceId int `db:"invoice_id,pk"`
+	Line      int `db:"line,pk"`
+	Quantity  int `db:"quantity"`
+}
+
+type follow struct {
+	FollowerId int `db:"follower_id,pk"`
+	FolloweeId int `db:"followee_id,pk"`
+}
+
+func TestSelectSQL(t *testing.T) {
+	query, err := Initialize("db").SelectSQL(invoice{})
+
+	if nil != err {
+		t.Fatalf("Basic SelectSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "SELECT id, customer, total FROM invoice"; expected != query {
+		t.Errorf("Basic SelectSQL test expected %q, received %q", expected, query)
+	}
+}
+
+func TestInsertSQL(t *testing.T) {
+	c := Initialize("db")
+	query, args, err := c.InsertSQL(invoice{Customer: "ada", Total: 10})
+
+	if nil != err {
+		t.Fatalf("Basic InsertSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "INSERT INTO invoice (customer, total) VALUES (?, ?)"; expected != query {
+		t.Errorf("Basic InsertSQL test expected %q, received %q", expected, query)
+	}
+
+	if !reflect.DeepEqual([]interface{}{"ada", 10}, args) {
+		t.Errorf("Basic InsertSQL test returned unexpected arguments: %v", args)
+	}
+
+	c.SetPlaceholder(Dollar)
+	query, _, _ = c.InsertSQL(lineItem{InvoiceId: 1})
+
+	if expected := "INSERT INTO line_item (invoice_id, line, quantity) VALUES ($1, $2, $3)"; expected != query {
+		t.Errorf("Basic InsertSQL test expected %q, received %q", expected, query)
+	}
+}
+
+func TestUpdateSQL(t *testing.T) {
+	c := Initialize("db")
+	c.SetPlaceholder(Dollar)
+
+	query, args, err := c.UpdateSQL(lineItem{1, 2, 3})
+
+	if nil != err {
+		t.Fatalf("Basic UpdateSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "UPDATE line_item SET quantity = $1 WHERE invoice_id = $2 AND line = $3"; expected != query {
+		t.Errorf("Basic UpdateSQL test expected %q, received %q", expected, query)
+	}
+
+	if !reflect.DeepEqual([]interface{}{3, 1, 2}, args) {
+		t.Errorf("Basic UpdateSQL test returned unexpected arguments: %v", args)
+	}
+
+	if _, _, err = c.UpdateSQL(faker{}); nil == err {
+		t.Error("Basic UpdateSQL test expected an error updating a type without a primary key")
+	}
+
+	if _, _, err = c.UpdateSQL(follow{1, 2}); nil == err {
+		t.Error("Basic UpdateSQL test expected an error updating a type without columns besides its primary keys")
+	}
+}
+
+func TestDeleteSQL(t *testing.T) {
+	query, args, err := Initialize("db").DeleteSQL(&invoice{Id: 4})
+
+	if nil != err {
+		t.Fatalf("Basic DeleteSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "DELETE FROM invoice WHERE id = ?"; expected != query || 1 != len(args) || 4 != args[0] {
+		t.Errorf("Basic DeleteSQL test returned unexpected statement: %q %v", query, args)
+	}
+}
@@ -0,0 +1,113 @@
+// Package sqlxcompat eases incremental migration between sqlx and
+// cartographer, offering Get and Select shaped functions which map
+// `db` tags as sqlx does, and consuming sqlx.Rows, which satisfy
+// cartographer.ScannableRows through their embedded *sql.Rows.
+package sqlxcompat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// NameMapper maps untagged field names to columns, as sqlx's NameMapper
+// does by default. It is read as each type is first discovered, so that
+// changing it affects the types Mapper has yet to discover.
+var NameMapper = strings.ToLower
+
+// Mapper is the Cartographer used by the package's functions.
+var Mapper = NewMapper()
+
+// Queryer is satisfied by *sql.DB, *sql.Tx, *sqlx.DB and *sqlx.Tx.
+type Queryer = cartographer.Querier
+
+// NewMapper returns a pointer to a new Cartographer mapping fields by
+// their `db` tags, untagged fields by NameMapper, and ignoring fields
+// tagged `db:"-"`, as sqlx does.
+func NewMapper() *cartographer.Cartographer {
+	mapper := cartographer.Initialize("db")
+	mapper.SetNamer(func(name string) string {
+		return NameMapper(name)
+	})
+
+	return mapper
+}
+
+// Get runs `query` with `args` against `q`, mapping the single row returned
+// into the struct pointed to by `dest`, returning sql.ErrNoRows if no rows
+// are returned, as sqlx's Get does.
+func Get(q Queryer, dest interface{}, query string, args ...interface{}) error {
+	return GetContext(context.Background(), q, dest, query, args...)
+}
+
+// GetContext behaves as Get, running `query` with context `ctx`, as sqlx's
+// GetContext does.
+func GetContext(ctx context.Context, q Queryer, dest interface{}, query string, args ...interface{}) error {
+	return Mapper.QueryOneContext(ctx, q, query, args, dest)
+}
+
+// Select runs `query` with `args` against `q`, appending each row returned
+// to the slice of structs, or pointers to structs, pointed to by `dest`, as
+// sqlx's Select does.
+func Select(q Queryer, dest interface{}, query string, args ...interface{}) error {
+	return SelectContext(context.Background(), q, dest, query, args...)
+}
+
+// SelectContext behaves as Select, running `query` with context `ctx`, as
+// sqlx's SelectContext does.
+func SelectContext(ctx context.Context, q Queryer, dest interface{}, query string, args ...interface{}) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+
+	if nil != err {
+		return err
+	}
+
+	defer rows.Close()
+
+	if err = ScanAll(rows, dest); nil != err {
+		return err
+	}
+
+	return rows.Err()
+}
+
+// ScanAll maps each of the `rows`, such as sqlx.Rows, appending them to
+// the slice of structs, or pointers to structs, pointed to by `dest`.
+func ScanAll(rows cartographer.ScannableRows, dest interface{}) error {
+	slice := reflect.ValueOf(dest)
+
+	if reflect.Ptr != slice.Kind() || reflect.Slice != slice.Elem().Kind() {
+		return errors.New(fmt.Sprintf("ScanAll expected a pointer to a slice to be passed, received %T", dest))
+	}
+
+	var (
+		typ     = slice.Elem().Type().Elem()
+		pointer = reflect.Ptr == typ.Kind()
+	)
+
+	if pointer {
+		typ = typ.Elem()
+	}
+
+	results, err := Mapper.Map(rows, reflect.New(typ).Interface())
+
+	if nil != err {
+		return err
+	}
+
+	for _, result := range results {
+		element := reflect.ValueOf(result)
+
+		if !pointer {
+			element = element.Elem()
+		}
+
+		slice.Elem().Set(reflect.Append(slice.Elem(), element))
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package sqlxcompat
+
+import (
+	"strings"
+	"testing"
+)
+
+type rows struct {
+	columns []string
+	values  [][]interface{}
+	index   int
+}
+
+func (self *rows) Next() bool {
+	self.index++
+	return self.index <= len(self.values)
+}
+
+func (self *rows) Columns() ([]string, error) {
+	return self.columns, nil
+}
+
+func (self *rows) Scan(dest ...interface{}) error {
+	for index, _ := range dest {
+		*dest[index].(*interface{}) = self.values[self.index-1][index]
+	}
+
+	return nil
+}
+
+type user struct {
+	Id        int
+	FirstName string `db:"first_name"`
+	Password  string `db:"-"`
+}
+
+func TestScanAll(t *testing.T) {
+	var (
+		users    []user
+		pointers []*user
+		source   = func() *rows {
+			return &rows{columns: []string{"id", "first_name"}, values: [][]interface{}{{int64(1), "Chuck"}, {int64(2), "Ada"}}}
+		}
+	)
+
+	if err := ScanAll(source(), &users); nil != err || 2 != len(users) || 2 != users[1].Id || "Chuck" != users[0].FirstName {
+		t.Errorf("Basic ScanAll test returned an unexpected results: %v, %v", users, err)
+	}
+
+	if err := ScanAll(source(), &pointers); nil != err || 2 != len(pointers) || "Ada" != pointers[1].FirstName {
+		t.Errorf("Pointer ScanAll test returned an unexpected results: %v, %v", pointers, err)
+	}
+
+	if columns, err := Mapper.ColumnsFor(user{}); nil != err || 2 != len(columns) {
+		t.Errorf("Basic NewMapper test mapped unexpected columns: %v, %v", columns, err)
+	}
+}
+
+type upper struct {
+	Nickname string
+}
+
+func TestNameMapper(t *testing.T) {
+	defer func(previous func(string) string) {
+		NameMapper = previous
+	}(NameMapper)
+
+	NameMapper = strings.ToUpper
+
+	var found []upper
+
+	if err := ScanAll(&rows{columns: []string{"NICKNAME"}, values: [][]interface{}{{"ada"}}}, &found); nil != err || 1 != len(found) || "ada" != found[0].Nickname {
+		t.Errorf("Basic NameMapper test returned an unexpected results: %v, %v", found, err)
+	}
+}
@@ -0,0 +1,69 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SetClock sets the clock InsertSQL and UpdateSQL stamp `autocreate` and
+// `autoupdate` columns with, such as a fixed time for tests. A nil clock
+// restores time.Now.
+func (self *Cartographer) SetClock(now func() time.Time) {
+	self.clock = now
+}
+
+// now returns the current time of the Cartographer's clock.
+func (self *Cartographer) now() time.Time {
+	if nil == self.clock {
+		return time.Now()
+	}
+
+	return self.clock()
+}
+
+// stamp sets the fields of the columns of the struct pointed to by `o`
+// with any of the `options` to the current time, or returns an error
+// should such a field not be a time.Time.
+func (self *Cartographer) stamp(o interface{}, options []string) (err error) {
+	if 0 == len(options) {
+		return
+	}
+
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	var (
+		item = reflect.ValueOf(o).Elem()
+		now  = self.now()
+	)
+
+	for _, mapped := range self.fields[typ] {
+		if !stamped(mapped, options) {
+			continue
+		}
+
+		if reflect.TypeOf(now) != mapped.typ {
+			return errors.New(fmt.Sprintf("Expected time.Time field for column %s, found %v", mapped.column, mapped.typ))
+		}
+
+		fieldByIndex(item, mapped.index, true).Set(reflect.ValueOf(now))
+	}
+
+	return
+}
+
+// stamped returns true if field `mapped` has any of the `options`.
+func stamped(mapped *mappedField, options []string) bool {
+	for _, option := range options {
+		if mapped.options.Has(option) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package cartographer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type article struct {
+	Id      int       `db:"id,pk"`
+	Title   string    `db:"title"`
+	Created time.Time `db:"created_at,autocreate"`
+	Updated time.Time `db:"updated_at,autoupdate"`
+}
+
+type memo struct {
+	Id        int       `db:"id,pk"`
+	CreatedAt time.Time `db:"created_at,autocreate" validate:"required"`
+}
+
+func TestInsertSQLStamps(t *testing.T) {
+	var (
+		c   = Initialize("db")
+		now = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		o   = &article{Title: "hello"}
+	)
+
+	c.SetClock(func() time.Time { return now })
+
+	query, args, err := c.InsertSQL(o)
+
+	if nil != err {
+		t.Fatalf("Basic InsertSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "INSERT INTO article (title, created_at, updated_at) VALUES (?, ?, ?)"; expected != query {
+		t.Errorf("Basic InsertSQL test expected %q, received %q", expected, query)
+	}
+
+	if !reflect.DeepEqual([]interface{}{"hello", now, now}, args) {
+		t.Errorf("Basic InsertSQL test returned unexpected arguments: %v", args)
+	}
+
+	if now != o.Created || now != o.Updated {
+		t.Errorf("Basic InsertSQL test expected fields to be stamped, received %+v", o)
+	}
+}
+
+func TestUpdateSQLStamps(t *testing.T) {
+	var (
+		c       = Initialize("db")
+		created = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		now     = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	)
+
+	c.SetClock(func() time.Time { return now })
+
+	_, args, err := c.UpdateSQL(article{1, "hello", created, created})
+
+	if nil != err {
+		t.Fatalf("Basic UpdateSQL test returned an unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual([]interface{}{"hello", created, now, 1}, args) {
+		t.Errorf("Basic UpdateSQL test returned unexpected arguments: %v", args)
+	}
+}
+
+type misstamped struct {
+	Id      int    `db:"id,pk"`
+	Created string `db:"created_at,autocreate"`
+}
+
+func TestStampRequiresTime(t *testing.T) {
+	if _, _, err := Initialize("db").InsertSQL(misstamped{}); nil == err {
+		t.Error("Basic InsertSQL test expected an error stamping a string field")
+	}
+}
+
+func TestStampsBeforeValidating(t *testing.T) {
+	c := Initialize("db")
+	c.SetValidation(true)
+
+	if _, _, err := c.InsertSQL(memo{}); nil != err {
+		t.Errorf("Basic InsertSQL stamping test returned an unexpected error: %v", err)
+	}
+
+	if _, _, err := c.InsertSQL(&memo{}); nil != err {
+		t.Errorf("Basic InsertSQL stamping test returned an unexpected error: %v", err)
+	}
+}
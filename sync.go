@@ -0,0 +1,396 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SyncColumns behaves as Sync, only syncing the `columns` given, so that a
+// RETURNING clause can not clobber fields the application has just set,
+// or returns an error if any of the `columns` is not mapped. Any `opts`
+// override the Cartographer's defaults for this call alone, see CallOption.
+func (self *Cartographer) SyncColumns(rows ScannableRows, o interface{}, columns []string, opts ...CallOption) error {
+	var (
+		call   = newCall(opts)
+		mapper = self.forCall(call)
+	)
+
+	typ, err := mapper.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	var only = make(map[string]bool)
+
+	for _, column := range columns {
+		if _, ok := mapper.columnsToFields[typ][column]; !ok {
+			return errors.New(fmt.Sprintf("No field for column %s on %v", column, typ))
+		}
+
+		only[column] = true
+	}
+
+	call.columns = only
+
+	return mapper.sync(context.Background(), rows, o, call.hooks, call)
+}
+
+// SyncContext behaves as Sync, passing context `ctx` to each of the hooks
+// given among `opts`. Syncing stops, returning the context's error, if `ctx`
+// is done before the row is read; pass a context to the query producing
+// `rows`, such as with sql.DB's QueryContext, to abort the query itself.
+func (self *Cartographer) SyncContext(ctx context.Context, rows ScannableRows, o interface{}, opts ...CallOption) error {
+	call := newCall(opts)
+	return self.forCall(call).sync(ctx, rows, o, call.hooks, call)
+}
+
+// SyncReport describes the columns Sync populated a struct's fields from.
+type SyncReport struct {
+	Fields  []string // Fields set from non-NULL values, in column order.
+	Columns []string // Columns the Fields were set from.
+	Null    []string // Columns returned NULL, leaving their fields untouched.
+}
+
+// SyncWithReport behaves as Sync, additionally returning a SyncReport of the
+// columns synced, so callers may distinguish a column returned NULL from one
+// that was not returned at all. Should multiple rows be allowed, see
+// SetSyncMultipleRows, the report describes the last row.
+func (self *Cartographer) SyncWithReport(rows ScannableRows, o interface{}, opts ...CallOption) (report SyncReport, err error) {
+	call := newCall(opts)
+	call.report = &report
+	err = self.forCall(call).sync(context.Background(), rows, o, call.hooks, call)
+	return
+}
+
+// record records the scanned `value` of field `mapped` within the report.
+func (self *SyncReport) record(mapped *mappedField, value interface{}) {
+	if nil == value {
+		self.Null = append(self.Null, mapped.column)
+		return
+	}
+
+	self.Fields = append(self.Fields, mapped.name)
+	self.Columns = append(self.Columns, mapped.column)
+}
+
+// sync syncs the single row of `rows` into the struct pointed to by `o`,
+// running each of the `hooks` with context `ctx` against it.
+func (self *Cartographer) sync(ctx context.Context, rows ScannableRows, o interface{}, hooks []ContextHook, call *call) (err error) {
+	defer self.hold()()
+
+	var (
+		typ    reflect.Type
+		synced int // Rows hydrated into `o`.
+		start  = time.Now()
+	)
+
+	if typ, err = self.DiscoverType(o); nil != err {
+		return
+	}
+
+	defer func() {
+		self.measure(ctx, typ, "sync", synced, start)
+	}()
+
+	object := reflect.ValueOf(o)
+
+	if reflect.Ptr != object.Kind() {
+		err = errors.New("Sync expected a pointer to be passed for manipulation")
+		return
+	}
+
+	element := object.Elem()
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	columns = call.alias(columns)
+
+	before, err := self.snapshotForSyncHooks(o)
+
+	if nil != err {
+		return
+	}
+
+	for row := 0; rows.Next(); row++ {
+		if err = ctx.Err(); nil != err {
+			return
+		}
+
+		if 1 == row {
+			if !self.syncMultipleRows {
+				return ErrMultipleRows
+			}
+
+			self.warn(ctx, "cartographer: several rows synced, the last taking precedence", "type", element.Type().String())
+			self.publish(Event{Kind: OnSyncMultipleRows, Type: element.Type(), Value: object, Err: ErrMultipleRows})
+		}
+
+		values, err := populatedRowValues(rows, len(columns))
+
+		if nil != err {
+			return err
+		}
+
+		if nil != call && nil != call.report {
+			*call.report = SyncReport{}
+		}
+
+		if err = self.hydrate(ctx, object, columns, values, row, call); errRowSkipped == err {
+			continue
+		} else if nil != err {
+			return err
+		}
+
+		synced++
+
+		for _, hook := range hooks {
+			if err = protect(func() error { return hook(ctx, object) }, "in hook at row %d", row); nil != err {
+				self.metricsFor(ctx).HookFailed(element.Type(), "")
+				return err // Hook returned an error, return it to caller to deal with.
+			}
+		}
+	}
+
+	if err = self.runSyncHooks(ctx, before, object); nil != err {
+		return
+	}
+
+	if self.autoTrack || call.tracks() {
+		if err = self.Track(o); nil != err {
+			return
+		}
+	}
+
+	self.debug(ctx, "cartographer: struct synced", "type", element.Type().String())
+	self.publish(Event{Kind: OnSyncComplete, Type: element.Type(), Value: object})
+
+	return
+}
+
+// SetSyncMultipleRows allows or disallows Sync reading more than a single
+// row. When allowed, each row is synced in turn, the last taking precedence,
+// and an OnSyncMultipleRows event is published as a warning; otherwise
+// Sync returns ErrMultipleRows.
+func (self *Cartographer) SetSyncMultipleRows(allowed bool) {
+	self.syncMultipleRows = allowed
+}
+
+// SyncAll behaves as Sync for statements returning several rows, such as
+// `INSERT ... RETURNING *` over multiple VALUES or `UPDATE ... RETURNING *`.
+// Parameter `dest` must be a pointer to a slice of structs or pointers to
+// structs; each row is synced into the element at its index, appending new
+// elements should there be more rows than elements. Any `opts` override the
+// Cartographer's defaults for this call alone, see CallOption; rows failing
+// validation are left partially synced when skipped with
+// WithContinueOnInvalid.
+func (self *Cartographer) SyncAll(rows ScannableRows, dest interface{}, opts ...CallOption) error {
+	return self.SyncAllContext(context.Background(), rows, dest, opts...)
+}
+
+// SyncAllContext behaves as SyncAll, passing context `ctx` to each of the
+// hooks given among `opts`. Syncing stops, returning the context's error,
+// if `ctx` is done before a row is read.
+func (self *Cartographer) SyncAllContext(ctx context.Context, rows ScannableRows, dest interface{}, opts ...CallOption) error {
+	call := newCall(opts)
+	return self.forCall(call).syncAll(ctx, rows, dest, call)
+}
+
+// syncAll behaves as SyncAllContext, applying the overrides of `call`.
+func (self *Cartographer) syncAll(ctx context.Context, rows ScannableRows, dest interface{}, call *call) (err error) {
+	defer self.hold()()
+
+	var (
+		synced int // Rows hydrated into elements of `dest`.
+		start  = time.Now()
+	)
+
+	slice := reflect.ValueOf(dest)
+
+	if reflect.Ptr != slice.Kind() || reflect.Slice != slice.Elem().Kind() {
+		return errors.New(fmt.Sprintf("SyncAll expected a pointer to a slice to be passed, received %T", dest))
+	}
+
+	slice = slice.Elem()
+
+	var (
+		typ     = slice.Type().Elem()
+		pointer = reflect.Ptr == typ.Kind()
+	)
+
+	if pointer {
+		typ = typ.Elem()
+	}
+
+	if _, err = self.DiscoverType(reflect.New(typ).Interface()); nil != err {
+		return
+	}
+
+	defer func() {
+		self.measure(ctx, typ, "sync", synced, start)
+	}()
+
+	columns, err := rows.Columns()
+
+	if nil != err {
+		return
+	}
+
+	columns = call.alias(columns)
+
+	var invalid RowErrors // Rows failing validation, should syncing continue past them.
+
+	for row := 0; rows.Next(); row++ {
+		if err = ctx.Err(); nil != err {
+			return
+		}
+
+		values, err := populatedRowValues(rows, len(columns))
+
+		if nil != err {
+			return err
+		}
+
+		if row == slice.Len() {
+			slice.Set(reflect.Append(slice, reflect.Zero(slice.Type().Elem())))
+		}
+
+		object := slice.Index(row)
+
+		if !pointer {
+			object = object.Addr()
+		} else if object.IsNil() {
+			object.Set(reflect.New(typ))
+		}
+
+		before, err := self.snapshotForSyncHooks(object.Interface())
+
+		if nil != err {
+			return err
+		}
+
+		if err = self.hydrate(ctx, object, columns, values, row, call); errRowSkipped == err {
+			continue
+		} else if failed, ok := err.(*RowError); ok && call.continueOnInvalid {
+			invalid = append(invalid, failed)
+			continue
+		} else if nil != err {
+			return err
+		}
+
+		synced++
+
+		for _, hook := range call.hooks {
+			if err = protect(func() error { return hook(ctx, object) }, "in hook at row %d", row); nil != err {
+				self.metricsFor(ctx).HookFailed(typ, "")
+				return err // Hook returned an error, return it to caller to deal with.
+			}
+		}
+
+		if err = self.runSyncHooks(ctx, before, object); nil != err {
+			return err
+		}
+
+		if self.autoTrack || call.tracks() {
+			if err = self.Track(object.Interface()); nil != err {
+				return err
+			}
+		}
+
+		self.publish(Event{Kind: OnSyncComplete, Type: typ, Value: object})
+	}
+
+	if 0 != len(invalid) {
+		err = invalid
+	}
+
+	return
+}
+
+// SyncHook is run once a struct has been synced, receiving a Snapshot of
+// its state beforehand, a pointer to the struct itself and the changes
+// made to it, so that audit and cache invalidation hooks may react to
+// exactly what the database assigned.
+type SyncHook func(ctx context.Context, before *Snapshot, after reflect.Value, changes []Change) error
+
+// RegisterSyncHook attaches SyncHook `hook` to parameter `o`'s type, running
+// it for every struct of the type synced by Sync, SyncColumns, SyncContext
+// or SyncAll, or returns an error if `o` is not a struct. The hook is unnamed
+// and registered with a priority of zero.
+func (self *Cartographer) RegisterSyncHook(o interface{}, hook SyncHook) error {
+	return self.RegisterNamedSyncHook(o, "", 0, hook)
+}
+
+// RegisterNamedSyncHook behaves as RegisterSyncHook, naming and ordering
+// the hook as RegisterNamedHook does.
+func (self *Cartographer) RegisterNamedSyncHook(o interface{}, name string, priority int, hook SyncHook) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	self.syncHooks[typ] = register(self.syncHooks[typ], registered[SyncHook]{name, priority, hook})
+
+	return nil
+}
+
+// RemoveSyncHook removes the sync hook named `name` registered for
+// parameter `o`'s type, or returns an error if `o` is not a struct or
+// no such hook is registered.
+func (self *Cartographer) RemoveSyncHook(o interface{}, name string) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	hooks, removed := unregister(self.syncHooks[typ], name)
+
+	if !removed {
+		return errors.New(fmt.Sprintf("No sync hook named %s on %v", name, typ))
+	}
+
+	self.syncHooks[typ] = hooks
+
+	return nil
+}
+
+// snapshotForSyncHooks returns a Snapshot of the struct pointed to by `o`
+// should sync hooks be registered for its type, else nil.
+func (self *Cartographer) snapshotForSyncHooks(o interface{}) (*Snapshot, error) {
+	if 0 == len(self.syncHooks[reflect.TypeOf(o).Elem()]) {
+		return nil, nil
+	}
+
+	return self.Snapshot(o)
+}
+
+// runSyncHooks runs the sync hooks registered for the type pointed to
+// by `value` with context `ctx`, given its Snapshot `before` the sync.
+func (self *Cartographer) runSyncHooks(ctx context.Context, before *Snapshot, value reflect.Value) (err error) {
+	if nil == before {
+		return
+	}
+
+	changes, err := before.Diff(value.Interface())
+
+	if nil != err {
+		return
+	}
+
+	for _, hook := range self.syncHooks[value.Type().Elem()] {
+		if err = protect(func() error { return hook.hook(ctx, before, value, changes) }, "in sync hook %q", hook.name); nil != err {
+			self.metricsFor(ctx).HookFailed(value.Type().Elem(), hook.name)
+			return // Hook returned an error, return it to caller to deal with.
+		}
+	}
+
+	return
+}
@@ -0,0 +1,252 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSyncMultipleRows(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		item         faker
+		warned       bool
+	)
+
+	rows := func() ScannableRows {
+		return &fakeRows{columns: []string{"id"}, values: [][]interface{}{{1}, {2}}}
+	}
+
+	if err := cartographer.Sync(rows(), &item); ErrMultipleRows != err || 1 != item.Id {
+		t.Errorf("Multiple row Sync test returned an unexpected results: %v, %v", item, err)
+	}
+
+	cartographer.SetSyncMultipleRows(true)
+	cartographer.Subscribe(OnSyncMultipleRows, func(event Event) {
+		warned = true
+	})
+
+	if err := cartographer.Sync(rows(), &item); nil != err || 2 != item.Id || !warned {
+		t.Errorf("Allowed multiple row Sync test returned an unexpected results: %v, %v", item, err)
+	}
+}
+
+func TestSyncAll(t *testing.T) {
+	var (
+		items    = []membership{{Role: "owner"}}
+		pointers []*membership
+	)
+
+	rows := func() ScannableRows {
+		return &fakeRows{columns: []string{"user_id", "group_id"}, values: [][]interface{}{{1, 2}, {3, 4}}}
+	}
+
+	if err := instance.SyncAll(rows(), &items); nil != err || 2 != len(items) {
+		t.Fatalf("Basic SyncAll test returned an unexpected results: %v, %v", items, err)
+	}
+
+	if 1 != items[0].UserId || "owner" != items[0].Role || 4 != items[1].GroupId {
+		t.Errorf("Basic SyncAll test synced unexpected values: %v", items)
+	}
+
+	if err := instance.SyncAll(rows(), &pointers); nil != err || 2 != len(pointers) || 3 != pointers[1].UserId {
+		t.Errorf("Pointer SyncAll test returned an unexpected results: %v, %v", pointers, err)
+	}
+
+	if err := instance.SyncAll(rows(), items); nil == err {
+		t.Errorf("Basic SyncAll test expected an error for a non-pointer")
+	}
+}
+
+func TestSyncAllContext(t *testing.T) {
+	var (
+		metrics = new(recordingMetrics)
+		c       = New(WithValidation(true), WithMetrics(metrics))
+		items   []signup
+	)
+
+	rows := func() *fakeRows {
+		return &fakeRows{
+			columns: []string{"id", "email", "plan"},
+			values:  [][]interface{}{{1, "ada@example.com", "free"}, {2, "nope", "free"}, {3, "bob@example.com", "pro"}},
+		}
+	}
+
+	err := c.SyncAll(rows(), &items, WithContinueOnInvalid())
+
+	var invalid RowErrors
+
+	if !errors.As(err, &invalid) || 1 != len(invalid) || 1 != invalid[0].Row || 3 != len(items) || 3 != items[2].Id {
+		t.Errorf("Basic SyncAll test expected the invalid row to be skipped, received %v, %v", items, err)
+	}
+
+	if 1 != len(metrics.durations) || "sync cartographer.signup" != metrics.durations[0] || "rows cartographer.signup 2" != metrics.measurements[len(metrics.measurements)-1] {
+		t.Errorf("Basic SyncAll test returned unexpected measurements: %v, %v", metrics.measurements, metrics.durations)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err = c.SyncAllContext(ctx, rows(), &items); context.Canceled != err {
+		t.Errorf("Basic SyncAllContext test expected the context's error, received %v", err)
+	}
+}
+
+func TestSyncColumns(t *testing.T) {
+	var (
+		item = membership{Role: "owner"}
+		rows = &fakeRows{columns: []string{"user_id", "group_id", "role"}, values: [][]interface{}{{1, 2, "member"}}}
+	)
+
+	if err := instance.SyncColumns(rows, &item, []string{"user_id"}); nil != err {
+		t.Errorf("Basic SyncColumns test returned an unexpected error: %v", err)
+	}
+
+	if 1 != item.UserId || 0 != item.GroupId || "owner" != item.Role {
+		t.Errorf("Basic SyncColumns test synced unexpected values: %v", item)
+	}
+
+	if err := instance.SyncColumns(rows, &item, []string{"missing"}); nil == err {
+		t.Errorf("Basic SyncColumns test expected an error for an unmapped column")
+	}
+
+	var (
+		aliased = &fakeRows{columns: []string{"uid", "role"}, values: [][]interface{}{{3, "member"}}}
+		hooked  int
+	)
+
+	hook := Hook(func(value reflect.Value) error {
+		hooked++
+		return nil
+	})
+
+	if err := instance.SyncColumns(aliased, &item, []string{"user_id"}, WithAliases(map[string]string{"uid": "user_id"}), hook); nil != err || 3 != item.UserId || "owner" != item.Role || 1 != hooked {
+		t.Errorf("Basic SyncColumns test returned unexpected sync with options: %v, %v, %d", item, err, hooked)
+	}
+}
+
+func TestSyncWithReport(t *testing.T) {
+	var (
+		item = membership{Role: "owner"}
+		rows = &fakeRows{columns: []string{"user_id", "role"}, values: [][]interface{}{{1, nil}}}
+	)
+
+	report, err := instance.SyncWithReport(rows, &item)
+
+	if nil != err || 1 != len(report.Fields) || "UserId" != report.Fields[0] || "user_id" != report.Columns[0] {
+		t.Errorf("Basic SyncWithReport test returned an unexpected results: %v, %v", report, err)
+	}
+
+	if 1 != len(report.Null) || "role" != report.Null[0] || "owner" != item.Role {
+		t.Errorf("Basic SyncWithReport test reported unexpected NULL columns: %v, %v", report, item)
+	}
+}
+
+type Model struct {
+	Id        int    `db:"id,pk"`
+	CreatedAt string `db:"created_at"`
+}
+
+type post struct {
+	*Model
+	Title string `db:"title"`
+}
+
+type shadowing struct {
+	Model
+	Id string `db:"uuid"`
+}
+
+func TestSyncEmbedded(t *testing.T) {
+	var (
+		item = post{Title: "Hello"}
+		rows = &fakeRows{columns: []string{"id", "created_at"}, values: [][]interface{}{{1, "today"}}}
+	)
+
+	if err := instance.Sync(rows, &item); nil != err {
+		t.Fatalf("Embedded Sync test returned an unexpected error: %v", err)
+	}
+
+	if nil == item.Model || 1 != item.Id || "today" != item.CreatedAt || "Hello" != item.Title {
+		t.Errorf("Embedded Sync test synced unexpected values: %v", item)
+	}
+
+	values, err := instance.FieldValueMapFor(post{})
+
+	if nil != err || 3 != len(values) || 0 != values["Id"] {
+		t.Errorf("Embedded FieldValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+
+	columns, err := instance.PrimaryKeysFor(shadowing{})
+
+	if field, _ := instance.FieldForColumn(shadowing{}, "uuid"); nil == err || "Id" != field {
+		t.Errorf("Shadowed embedded test returned an unexpected results: %v, %v, %v", columns, field, err)
+	}
+}
+
+func TestSyncContext(t *testing.T) {
+	var item faker
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	hook := ContextHook(func(ctx context.Context, value reflect.Value) error {
+		cancel()
+		return nil
+	})
+
+	if err := instance.SyncContext(ctx, &scanner{}, &item, hook); nil != err || 1 != item.Id {
+		t.Errorf("Basic SyncContext test returned an unexpected results: %v, %v", item, err)
+	}
+
+	if err := instance.SyncContext(ctx, &scanner{}, &item); context.Canceled != err {
+		t.Errorf("Canceled SyncContext test returned an unexpected error: %v", err)
+	}
+}
+
+func TestRegisterSyncHook(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		item         = membership{UserId: 1, Role: "owner"}
+		rows         = &fakeRows{columns: []string{"user_id", "group_id"}, values: [][]interface{}{{1, 2}}}
+		observed     []Change
+	)
+
+	cartographer.RegisterSyncHook(membership{}, func(ctx context.Context, before *Snapshot, after reflect.Value, changes []Change) error {
+		observed = changes
+		return nil
+	})
+
+	if err := cartographer.Sync(rows, &item); nil != err {
+		t.Errorf("Basic RegisterSyncHook test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(observed) || "group_id" != observed[0].Column || 0 != observed[0].Old || 2 != observed[0].New {
+		t.Errorf("Basic RegisterSyncHook test observed unexpected changes: %v", observed)
+	}
+}
+
+func TestSyncUnknownColumns(t *testing.T) {
+	var (
+		cartographer = Initialize("db")
+		item         faker
+	)
+
+	rows := func() ScannableRows {
+		return &fakeRows{columns: []string{"id", "added_later"}, values: [][]interface{}{{1, "x"}}}
+	}
+
+	if err := cartographer.Sync(rows(), &item); nil != err || 1 != item.Id {
+		t.Errorf("Unknown column Sync test returned an unexpected results: %v, %v", item, err)
+	}
+
+	cartographer.SetStrictColumns(true)
+
+	if err := cartographer.Sync(rows(), &item); nil == err {
+		t.Errorf("Strict unknown column Sync test expected an error")
+	}
+
+	if _, err := cartographer.Map(rows(), faker{}); nil == err {
+		t.Errorf("Strict unknown column Map test expected an error")
+	}
+}
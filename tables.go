@@ -0,0 +1,82 @@
+package cartographer
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Tabler is implemented by types declaring the database table they are stored in.
+type Tabler interface {
+	TableName() string
+}
+
+// RegisterTable sets the database table parameter `o`'s type is stored
+// in to `table`, or returns an error if `o` is not a struct. Registered
+// tables take precedence over the Tabler interface.
+func (self *Cartographer) RegisterTable(o interface{}, table string) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	self.tables[typ] = table
+
+	return nil
+}
+
+// TableFor returns the database table parameter `o`'s type is stored in,
+// or an error if `o` is not a struct. The table is that registered with
+// RegisterTable, else that of its base should it be a variant registered
+// with RegisterVariant, else that returned by the type's TableName method
+// should it implement Tabler, else the type's name in snake case.
+func (self *Cartographer) TableFor(o interface{}) (string, error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return "", err
+	}
+
+	if table, ok := self.tables[typ]; ok {
+		return table, nil
+	}
+
+	if variant, ok := self.variants[typ]; ok {
+		return self.TableFor(reflect.New(variant.base).Interface())
+	}
+
+	if tabler, ok := reflect.New(typ).Interface().(Tabler); ok {
+		return tabler.TableName(), nil
+	}
+
+	return snakeCase(typ.Name()), nil
+}
+
+// SnakeCase converts `name`, such as `UserRole`, to snake case, such as
+// `user_role`, as TableFor does the names of types declaring no table.
+func SnakeCase(name string) string {
+	return snakeCase(name)
+}
+
+// snakeCase converts `name`, such as `UserRole`, to snake case, such as `user_role`.
+func snakeCase(name string) string {
+	var (
+		builder strings.Builder
+		runes   = []rune(name)
+	)
+
+	for index, character := range runes {
+		if unicode.IsUpper(character) {
+			if 0 != index && (unicode.IsLower(runes[index-1]) || (index+1 < len(runes) && unicode.IsLower(runes[index+1]))) {
+				builder.WriteRune('_')
+			}
+
+			character = unicode.ToLower(character)
+		}
+
+		builder.WriteRune(character)
+	}
+
+	return builder.String()
+}
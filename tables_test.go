@@ -0,0 +1,37 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type UserRole struct {
+	Id int `db:"id"`
+}
+
+type HTTPLog struct {
+	Id int `db:"id"`
+}
+
+type person struct {
+	Id int `db:"id"`
+}
+
+func (self person) TableName() string {
+	return "people"
+}
+
+func TestTableFor(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	for o, expected := range map[interface{}]string{UserRole{}: "user_role", HTTPLog{}: "http_log", person{}: "people"} {
+		if table, err := cartographer.TableFor(o); nil != err || expected != table {
+			t.Errorf("Basic TableFor test returned an unexpected results: %v, %v", table, err)
+		}
+	}
+
+	cartographer.RegisterTable(UserRole{}, "roles")
+
+	if table, err := cartographer.TableFor(&UserRole{}); nil != err || "roles" != table {
+		t.Errorf("Registered TableFor test returned an unexpected results: %v, %v", table, err)
+	}
+}
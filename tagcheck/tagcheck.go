@@ -0,0 +1,235 @@
+// Package tagcheck reports mistakes within the struct tags cartographer
+// maps fields through, at lint time rather than when a row is first
+// scanned, as run by the cartographer-vet command. It reports:
+//
+//   - tag keys resembling, but not matching, the mapping tag or the `rel`
+//     and `validate` tags, such as `bd:"id"` or `DB:"id"`;
+//   - options resembling, but not matching, a known option, such as
+//     `db:"email,uniqe"`;
+//   - columns mapped by several fields of a struct;
+//   - tagged fields that are unexported, and so can never be set.
+//
+// Check is shaped after the Run of an analysis.Analyzer, taking the files
+// of a single package, so that it may be wrapped by one where the
+// golang.org/x/tools module is available.
+package tagcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// Options are the tag options cartographer and its sanitizers recognize.
+var Options = []string{
+	"pk", "omitempty", "notnull", "nullable", "unique", "index", "version", "json",
+	"autocreate", "autoupdate", "trim", "squash", "lower", "upper",
+}
+
+// Diagnostic is a mistake found within a struct tag.
+type Diagnostic struct {
+	Pos     token.Pos // Position of the field at fault.
+	Message string    // Description of the mistake.
+}
+
+// Check returns the Diagnostics of the struct tags within `files`, whose
+// fields are mapped through struct tag `tag`, cartographer.DefaultStructTag
+// if empty, in the order they are found.
+func Check(files []*ast.File, tag string) (diagnostics []Diagnostic) {
+	if 0 == len(tag) {
+		tag = cartographer.DefaultStructTag
+	}
+
+	var keys = []string{tag, cartographer.RelationTag, cartographer.ValidationTag}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if structure, ok := node.(*ast.StructType); ok {
+				diagnostics = append(diagnostics, checkStruct(structure, tag, keys)...)
+			}
+
+			return true
+		})
+	}
+
+	return
+}
+
+// checkStruct returns the Diagnostics of the fields of struct `structure`.
+func checkStruct(structure *ast.StructType, tag string, keys []string) (diagnostics []Diagnostic) {
+	var (
+		report = func(field *ast.Field, format string, args ...interface{}) {
+			diagnostics = append(diagnostics, Diagnostic{field.Pos(), fmt.Sprintf(format, args...)})
+		}
+		columns = make(map[string]string) // Field first mapping each column, keyed by column.
+	)
+
+	for _, field := range structure.Fields.List {
+		if nil == field.Tag {
+			continue
+		}
+
+		literal, err := strconv.Unquote(field.Tag.Value)
+
+		if nil != err {
+			continue
+		}
+
+		for _, key := range tagKeys(literal) {
+			if suggestion := resembles(key, keys); 0 != len(suggestion) {
+				report(field, "struct tag key %q resembles %q", key, suggestion)
+			}
+		}
+
+		value, ok := reflect.StructTag(literal).Lookup(tag)
+
+		if !ok {
+			continue
+		}
+
+		column, options := cartographer.ParseTag(value)
+
+		if "-" == column {
+			continue
+		}
+
+		for _, option := range options {
+			if suggestion := resembles(option.Name, Options); 0 != len(suggestion) {
+				report(field, "%s tag option %q resembles %q", tag, option.Name, suggestion)
+			}
+		}
+
+		for _, name := range fieldNames(field) {
+			if !ast.IsExported(name) {
+				report(field, "field %s is tagged %q but unexported, so can never be set", name, tag)
+			}
+
+			if 0 == len(column) {
+				continue
+			}
+
+			if first, ok := columns[column]; ok {
+				report(field, "column %s of field %s is already mapped by field %s", column, name, first)
+			} else {
+				columns[column] = name
+			}
+		}
+	}
+
+	return
+}
+
+// fieldNames returns the names of `field`, that of its type if embedded.
+func fieldNames(field *ast.Field) (names []string) {
+	for _, name := range field.Names {
+		names = append(names, name.Name)
+	}
+
+	if 0 != len(names) {
+		return
+	}
+
+	var expr = field.Type
+
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		names = append(names, expr.Name)
+	case *ast.SelectorExpr:
+		names = append(names, expr.Sel.Name)
+	}
+
+	return
+}
+
+// tagKeys returns the keys of struct tag `literal`, following the
+// conventional `key:"value"` format that reflect.StructTag parses.
+func tagKeys(literal string) (keys []string) {
+	for literal = strings.TrimSpace(literal); 0 != len(literal); literal = strings.TrimSpace(literal) {
+		colon := strings.Index(literal, ":")
+
+		if -1 == colon || colon+1 >= len(literal) || '"' != literal[colon+1] {
+			return
+		}
+
+		keys = append(keys, literal[:colon])
+
+		value, err := strconv.QuotedPrefix(literal[colon+1:])
+
+		if nil != err {
+			return
+		}
+
+		literal = literal[colon+1+len(value):]
+	}
+
+	return
+}
+
+// resembles returns the first of the `known` words `word` resembles but
+// does not match: a word equal regardless of case, or within a single
+// edit, or two for words of five letters or more, of `word`. An empty
+// string is returned should `word` match or resemble none of them.
+func resembles(word string, known []string) string {
+	for _, candidate := range known {
+		if word == candidate {
+			return ""
+		}
+	}
+
+	for _, candidate := range known {
+		var allowed = 1
+
+		if 5 <= len(candidate) {
+			allowed = 2
+		}
+
+		if strings.EqualFold(word, candidate) || distance(word, candidate) <= allowed {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// distance returns the optimal string alignment distance between `a`
+// and `b`: the edits, being insertions, deletions, substitutions and
+// transpositions of adjacent letters, turning one into the other.
+func distance(a, b string) int {
+	var rows = make([][]int, len(a)+1)
+
+	for i, _ := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+
+	for j := 0; j <= len(b); j++ {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+
+			if 1 < i && 1 < j && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
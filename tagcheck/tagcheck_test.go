@@ -0,0 +1,74 @@
+package tagcheck
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const source = `package models
+
+type Base struct {
+	ID int ` + "`db:\"id,pk\"`" + `
+}
+
+type User struct {
+	Base
+	Name     string ` + "`db:\"name\" json:\"name\"`" + `
+	Email    string ` + "`bd:\"email,uniqe\"`" + `
+	Login    string ` + "`DB:\"login\"`" + `
+	Alias    string ` + "`db:\"name,omitemtpy\"`" + `
+	password string ` + "`db:\"password\"`" + `
+	session  string ` + "`db:\"-\"`" + `
+	Roles    []int  ` + "`rel:\"has_many,fk=user_id\" valdate:\"required\"`" + `
+	Custom   string ` + "`db:\"custom,size=20,notnull\" pg:\"custom\"`" + `
+}
+`
+
+func TestCheck(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "models.go", source, 0)
+
+	if nil != err {
+		t.Fatalf("Basic Check test returned an unexpected error: %v", err)
+	}
+
+	var messages []string
+
+	for _, diagnostic := range Check([]*ast.File{file}, "") {
+		messages = append(messages, fset.Position(diagnostic.Pos).String()+": "+diagnostic.Message)
+	}
+
+	var expected = []string{
+		`models.go:10:2: struct tag key "bd" resembles "db"`,
+		`models.go:11:2: struct tag key "DB" resembles "db"`,
+		`models.go:12:2: db tag option "omitemtpy" resembles "omitempty"`,
+		`models.go:12:2: column name of field Alias is already mapped by field Name`,
+		`models.go:13:2: field password is tagged "db" but unexported, so can never be set`,
+		`models.go:15:2: struct tag key "valdate" resembles "validate"`,
+	}
+
+	if strings.Join(expected, "\n") != strings.Join(messages, "\n") {
+		t.Errorf("Basic Check test expected diagnostics:\n%s\nreceived:\n%s", strings.Join(expected, "\n"), strings.Join(messages, "\n"))
+	}
+
+	if diagnostics := Check([]*ast.File{file}, "bd"); 8 != len(diagnostics) || `bd tag option "uniqe" resembles "unique"` != diagnostics[2].Message {
+		t.Errorf("Basic Check test expected the tag to be configurable, received %v", diagnostics)
+	}
+}
+
+func TestResembles(t *testing.T) {
+	for word, expected := range map[string]string{"db": "", "d": "db", "bd": "db", "dbb": "db", "pg": "", "json": "", "sql": "", "rle": "rel"} {
+		if suggestion := resembles(word, []string{"db", "rel", "validate"}); expected != suggestion {
+			t.Errorf("Basic Resembles test expected key %q to resemble %q, received %q", word, expected, suggestion)
+		}
+	}
+
+	for word, expected := range map[string]string{"pk": "", "uniq": "unique", "nulable": "nullable", "versoin": "version", "size": "", "fk": "pk"} {
+		if suggestion := resembles(word, Options); expected != suggestion {
+			t.Errorf("Basic Resembles test expected option %q to resemble %q, received %q", word, expected, suggestion)
+		}
+	}
+}
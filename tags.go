@@ -0,0 +1,177 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TagOption is a single option following the column name within
+// a struct field's tag, such as `unique` or `index=idx_name`.
+type TagOption struct {
+	Name  string // Name of the option, e.g. `index`.
+	Value string // Value following the `=`, if any.
+}
+
+// String returns the option as it is written within a tag.
+func (self TagOption) String() string {
+	if 0 == len(self.Value) {
+		return self.Name
+	}
+
+	return self.Name + "=" + self.Value
+}
+
+// TagOptions is the ordered list of options parsed from a struct field's tag.
+type TagOptions []TagOption
+
+// Has returns true if the option `name` is present.
+func (self TagOptions) Has(name string) bool {
+	for _, option := range self {
+		if name == option.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get returns the value of the first option `name`, or an empty
+// string if the option is not present or has no value.
+func (self TagOptions) Get(name string) string {
+	for _, option := range self {
+		if name == option.Name {
+			return option.Value
+		}
+	}
+
+	return ""
+}
+
+// Values returns the values of every option `name`, allowing a field
+// to take part in several composite indexes.
+func (self TagOptions) Values(name string) (values []string) {
+	for _, option := range self {
+		if name == option.Name {
+			values = append(values, option.Value)
+		}
+	}
+
+	return
+}
+
+// Index describes an index declared through the `index` and `unique`
+// tag options. Fields sharing a named option, e.g. `index=idx_name`,
+// are grouped into a single composite index in field declaration order.
+type Index struct {
+	Name    string   // Name of the index.
+	Columns []string // Columns covered by the index, in order.
+	Unique  bool     // Is the index a unique index?
+}
+
+type mappedField struct {
+	name    string       // Name of the struct field.
+	column  string       // Database column the field is mapped to.
+	options TagOptions   // Options parsed from the field's tag.
+	index   []int        // Index path of the field, as used by reflect.Value.FieldByIndex.
+	typ     reflect.Type // Go type of the field.
+	rules   []Rule       // Validation rules parsed from the field's `validate` tag.
+}
+
+// OptionsFor returns the tag options of parameter `o`'s field or column `field`,
+// or an error if `o` is not a struct or the field is not mapped.
+func (self *Cartographer) OptionsFor(o interface{}, field interface{}) (TagOptions, error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return nil, err
+	}
+
+	for _, mapped := range self.fields[typ] {
+		if field == mapped.name || field == mapped.column {
+			return mapped.options, nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("No field %s on %v", field, typ))
+}
+
+// IndexesFor returns the indexes declared by parameter `o`'s tags, ordered
+// by the first field taking part in each, or an error if `o` is not a struct.
+// Unnamed indexes are named after their column, prefixed with `idx_` or
+// `uniq_` for unique indexes.
+func (self *Cartographer) IndexesFor(o interface{}) (indexes []Index, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	var positions = make(map[string]int) // Position of named indexes within `indexes`.
+
+	for _, mapped := range self.fields[typ] {
+		for _, option := range mapped.options {
+			var unique = "unique" == option.Name
+
+			if !unique && "index" != option.Name {
+				continue
+			}
+
+			name := option.Value
+
+			if 0 == len(name) {
+				if unique {
+					name = "uniq_" + mapped.column
+				} else {
+					name = "idx_" + mapped.column
+				}
+			}
+
+			if position, ok := positions[name]; ok {
+				indexes[position].Columns = append(indexes[position].Columns, mapped.column)
+				indexes[position].Unique = indexes[position].Unique || unique
+				continue
+			}
+
+			positions[name] = len(indexes)
+			indexes = append(indexes, Index{name, []string{mapped.column}, unique})
+		}
+	}
+
+	return
+}
+
+// ParseTag splits the value of a struct field's tag, such as
+// `email,unique`, into its column name and options, as DiscoverType
+// does, for tools working with tags outside of a Cartographer.
+func ParseTag(tag string) (column string, options TagOptions) {
+	return parseTag(tag)
+}
+
+// parseTag splits a struct field's tag into the column name
+// and any comma separated options that follow it.
+func parseTag(tag string) (column string, options TagOptions) {
+	parts := strings.Split(tag, ",")
+	column = strings.TrimSpace(parts[0])
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+
+		if 0 == len(part) {
+			continue
+		}
+
+		var option TagOption
+
+		if index := strings.Index(part, "="); -1 != index {
+			option = TagOption{part[:index], part[index+1:]}
+		} else {
+			option = TagOption{part, ""}
+		}
+
+		options = append(options, option)
+	}
+
+	return
+}
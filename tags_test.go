@@ -0,0 +1,46 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type indexed struct {
+	Id        int    `db:"id,pk"`
+	Email     string `db:"email,unique"`
+	FirstName string `db:"first_name,index=idx_name"`
+	LastName  string `db:"last_name,index=idx_name"`
+}
+
+func TestOptionsFor(t *testing.T) {
+	options, err := instance.OptionsFor(indexed{}, "email")
+
+	if nil != err || !options.Has("unique") {
+		t.Errorf("Basic OptionsFor test returned an unexpected results: %v, %v", options, err)
+	}
+
+	options, err = instance.OptionsFor(indexed{}, "FirstName")
+
+	if nil != err || "idx_name" != options.Get("index") {
+		t.Errorf("Basic OptionsFor test returned an unexpected results: %v, %v", options, err)
+	}
+}
+
+func TestIndexesFor(t *testing.T) {
+	indexes, err := instance.IndexesFor(indexed{})
+
+	if nil != err {
+		t.Errorf("Basic IndexesFor test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(indexes) {
+		t.Fatalf("Basic IndexesFor test returned unexpected indexes: %v", indexes)
+	}
+
+	if "uniq_email" != indexes[0].Name || !indexes[0].Unique || 1 != len(indexes[0].Columns) {
+		t.Errorf("Basic IndexesFor test returned an unexpected unique index: %v", indexes[0])
+	}
+
+	if "idx_name" != indexes[1].Name || indexes[1].Unique || 2 != len(indexes[1].Columns) {
+		t.Errorf("Basic IndexesFor test returned an unexpected composite index: %v", indexes[1])
+	}
+}
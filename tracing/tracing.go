@@ -0,0 +1,134 @@
+// Package tracing traces the mapping of rows by a
+// cartographer.Cartographer, wrapping MapContext and SyncContext in spans
+// annotated with the type mapped and the rows and columns read, so that
+// the cost of mapping shows up in distributed traces alongside the span of
+// the query itself.
+//
+// Spans are started through the Tracer interface rather than any tracing
+// library's API, so that cartographer depends on none; a Tracer is
+// implemented over whichever tracing library the application uses, such
+// as OpenTelemetry, by converting each Attribute to that library's own.
+package tracing
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/chuckpreslar/cartographer"
+)
+
+// Keys of the attributes spans are annotated with.
+const (
+	TypeKey    = "cartographer.type"    // Name of the type mapped, qualified by its package.
+	RowsKey    = "cartographer.rows"    // Number of rows read.
+	ColumnsKey = "cartographer.columns" // Number of columns of each row read.
+)
+
+// Attribute is a key-value pair annotating a Span, its value a string or int.
+type Attribute struct {
+	Key   string      // Key of the attribute, such as TypeKey.
+	Value interface{} // Value of the attribute.
+}
+
+// Span is a span of a trace started by a Tracer.
+type Span interface {
+	SetAttributes(attributes ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts Spans, as children of any span carried by their context.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Mapper maps rows through a Cartographer, tracing each call with a Tracer.
+type Mapper struct {
+	cartographer *cartographer.Cartographer // Cartographer rows are mapped through.
+	tracer       Tracer                     // Tracer spans are started with.
+}
+
+// New returns a pointer to a new Mapper mapping rows through Cartographer
+// `c`, tracing each call with Tracer `tracer`.
+func New(c *cartographer.Cartographer, tracer Tracer) *Mapper {
+	return &Mapper{c, tracer}
+}
+
+// MapContext behaves as the Cartographer's MapContext within a span named
+// `cartographer.Map`, passed through `ctx` to any hooks among `opts` and to the
+// Cartographer's ContextLogger and ContextMetricsSink, if set, so that
+// diagnostics may be correlated with the trace, recording any error returned.
+func (self *Mapper) MapContext(ctx context.Context, rows cartographer.ScannableRows, o interface{}, opts ...cartographer.CallOption) (results []interface{}, err error) {
+	ctx, span := self.tracer.Start(ctx, "cartographer.Map")
+	defer span.End()
+
+	var counted = &countingRows{ScannableRows: rows}
+
+	results, err = self.cartographer.MapContext(ctx, counted, o, opts...)
+	finish(span, o, counted, err)
+
+	return
+}
+
+// SyncContext behaves as the Cartographer's SyncContext within a span
+// named `cartographer.Sync`, passed through `ctx` as MapContext passes it,
+// recording any error returned.
+func (self *Mapper) SyncContext(ctx context.Context, rows cartographer.ScannableRows, o interface{}, opts ...cartographer.CallOption) (err error) {
+	ctx, span := self.tracer.Start(ctx, "cartographer.Sync")
+	defer span.End()
+
+	var counted = &countingRows{ScannableRows: rows}
+
+	err = self.cartographer.SyncContext(ctx, counted, o, opts...)
+	finish(span, o, counted, err)
+
+	return
+}
+
+// finish annotates `span` with the type of `o` and the rows and columns
+// read from `rows`, recording `err` should it not be nil.
+func finish(span Span, o interface{}, rows *countingRows, err error) {
+	var typ = reflect.TypeOf(o)
+
+	for nil != typ && reflect.Ptr == typ.Kind() {
+		typ = typ.Elem()
+	}
+
+	var name string
+
+	if nil != typ {
+		name = typ.String()
+	}
+
+	span.SetAttributes(Attribute{TypeKey, name}, Attribute{RowsKey, rows.rows}, Attribute{ColumnsKey, rows.columns})
+
+	if nil != err {
+		span.RecordError(err)
+	}
+}
+
+// countingRows counts the rows read from, and columns of, the rows it wraps.
+type countingRows struct {
+	cartographer.ScannableRows
+	rows    int // Rows read.
+	columns int // Columns of each row.
+}
+
+// Next advances the wrapped rows, counting each row read.
+func (self *countingRows) Next() bool {
+	if self.ScannableRows.Next() {
+		self.rows++
+		return true
+	}
+
+	return false
+}
+
+// Columns returns the columns of the wrapped rows, counting them.
+func (self *countingRows) Columns() (columns []string, err error) {
+	if columns, err = self.ScannableRows.Columns(); nil == err {
+		self.columns = len(columns)
+	}
+
+	return
+}
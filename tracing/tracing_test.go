@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/chuckpreslar/cartographer"
+	"github.com/chuckpreslar/cartographer/maprows"
+)
+
+type account struct {
+	Id    int    `db:"id,pk"`
+	Email string `db:"email"`
+}
+
+// recordedSpan records the attributes and errors set on it.
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	errors     []error
+	ended      bool
+}
+
+func (self *recordedSpan) SetAttributes(attributes ...Attribute) {
+	for _, attribute := range attributes {
+		self.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (self *recordedSpan) RecordError(err error) {
+	self.errors = append(self.errors, err)
+}
+
+func (self *recordedSpan) End() {
+	self.ended = true
+}
+
+// recordingTracer records each span started.
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+type spanKey struct{}
+
+func (self *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordedSpan{name: name, attributes: make(map[string]interface{})}
+	self.spans = append(self.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestMapContext(t *testing.T) {
+	var (
+		tracer = new(recordingTracer)
+		mapper = New(cartographer.New(), tracer)
+		rows   = maprows.From([]map[string]interface{}{{"id": 1, "email": "ada@example.com"}, {"id": 2, "email": "alan@example.com"}})
+		traced bool
+	)
+
+	hook := cartographer.ContextHook(func(ctx context.Context, _ reflect.Value) error {
+		traced = nil != ctx.Value(spanKey{})
+		return nil
+	})
+
+	results, err := mapper.MapContext(context.Background(), rows, account{}, hook)
+
+	if nil != err || 2 != len(results) {
+		t.Fatalf("Basic MapContext test returned unexpected results: %v, %v", results, err)
+	}
+
+	span := tracer.spans[0]
+
+	if "cartographer.Map" != span.name || !span.ended || !traced {
+		t.Errorf("Basic MapContext test returned unexpected span: %+v", span)
+	}
+
+	if "tracing.account" != span.attributes[TypeKey] || 2 != span.attributes[RowsKey] || 2 != span.attributes[ColumnsKey] {
+		t.Errorf("Basic MapContext test returned unexpected attributes: %v", span.attributes)
+	}
+}
+
+func TestSyncContext(t *testing.T) {
+	var (
+		tracer = new(recordingTracer)
+		mapper = New(cartographer.New(), tracer)
+		item   account
+	)
+
+	if err := mapper.SyncContext(context.Background(), maprows.From([]map[string]interface{}{{"id": 3}}), &item); nil != err || 3 != item.Id {
+		t.Fatalf("Basic SyncContext test returned unexpected item: %+v, %v", item, err)
+	}
+
+	if span := tracer.spans[0]; "cartographer.Sync" != span.name || "tracing.account" != span.attributes[TypeKey] || 1 != span.attributes[RowsKey] {
+		t.Errorf("Basic SyncContext test returned unexpected span: %+v", span)
+	}
+
+	rows := maprows.From([]map[string]interface{}{{"id": 4}, {"id": 5}})
+
+	if err := mapper.SyncContext(context.Background(), rows, &item); !errors.Is(err, cartographer.ErrMultipleRows) {
+		t.Fatalf("Basic SyncContext test expected ErrMultipleRows, received %v", err)
+	}
+
+	if span := tracer.spans[1]; 1 != len(span.errors) || !span.ended {
+		t.Errorf("Basic SyncContext test expected the error to be recorded, received %+v", span)
+	}
+}
@@ -0,0 +1,62 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Track records a Snapshot of the struct pointed to by `o`, keyed by the
+// pointer, so that changes made through it may later be retrieved with
+// Changes without the caller holding on to the snapshot, or returns an
+// error if `o` is not a pointer to a struct. Tracking a pointer already
+// tracked replaces its snapshot, such as after the struct has been saved.
+// Tracked pointers are retained until passed to Untrack.
+func (self *Cartographer) Track(o interface{}) error {
+	if reflect.Ptr != reflect.ValueOf(o).Kind() {
+		return errors.New(fmt.Sprintf("Track expected a pointer to be passed, received %T", o))
+	}
+
+	snapshot, err := self.Snapshot(o)
+
+	if nil != err {
+		return err
+	}
+
+	self.tracked[o] = snapshot
+
+	return nil
+}
+
+// Untrack stops tracking the pointer `o`, releasing its snapshot.
+func (self *Cartographer) Untrack(o interface{}) {
+	delete(self.tracked, o)
+}
+
+// Tracked returns the Snapshot recorded for pointer `o`, and whether it is tracked.
+func (self *Cartographer) Tracked(o interface{}) (snapshot *Snapshot, ok bool) {
+	snapshot, ok = self.tracked[o]
+	return
+}
+
+// Changes returns the changes made to the struct pointed to by `o` since
+// it was tracked, or an error if the pointer is not tracked.
+func (self *Cartographer) Changes(o interface{}) ([]Change, error) {
+	snapshot, ok := self.tracked[o]
+
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("%T is not tracked", o))
+	}
+
+	return snapshot.Diff(o)
+}
+
+// SetAutoTrack enables or disables tracking every struct hydrated by Map
+// and synced by Sync, so that applications need not remember to take
+// snapshots before modifying their models. Tracked structs are retained
+// until passed to Untrack, so that long running applications must Untrack
+// each once done with it, or track only the structs of the calls they
+// modify with WithTracking, lest the snapshots grow without bound.
+func (self *Cartographer) SetAutoTrack(enabled bool) {
+	self.autoTrack = enabled
+}
@@ -0,0 +1,68 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+func TestTrack(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	cartographer.SetAutoTrack(true)
+
+	results, err := cartographer.Map(&scanner{}, faker{})
+
+	if nil != err || 1 != len(results) {
+		t.Fatalf("Basic Track test returned an unexpected results: %v, %v", results, err)
+	}
+
+	item := results[0].(*faker)
+	item.Id = 2
+
+	changes, err := cartographer.Changes(item)
+
+	if nil != err || 1 != len(changes) || 1 != changes[0].Old || 2 != changes[0].New {
+		t.Errorf("Basic Track test returned unexpected changes: %v, %v", changes, err)
+	}
+
+	cartographer.Untrack(item)
+
+	if _, err = cartographer.Changes(item); nil == err {
+		t.Errorf("Basic Untrack test expected an error for an untracked pointer")
+	}
+
+	if err = cartographer.Track(faker{}); nil == err {
+		t.Errorf("Basic Track test expected an error tracking a non-pointer")
+	}
+}
+
+func TestWithTracking(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	results, err := cartographer.Map(&scanner{}, faker{})
+
+	if nil != err || 1 != len(results) {
+		t.Fatalf("Basic WithTracking test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if _, ok := cartographer.Tracked(results[0]); ok {
+		t.Errorf("Basic WithTracking test expected structs of other calls to be untracked")
+	}
+
+	if results, err = cartographer.MapWith(&scanner{}, faker{}, WithTracking()); nil != err || 1 != len(results) {
+		t.Fatalf("Basic WithTracking test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if _, ok := cartographer.Tracked(results[0]); !ok {
+		t.Errorf("Basic WithTracking test expected the struct hydrated to be tracked")
+	}
+
+	var item faker
+
+	if err = cartographer.SyncWith(&scanner{}, &item, WithTracking()); nil != err {
+		t.Errorf("Basic WithTracking test returned an unexpected error: %v", err)
+	}
+
+	if _, ok := cartographer.Tracked(&item); !ok {
+		t.Errorf("Basic WithTracking test expected the struct synced to be tracked")
+	}
+}
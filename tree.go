@@ -0,0 +1,72 @@
+package cartographer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MapTree behaves as Map, then links the results into a forest by their
+// parent ids, for categories, org charts, threaded comments and other
+// adjacency lists. Each result is appended, in row order, to field
+// `childrenField` of the result whose `idColumn` its `parentColumn` holds.
+// The field must be a slice of pointers to `o`'s type. Results whose
+// parent is NULL or was not read are returned as roots.
+func (self *Cartographer) MapTree(rows ScannableRows, o interface{}, idColumn, parentColumn, childrenField string, opts ...CallOption) (roots []interface{}, err error) {
+	var (
+		call   = newCall(opts)
+		mapper = self.forCall(call)
+	)
+
+	defer mapper.hold()()
+
+	typ, err := mapper.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	id, parent := mapper.byColumn[typ][idColumn], mapper.byColumn[typ][parentColumn]
+
+	if nil == id || nil == parent {
+		return nil, errors.New(fmt.Sprintf("No field for column %s or %s on %v", idColumn, parentColumn, typ))
+	}
+
+	children, ok := typ.FieldByName(childrenField)
+
+	if !ok || reflect.SliceOf(reflect.PtrTo(typ)) != children.Type {
+		return nil, errors.New(fmt.Sprintf("Expected field %s of %v to be a slice of pointers to %v", childrenField, typ, typ))
+	}
+
+	results, err := mapper.mapContext(context.Background(), rows, o, call.hooks, call)
+
+	if nil != err {
+		return
+	}
+
+	var nodes = make(map[string]reflect.Value) // Results, by id.
+
+	for _, result := range results {
+		node := reflect.ValueOf(result)
+
+		if key, ok := relationKey(fieldValue(node.Elem(), id)); ok {
+			nodes[key] = node
+		}
+	}
+
+	for _, result := range results {
+		node := reflect.ValueOf(result)
+		key, ok := relationKey(fieldValue(node.Elem(), parent))
+
+		if owner, found := nodes[key]; ok && found {
+			field := owner.Elem().FieldByIndex(children.Index)
+			field.Set(reflect.Append(field, node))
+			continue
+		}
+
+		roots = append(roots, result)
+	}
+
+	return
+}
@@ -0,0 +1,57 @@
+package cartographer
+
+import (
+	"testing"
+)
+
+type category struct {
+	Id       int    `db:"id"`
+	ParentId *int   `db:"parent_id"`
+	Name     string `db:"name"`
+	Children []*category
+}
+
+func TestMapTree(t *testing.T) {
+	rows := &fakeRows{
+		columns: []string{"id", "parent_id", "name"},
+		values: [][]interface{}{
+			{3, 2, "phones"},
+			{1, nil, "root"},
+			{2, 1, "electronics"},
+			{4, 1, "books"},
+			{5, 9, "orphan"},
+		},
+	}
+
+	roots, err := Initialize("db").MapTree(rows, category{}, "id", "parent_id", "Children")
+
+	if nil != err {
+		t.Fatalf("Basic MapTree test returned an unexpected error: %v", err)
+	}
+
+	if 2 != len(roots) || "root" != roots[0].(*category).Name || "orphan" != roots[1].(*category).Name {
+		t.Fatalf("Basic MapTree test returned unexpected roots: %v", roots)
+	}
+
+	root := roots[0].(*category)
+
+	if 2 != len(root.Children) || "electronics" != root.Children[0].Name || "books" != root.Children[1].Name {
+		t.Errorf("Basic MapTree test returned unexpected children: %v", root.Children)
+	}
+
+	if 1 != len(root.Children[0].Children) || "phones" != root.Children[0].Children[0].Name {
+		t.Errorf("Basic MapTree test returned unexpected grandchildren: %v", root.Children[0].Children)
+	}
+}
+
+func TestMapTreeErrors(t *testing.T) {
+	c := Initialize("db")
+
+	if _, err := c.MapTree(&fakeRows{}, category{}, "id", "missing", "Children"); nil == err {
+		t.Error("Basic MapTree test expected an error given an unmapped parent column")
+	}
+
+	if _, err := c.MapTree(&fakeRows{}, category{}, "id", "parent_id", "Name"); nil == err {
+		t.Error("Basic MapTree test expected an error given a field other than a slice of pointers")
+	}
+}
@@ -0,0 +1,625 @@
+package cartographer
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationTag is the struct field tag validation rules are declared
+// with, e.g. `validate:"required,max=255"`. Rules are read alongside the
+// field's column when its type is discovered, so only mapped fields are
+// validated.
+const ValidationTag = "validate"
+
+// rule reports whether field value `value` satisfies a validation rule
+// given the rule's parameter `param`. The value is invalid should the
+// field be promoted through a nil embedded pointer.
+type rule func(value reflect.Value, param string) bool
+
+// validationRules are the rules understood within `validate` tags:
+//
+//	required    The field is not its zero value, or a nil pointer.
+//	empty       The field is its zero value, or a nil pointer.
+//	min=n       The field's length, or value for numbers, is at least n.
+//	max=n       The field's length, or value for numbers, is at most n.
+//	len=n       The field's length, or value for numbers, is exactly n.
+//	oneof=a b   The field, formatted with fmt.Sprint, is one of the space separated values.
+//	email       The field is empty or a bare email address.
+//
+// Every rule but `required` is satisfied by a nil pointer.
+var validationRules = map[string]rule{
+	"required": func(value reflect.Value, param string) bool {
+		return value.IsValid() && !value.IsZero()
+	},
+	"empty": func(value reflect.Value, param string) bool {
+		return !value.IsValid() || value.IsZero()
+	},
+	"min": func(value reflect.Value, param string) bool {
+		return compareSize(value, param, func(size, bound float64) bool { return size >= bound })
+	},
+	"max": func(value reflect.Value, param string) bool {
+		return compareSize(value, param, func(size, bound float64) bool { return size <= bound })
+	},
+	"len": func(value reflect.Value, param string) bool {
+		return compareSize(value, param, func(size, bound float64) bool { return size == bound })
+	},
+	"oneof": func(value reflect.Value, param string) bool {
+		if value = indirectValue(value); !value.IsValid() {
+			return true
+		}
+
+		for _, allowed := range strings.Fields(param) {
+			if allowed == fmt.Sprint(value.Interface()) {
+				return true
+			}
+		}
+
+		return false
+	},
+	"email": func(value reflect.Value, param string) bool {
+		if value = indirectValue(value); !value.IsValid() || reflect.String != value.Kind() || 0 == value.Len() {
+			return true
+		}
+
+		address, err := mail.ParseAddress(value.String())
+
+		return nil == err && address.Address == value.String()
+	},
+}
+
+// Groups of the rules validating structs written by InsertSQL and UpdateSQL.
+const (
+	InsertGroup = "insert"
+	UpdateGroup = "update"
+)
+
+// Rule is a validation rule parsed from a `validate` tag. Rules may be
+// scoped to a group by suffixing them with `@` and the group's name, such
+// as `required@update,empty@insert` for a primary key the database assigns,
+// so that parameters may not themselves contain `@`.
+type Rule struct {
+	Name  string // Name of the rule, e.g. `max`.
+	Value string // Parameter following the `=`, if any.
+	Group string // Group the rule is scoped to, applying whatever the group if empty.
+}
+
+// String returns the rule as it is written within a tag.
+func (self Rule) String() string {
+	var text = TagOption{self.Name, self.Value}.String()
+
+	if 0 != len(self.Group) {
+		text += "@" + self.Group
+	}
+
+	return text
+}
+
+// parseRules parses the comma separated rules of a `validate` tag.
+func parseRules(tag string) (rules []Rule) {
+	_, options := parseTag("," + tag)
+
+	for _, option := range options {
+		var rule = Rule{Name: option.Name, Value: option.Value}
+
+		if 0 == len(rule.Value) {
+			if index := strings.LastIndex(rule.Name, "@"); -1 != index {
+				rule.Name, rule.Group = rule.Name[:index], rule.Name[index+1:]
+			}
+		} else if index := strings.LastIndex(rule.Value, "@"); -1 != index {
+			rule.Value, rule.Group = rule.Value[:index], rule.Value[index+1:]
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return
+}
+
+// crossFieldRules are the rules within `validate` tags whose parameter
+// names, separated by spaces, other mapped fields of the struct, reporting
+// whether field value `value` satisfies the rule given their `others`:
+//
+//	required_with=A B   The field is required should any of A or B be set.
+//	excluded_with=A B   The field is empty should any of A or B be set.
+//
+// A field is set should it be neither its zero value nor a nil pointer.
+var crossFieldRules = map[string]func(value reflect.Value, others []reflect.Value) bool{
+	"required_with": func(value reflect.Value, others []reflect.Value) bool {
+		return !anySet(others) || validationRules["required"](value, "")
+	},
+	"excluded_with": func(value reflect.Value, others []reflect.Value) bool {
+		return !anySet(others) || validationRules["empty"](value, "")
+	},
+}
+
+// anySet reports whether any of the `values` is set.
+func anySet(values []reflect.Value) bool {
+	for _, value := range values {
+		if value.IsValid() && !value.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sizedRules are the rules whose parameter must be a number.
+var sizedRules = map[string]bool{"min": true, "max": true, "len": true}
+
+// ruleMessages describe the violation of each rule given its parameter.
+var ruleMessages = map[string]func(param string) string{
+	"required": func(param string) string { return "is required" },
+	"empty":    func(param string) string { return "must be empty" },
+	"min":      func(param string) string { return "must be at least " + param },
+	"max":      func(param string) string { return "must be at most " + param },
+	"len":      func(param string) string { return "must be exactly " + param },
+	"oneof":    func(param string) string { return "must be one of " + strings.Join(strings.Fields(param), ", ") },
+	"email":    func(param string) string { return "must be an email address" },
+	"notnull":  func(param string) string { return "must not be null" },
+
+	"required_with": func(param string) string { return "is required with " + strings.Join(strings.Fields(param), ", ") },
+	"excluded_with": func(param string) string { return "must be empty with " + strings.Join(strings.Fields(param), ", ") },
+}
+
+// FieldError describes a field violating a validation rule, encoding to
+// JSON so that APIs may report each violation, such as in a 422 response.
+type FieldError struct {
+	Field   string      `json:"field,omitempty"`  // Name of the struct field, empty for violations of the struct as a whole.
+	Column  string      `json:"column,omitempty"` // Database column the field is mapped to.
+	Rule    string      `json:"rule"`             // Rule violated, as written within the tag, e.g. `max=255`.
+	Value   interface{} `json:"value,omitempty"`  // Value of the field violating the rule.
+	Message string      `json:"message"`          // Message describing the violation, e.g. `must be at most 255`.
+}
+
+// violation returns a FieldError of field `mapped` holding `value`
+// violating rule `rule`.
+func violation(mapped *mappedField, value reflect.Value, rule Rule) *FieldError {
+	var violation = &FieldError{Field: mapped.name, Column: mapped.column, Rule: rule.String()}
+
+	if describe, ok := ruleMessages[rule.Name]; ok {
+		violation.Message = describe(rule.Value)
+	}
+
+	if value.IsValid() && value.CanInterface() {
+		violation.Value = value.Interface()
+	}
+
+	return violation
+}
+
+// Error describes the violation.
+func (self *FieldError) Error() string {
+	var message = fmt.Sprintf("Field %s (column %s) failed rule %s", self.Field, self.Column, self.Rule)
+
+	if 0 == len(self.Field) {
+		message = fmt.Sprintf("Failed rule %s", self.Rule)
+	}
+
+	if 0 != len(self.Message) {
+		message += ": " + self.Message
+	}
+
+	return message
+}
+
+// ValidationErrors is the list of every rule violated by a struct,
+// returned by Validate.
+type ValidationErrors []*FieldError
+
+// Error describes each of the violations in turn.
+func (self ValidationErrors) Error() string {
+	var messages = make([]string, 0, len(self))
+
+	for _, violation := range self {
+		messages = append(messages, violation.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Messages returns the messages of the violations keyed by the column of
+// the field at fault, in the order they were violated. Violations of the
+// struct as a whole are keyed by an empty string.
+func (self ValidationErrors) Messages() map[string][]string {
+	var messages = make(map[string][]string)
+
+	for _, violation := range self {
+		messages[violation.Column] = append(messages[violation.Column], violation.Message)
+	}
+
+	return messages
+}
+
+// Validator validates structs beyond the rules of `validate` tags, such
+// as an adapter around a third-party validation package.
+type Validator interface {
+	Validate(o interface{}) error
+}
+
+// ValidatorFunc adapts a function, such as the Struct method of
+// go-playground/validator's Validate, to a Validator.
+type ValidatorFunc func(o interface{}) error
+
+// Validate calls the function with `o`.
+func (self ValidatorFunc) Validate(o interface{}) error {
+	return self(o)
+}
+
+// SetValidator sets the Validator run against every struct hydrated by
+// Map or Sync, after its hooks have run, and against every struct whose
+// column values are taken by ColumnValueMapFor, before they are taken,
+// returning the Validator's error in place of the results, wrapped in a
+// *RowError when hydrating. A nil Validator removes it.
+func (self *Cartographer) SetValidator(validator Validator) {
+	self.validator = validator
+}
+
+// SetValidation enables or disables validating every struct hydrated by
+// Map or Sync with Validate, after its hooks have run, so that structs
+// violating their `validate` tags are rejected as they are read. Should a
+// row fail validation, a *RowError is returned; see WithContinueOnInvalid
+// to map the remaining rows regardless.
+//
+// Structs are validated when written too, by ColumnValueMapFor, InsertSQL,
+// UpdateSQL and UpsertSQL, rejecting invalid structs before a round trip
+// to the database. Columns tagged with the `notnull` option, e.g.
+// `db:"email,notnull"`, or registered with RegisterNotNull, are
+// additionally checked not to be written as NULL, such as from a nil
+// pointer, violating the rule `notnull`.
+func (self *Cartographer) SetValidation(enabled bool) {
+	self.validation = enabled
+}
+
+// RowError describes a row whose struct failed validation once hydrated.
+type RowError struct {
+	Row int   // Index of the row within the rows mapped or synced.
+	Err error // Error validating the struct, ValidationErrors should `validate` tags be violated.
+}
+
+// Error describes the row and its validation error.
+func (self *RowError) Error() string {
+	return fmt.Sprintf("Row %d failed validation: %s", self.Row, self.Err.Error())
+}
+
+// Unwrap returns the error validating the struct.
+func (self *RowError) Unwrap() error {
+	return self.Err
+}
+
+// RowErrors lists every row failing validation, as returned by Map
+// should mapping continue past invalid rows, see WithContinueOnInvalid.
+type RowErrors []*RowError
+
+// Error describes each of the rows in turn.
+func (self RowErrors) Error() string {
+	var messages = make([]string, 0, len(self))
+
+	for _, failed := range self {
+		messages = append(messages, failed.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the error of each row.
+func (self RowErrors) Unwrap() []error {
+	var errs = make([]error, 0, len(self))
+
+	for _, failed := range self {
+		errs = append(errs, failed)
+	}
+
+	return errs
+}
+
+// validateWrite validates struct `o` of type `typ`, whose column `values`
+// are about to be written, with the rules of group `group` should
+// validation be enabled with SetValidation, else with the Validator only.
+func (self *Cartographer) validateWrite(o interface{}, typ reflect.Type, values map[interface{}]interface{}, group string) error {
+	if !self.validation {
+		return self.runValidator(o)
+	}
+
+	var violations ValidationErrors
+
+	err := self.ValidateGroup(o, group)
+
+	if tagged, ok := err.(ValidationErrors); ok {
+		violations = tagged
+	} else if nil != err {
+		return err
+	}
+
+	for _, mapped := range self.fields[typ] {
+		if self.isNotNull(typ, mapped) && nil == values[mapped.column] {
+			violations = append(violations, violation(mapped, reflect.Value{}, Rule{Name: "notnull"}))
+		}
+	}
+
+	if 0 != len(violations) {
+		return violations
+	}
+
+	return nil
+}
+
+// RegisterNotNull records that `columns` of parameter `o`'s type are NOT
+// NULL, as known from the database's schema, so that validation, see
+// SetValidation, rejects structs writing them as NULL, such as from nil
+// pointers or invalid sql.NullString fields, as it rejects those tagged
+// with the `notnull` option. Columns tagged with the `nullable` option
+// are exempt, such as those the database fills through a trigger. An
+// error is returned if `o` is not a struct or a column is not mapped.
+func (self *Cartographer) RegisterNotNull(o interface{}, columns ...string) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	for _, column := range columns {
+		if nil == self.byColumn[typ][column] {
+			return errors.New(fmt.Sprintf("No field for column %s on %v", column, typ))
+		}
+	}
+
+	if nil == self.notNull[typ] {
+		self.notNull[typ] = make(map[string]bool)
+	}
+
+	for _, column := range columns {
+		self.notNull[typ][column] = true
+	}
+
+	return nil
+}
+
+// isNotNull reports whether field `mapped` of type `typ` may not be
+// written as NULL.
+func (self *Cartographer) isNotNull(typ reflect.Type, mapped *mappedField) bool {
+	if mapped.options.Has("nullable") {
+		return false
+	}
+
+	return mapped.options.Has("notnull") || self.notNull[typ][mapped.column]
+}
+
+// validateHydrated validates the hydrated struct `o` with Validate should
+// validation be enabled with SetValidation, else with the Validator only.
+func (self *Cartographer) validateHydrated(o interface{}) error {
+	if self.validation {
+		return self.Validate(o)
+	}
+
+	return self.runValidator(o)
+}
+
+// runValidator runs the Validator set with SetValidator against `o`, if any.
+func (self *Cartographer) runValidator(o interface{}) error {
+	if nil == self.validator {
+		return nil
+	}
+
+	return protect(func() error { return self.validator.Validate(o) }, "in Validator for %T", o)
+}
+
+// Validate checks parameter `o`'s fields against the rules declared by
+// their `validate` tags, returning ValidationErrors listing every rule
+// violated, or an error if `o` is not a struct. Should no rule be violated,
+// the error of the Validator set with SetValidator, if any, is returned.
+// Rules scoped to a group are skipped, see ValidateGroup.
+func (self *Cartographer) Validate(o interface{}) error {
+	return self.ValidateGroup(o, "")
+}
+
+// ValidateGroup behaves as Validate, additionally checking the rules scoped
+// to group `group`, such as InsertGroup, which InsertSQL validates structs
+// with, or UpdateGroup, which UpdateSQL validates structs with.
+func (self *Cartographer) ValidateGroup(o interface{}, group string) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	if err = self.checkRules(typ); nil != err {
+		return err
+	}
+
+	var (
+		item       = reflect.Indirect(reflect.ValueOf(o))
+		violations ValidationErrors
+	)
+
+	for _, mapped := range self.fields[typ] {
+		value := fieldByIndex(item, mapped.index, false)
+
+		for _, rule := range mapped.rules {
+			if 0 != len(rule.Group) && group != rule.Group {
+				continue
+			}
+
+			var satisfied bool
+
+			if check, ok := crossFieldRules[rule.Name]; ok {
+				satisfied = check(value, self.siblings(typ, item, rule.Value))
+			} else {
+				satisfied = validationRules[rule.Name](value, rule.Value)
+			}
+
+			if !satisfied {
+				violations = append(violations, violation(mapped, value, rule))
+			}
+		}
+	}
+
+	if 0 != len(self.validations[typ]) {
+		pointer := reflect.New(typ)
+		pointer.Elem().Set(item)
+
+		for _, validation := range self.validations[typ] {
+			if err = protect(func() error { return validation(pointer) }, "in validation for %v", typ); nil != err {
+				violations = append(violations, violationsOf(err)...)
+			}
+		}
+	}
+
+	if 0 != len(violations) {
+		return violations
+	}
+
+	return self.runValidator(o)
+}
+
+// Validation checks a struct, passed as a pointer, against an invariant
+// of its type, such as a date range or a rule spanning several fields.
+// Returning a *FieldError, or ValidationErrors, reports the fields at
+// fault; any other error is reported as a violation of the rule `custom`.
+type Validation func(value reflect.Value) error
+
+// ValidationFor adapts function `fn`, which works with a pointer to the
+// concrete struct `T`, to a Validation, as HookFor adapts hooks.
+func ValidationFor[T any](fn func(*T) error) Validation {
+	return Validation(HookFor(fn))
+}
+
+// RegisterValidation attaches Validation `validation` to parameter `o`'s
+// type, running it within Validate after the rules of `validate` tags, so
+// its violations are reported alongside theirs, or returns an error if `o`
+// is not a struct. Validations run in the order they were registered.
+func (self *Cartographer) RegisterValidation(o interface{}, validation Validation) error {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return err
+	}
+
+	self.validations[typ] = append(self.validations[typ], validation)
+
+	return nil
+}
+
+// violationsOf returns the violations reported by the error `err` of a
+// Validation.
+func violationsOf(err error) ValidationErrors {
+	var (
+		violations ValidationErrors
+		violation  *FieldError
+	)
+
+	if errors.As(err, &violations) {
+		return violations
+	}
+
+	if errors.As(err, &violation) {
+		return ValidationErrors{violation}
+	}
+
+	return ValidationErrors{&FieldError{Rule: "custom", Message: err.Error()}}
+}
+
+// checkSiblings returns an error should cross-field rule `rule` of field
+// `mapped` of type `typ` name no fields, or a field the type does not map.
+func (self *Cartographer) checkSiblings(typ reflect.Type, mapped *mappedField, rule Rule) error {
+	if 0 == len(strings.Fields(rule.Value)) {
+		return errors.New(fmt.Sprintf("Expected field names for validation rule %s of field %s on %v", rule.Name, mapped.name, typ))
+	}
+
+	for _, name := range strings.Fields(rule.Value) {
+		if nil == self.fieldNamed(typ, name) {
+			return errors.New(fmt.Sprintf("No field %s for validation rule %s of field %s on %v", name, rule.Name, mapped.name, typ))
+		}
+	}
+
+	return nil
+}
+
+// siblings returns the values of the fields of struct value `item`, of
+// type `typ`, named by the space separated `names`.
+func (self *Cartographer) siblings(typ reflect.Type, item reflect.Value, names string) (values []reflect.Value) {
+	for _, name := range strings.Fields(names) {
+		values = append(values, fieldByIndex(item, self.fieldNamed(typ, name).index, false))
+	}
+
+	return
+}
+
+// checkRules returns an error should a field of type `typ` declare an
+// unknown validation rule, a sized rule without a numeric parameter, or
+// a cross-field rule naming a field the type does not map. Rules are only
+// checked once validated, or by ValidateMappings, so that types tagged for
+// another validator, such as one set with SetValidator, may still be mapped.
+func (self *Cartographer) checkRules(typ reflect.Type) error {
+	for _, mapped := range self.fields[typ] {
+		for _, rule := range mapped.rules {
+			if _, ok := crossFieldRules[rule.Name]; ok {
+				if err := self.checkSiblings(typ, mapped, rule); nil != err {
+					return err
+				}
+
+				continue
+			}
+
+			if _, ok := validationRules[rule.Name]; !ok {
+				return errors.New(fmt.Sprintf("Unknown validation rule %s for field %s on %v", rule.Name, mapped.name, typ))
+			}
+
+			if _, err := strconv.ParseFloat(rule.Value, 64); sizedRules[rule.Name] && nil != err {
+				return errors.New(fmt.Sprintf("Expected a number for validation rule %s of field %s on %v", rule, mapped.name, typ))
+			}
+		}
+	}
+
+	return nil
+}
+
+// compareSize compares the size of `value`, its length for strings,
+// slices, maps and arrays, else its numeric value, against the number
+// `param` with `compare`. A nil pointer, or a value without a size,
+// satisfies the comparison.
+func compareSize(value reflect.Value, param string, compare func(size, bound float64) bool) bool {
+	if value = indirectValue(value); !value.IsValid() {
+		return true
+	}
+
+	var (
+		bound, _ = strconv.ParseFloat(param, 64)
+		size     float64
+	)
+
+	switch value.Kind() {
+	case reflect.String:
+		size = float64(utf8.RuneCountInString(value.String()))
+	case reflect.Slice, reflect.Map, reflect.Array:
+		size = float64(value.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = value.Float()
+	default:
+		return true
+	}
+
+	return compare(size, bound)
+}
+
+// indirectValue dereferences `value` should it be a pointer, returning
+// an invalid reflect.Value for nil pointers.
+func indirectValue(value reflect.Value) reflect.Value {
+	if value.IsValid() && reflect.Ptr == value.Kind() {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+
+		return value.Elem()
+	}
+
+	return value
+}
@@ -0,0 +1,370 @@
+package cartographer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type signup struct {
+	Id       int     `db:"id,pk"`
+	Email    string  `db:"email" validate:"required,email,max=32"`
+	Name     *string `db:"name" validate:"min=2"`
+	Plan     string  `db:"plan" validate:"oneof=free pro"`
+	Tags     []byte  `db:"tags" validate:"len=0"`
+	Referrer string  `validate:"required"`
+}
+
+type misvalidated struct {
+	Name string `db:"name" validate:"shiny"`
+}
+
+type unsized struct {
+	Name string `db:"name" validate:"max=many"`
+}
+
+func TestValidate(t *testing.T) {
+	c := Initialize("db")
+	name := "ada"
+
+	if err := c.Validate(signup{Email: "ada@example.com", Name: &name, Plan: "pro"}); nil != err {
+		t.Errorf("Basic Validate test expected a valid signup, received %v", err)
+	}
+
+	if err := c.Validate(&signup{Email: "ada@example.com", Plan: "free"}); nil != err {
+		t.Errorf("Basic Validate test expected a nil pointer to satisfy min, received %v", err)
+	}
+
+	short := "a"
+	err := c.Validate(signup{Email: "Ada <ada@example.com>", Name: &short, Plan: "gold", Tags: []byte("x")})
+
+	var violations ValidationErrors
+
+	if !errors.As(err, &violations) {
+		t.Fatalf("Basic Validate test expected ValidationErrors, received %v", err)
+	}
+
+	var rules []string
+
+	for _, violation := range violations {
+		rules = append(rules, violation.Column+" "+violation.Rule)
+	}
+
+	if expected := []string{"email email", "name min=2", "plan oneof=free pro", "tags len=0"}; !reflect.DeepEqual(expected, rules) {
+		t.Errorf("Basic Validate test expected violations %v, received %v", expected, rules)
+	}
+
+	if err = c.Validate(signup{Plan: "free"}); nil == err || "Field Email (column email) failed rule required: is required" != err.Error() {
+		t.Errorf("Basic Validate test returned unexpected error: %v", err)
+	}
+}
+
+func TestValidateUnknownRule(t *testing.T) {
+	c := Initialize("db")
+
+	if _, err := c.Map(&fakeRows{columns: []string{"name"}, values: [][]interface{}{{"a"}}}, misvalidated{}); nil != err {
+		t.Errorf("Basic TestValidateUnknownRule test returned an unexpected error mapping without validation: %v", err)
+	}
+
+	if err := c.Validate(misvalidated{}); nil == err {
+		t.Error("Basic Validate test expected an error validating an unknown validation rule")
+	}
+
+	if err := c.ValidateMappings(misvalidated{}); nil == err || !strings.Contains(err.Error(), "Unknown validation rule shiny") {
+		t.Errorf("Basic Validate test expected ValidateMappings to report the unknown validation rule, received %v", err)
+	}
+
+	c.SetValidation(true)
+
+	if _, err := c.Map(&fakeRows{columns: []string{"name"}, values: [][]interface{}{{"a"}}}, misvalidated{}); nil == err {
+		t.Error("Basic Validate test expected an error mapping an unknown validation rule with validation enabled")
+	}
+
+	if err := c.Validate(unsized{}); nil == err {
+		t.Error("Basic Validate test expected an error for a max rule without a number")
+	}
+
+	if err := c.Validate(1); nil == err {
+		t.Error("Basic Validate test expected an error validating a non-struct")
+	}
+}
+
+func TestSetValidator(t *testing.T) {
+	var (
+		expected  = errors.New("invalid")
+		validated []interface{}
+	)
+
+	c := New(WithValidator(ValidatorFunc(func(o interface{}) error {
+		validated = append(validated, o)
+
+		if "bad" == o.(*invoice).Customer {
+			return expected
+		}
+
+		return nil
+	})))
+
+	rows := &fakeRows{columns: []string{"id", "customer"}, values: [][]interface{}{{1, "ada"}, {2, "bad"}}}
+
+	if _, err := c.Map(rows, invoice{}); !errors.Is(err, expected) || 2 != len(validated) {
+		t.Errorf("Basic SetValidator test expected the Validator's error, received %v after %d validations", err, len(validated))
+	}
+
+	if _, err := c.ColumnValueMapFor(&invoice{Customer: "bad"}); expected != err {
+		t.Errorf("Basic SetValidator test expected the Validator's error writing, received %v", err)
+	}
+
+	if _, err := c.NonZeroColumnValueMapFor(&invoice{Customer: "bad"}); nil != err {
+		t.Errorf("Basic SetValidator test expected partial value maps not to be validated, received %v", err)
+	}
+
+	if err := c.Validate(&invoice{Customer: "bad"}); expected != err {
+		t.Errorf("Basic SetValidator test expected Validate to run the Validator, received %v", err)
+	}
+}
+
+func TestSetValidation(t *testing.T) {
+	c := New(WithValidation(true))
+
+	rows := func() *fakeRows {
+		return &fakeRows{
+			columns: []string{"id", "email", "plan"},
+			values:  [][]interface{}{{1, "ada@example.com", "free"}, {2, "nope", "free"}, {3, "bob@example.com", "gold"}, {4, "cy@example.com", "pro"}},
+		}
+	}
+
+	results, err := c.Map(rows(), signup{})
+
+	var failed *RowError
+
+	if !errors.As(err, &failed) || 1 != failed.Row || 1 != len(results) {
+		t.Fatalf("Basic SetValidation test expected mapping to stop at row 1, received %v, %v", results, err)
+	}
+
+	results, err = c.MapWith(rows(), signup{}, WithContinueOnInvalid())
+
+	var invalid RowErrors
+
+	if !errors.As(err, &invalid) || 2 != len(invalid) || 2 != len(results) {
+		t.Fatalf("Basic SetValidation test expected two invalid rows, received %v, %v", results, err)
+	}
+
+	var violations ValidationErrors
+
+	if 2 != invalid[1].Row || !errors.As(invalid[1], &violations) || "Plan" != violations[0].Field || "oneof=free pro" != violations[0].Rule {
+		t.Errorf("Basic SetValidation test returned unexpected row error: %v", invalid[1])
+	}
+
+	if 4 != results[1].(*signup).Id {
+		t.Errorf("Basic SetValidation test expected the last row to be mapped, received %v", results[1])
+	}
+}
+
+type patron struct {
+	Id    int     `db:"id,pk"`
+	Email *string `db:"email,notnull" validate:"email"`
+	Phone string  `db:"phone" validate:"required"`
+}
+
+func TestValidateOnWrite(t *testing.T) {
+	c := New(WithValidation(true))
+
+	_, _, err := c.InsertSQL(patron{})
+
+	var violations ValidationErrors
+
+	if !errors.As(err, &violations) || 2 != len(violations) || "required" != violations[0].Rule || "notnull" != violations[1].Rule {
+		t.Errorf("Basic write validation test expected required and notnull violations, received %v", err)
+	}
+
+	email := "ada@example.com"
+
+	if _, _, err = c.InsertSQL(patron{Email: &email, Phone: "555"}); nil != err {
+		t.Errorf("Basic write validation test expected a valid insert, received %v", err)
+	}
+
+	if _, err = New().ColumnValueMapFor(patron{}); nil != err {
+		t.Errorf("Basic write validation test expected no validation without SetValidation, received %v", err)
+	}
+}
+
+type booking struct {
+	Id    int `db:"id,pk"`
+	Start int `db:"start"`
+	End   int `db:"end" validate:"min=1"`
+}
+
+func TestRegisterValidation(t *testing.T) {
+	c := New()
+
+	c.RegisterValidation(booking{}, ValidationFor(func(item *booking) error {
+		if item.End < item.Start {
+			return &FieldError{Field: "End", Column: "end", Rule: "after_start", Message: "must not precede start"}
+		}
+
+		return nil
+	}))
+
+	c.RegisterValidation(booking{}, ValidationFor(func(item *booking) error {
+		if 0 == item.Id {
+			return errors.New("unsaved")
+		}
+
+		return nil
+	}))
+
+	err := c.Validate(booking{Start: 5, End: 0})
+
+	var violations ValidationErrors
+
+	if !errors.As(err, &violations) || 3 != len(violations) {
+		t.Fatalf("Basic RegisterValidation test expected three violations, received %v", err)
+	}
+
+	if "after_start" != violations[1].Rule || "custom" != violations[2].Rule || "Failed rule custom: unsaved" != violations[2].Error() {
+		t.Errorf("Basic RegisterValidation test returned unexpected violations: %v", violations)
+	}
+
+	if err = c.Validate(&booking{Id: 1, Start: 1, End: 2}); nil != err {
+		t.Errorf("Basic RegisterValidation test expected a valid booking, received %v", err)
+	}
+
+	if err = c.RegisterValidation(1, nil); nil == err {
+		t.Error("Basic RegisterValidation test expected an error registering a validation for a non-struct")
+	}
+}
+
+type ticket struct {
+	Id    int    `db:"id,pk" validate:"required@update,empty@insert"`
+	Title string `db:"title" validate:"max=5@update"`
+}
+
+func TestValidationGroups(t *testing.T) {
+	c := New(WithValidation(true))
+
+	if _, _, err := c.InsertSQL(ticket{Title: "a long title"}); nil != err {
+		t.Errorf("Basic ValidateGroup test expected a valid insert, received %v", err)
+	}
+
+	if _, _, err := c.InsertSQL(ticket{Id: 1}); nil == err || "Field Id (column id) failed rule empty@insert: must be empty" != err.Error() {
+		t.Errorf("Basic ValidateGroup test expected the id to be forbidden on insert, received %v", err)
+	}
+
+	if _, _, err := c.UpdateSQL(ticket{Title: "a long title"}); nil == err || 2 != len(err.(ValidationErrors)) {
+		t.Errorf("Basic ValidateGroup test expected the id and title to be rejected on update, received %v", err)
+	}
+
+	if _, _, err := c.UpsertSQL(ticket{Id: 1}); nil != err {
+		t.Errorf("Basic ValidateGroup test expected grouped rules not to apply to upserts, received %v", err)
+	}
+
+	if _, _, err := c.DeleteSQL(ticket{Id: 1, Title: "a long title"}); nil != err {
+		t.Errorf("Basic ValidateGroup test expected deletes not to be validated, received %v", err)
+	}
+
+	if err := c.Validate(ticket{Id: 1}); nil != err {
+		t.Errorf("Basic ValidateGroup test expected grouped rules to be skipped by Validate, received %v", err)
+	}
+
+	if mapping, _ := c.MappingFor(ticket{}); "update" != mapping.Fields[0].Rules[0].Group || "5" != mapping.Fields[1].Rules[0].Value {
+		t.Errorf("Basic ValidateGroup test returned unexpected rules: %v", mapping.Fields)
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	err := New().Validate(signup{Email: "nope", Plan: "gold"})
+
+	violations := err.(ValidationErrors)
+
+	if "nope" != violations[0].Value || "must be an email address" != violations[0].Message {
+		t.Errorf("Basic FieldError test returned unexpected violation: %+v", violations[0])
+	}
+
+	encoded, _ := json.Marshal(violations[1])
+
+	if `{"field":"Plan","column":"plan","rule":"oneof=free pro","value":"gold","message":"must be one of free, pro"}` != string(encoded) {
+		t.Errorf("Basic FieldError test returned unexpected JSON: %s", encoded)
+	}
+
+	if messages := violations.Messages(); "must be one of free, pro" != messages["plan"][0] {
+		t.Errorf("Basic FieldError test returned unexpected messages: %v", messages)
+	}
+}
+
+type biography struct {
+	Id      int            `db:"id,pk"`
+	Bio     sql.NullString `db:"bio"`
+	Website *string        `db:"website"`
+	Avatar  *string        `db:"avatar,nullable"`
+}
+
+func TestRegisterNotNull(t *testing.T) {
+	c := New(WithValidation(true))
+
+	if _, _, err := c.InsertSQL(biography{}); nil != err {
+		t.Fatalf("Basic RegisterNotNull test expected NULL to be allowed before registering, received %v", err)
+	}
+
+	if err := c.RegisterNotNull(biography{}, "bio", "website", "avatar"); nil != err {
+		t.Fatalf("Basic RegisterNotNull test returned an unexpected error: %v", err)
+	}
+
+	_, _, err := c.InsertSQL(biography{})
+
+	if messages := err.(ValidationErrors).Messages(); 2 != len(messages) || "must not be null" != messages["bio"][0] || 1 != len(messages["website"]) {
+		t.Errorf("Basic RegisterNotNull test expected bio and website to be rejected, received %v", err)
+	}
+
+	site := "example.com"
+
+	if _, _, err = c.InsertSQL(biography{Bio: sql.NullString{String: "", Valid: true}, Website: &site}); nil != err {
+		t.Errorf("Basic RegisterNotNull test expected a valid insert, received %v", err)
+	}
+
+	if err = c.RegisterNotNull(biography{}, "unknown"); nil == err {
+		t.Error("Basic RegisterNotNull test expected an error registering an unmapped column")
+	}
+}
+
+type shipping struct {
+	Street  string  `db:"street" validate:"required_with=City Zip"`
+	City    string  `db:"city"`
+	Zip     *string `db:"zip"`
+	Pickup  bool    `db:"pickup" validate:"excluded_with=Street"`
+	Courier string  `db:"courier" validate:"required_with=Pickup@insert"`
+}
+
+type unsiblinged struct {
+	Street string `db:"street" validate:"required_with=Town"`
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	c := New()
+	zip := "12345"
+
+	if err := c.Validate(shipping{}); nil != err {
+		t.Errorf("Basic cross-field rule test expected an empty address to be valid, received %v", err)
+	}
+
+	err := c.Validate(shipping{Zip: &zip})
+
+	if nil == err || "Field Street (column street) failed rule required_with=City Zip: is required with City, Zip" != err.Error() {
+		t.Errorf("Basic cross-field rule test expected the street to be required with a zip, received %v", err)
+	}
+
+	if err = c.Validate(shipping{Street: "Main", Pickup: true}); nil == err || "excluded_with=Street" != err.(ValidationErrors)[0].Rule {
+		t.Errorf("Basic cross-field rule test expected pickup to be excluded with a street, received %v", err)
+	}
+
+	if err = c.ValidateGroup(shipping{Pickup: true}, InsertGroup); nil == err || "courier" != err.(ValidationErrors)[0].Column {
+		t.Errorf("Basic cross-field rule test expected the courier to be required with pickup on insert, received %v", err)
+	}
+
+	if err = c.Validate(unsiblinged{}); nil == err {
+		t.Error("Basic cross-field rule test expected an error for a rule naming an unmapped field")
+	}
+}
@@ -0,0 +1,189 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Converter converts a field's value to the value written to its column.
+type Converter func(value interface{}) (interface{}, error)
+
+// RegisterWriteConverter registers Converter `converter` for converting
+// field values of the same Go type as `example` when producing column
+// value maps, such as with ColumnValueMapFor.
+func (self *Cartographer) RegisterWriteConverter(example interface{}, converter Converter) {
+	self.writeConverters[reflect.TypeOf(example)] = converter
+}
+
+// ColumnValueMapFor returns a map of parameter `o`'s columns to their
+// fields' values, or an error if `o` is not a struct. Values are taken as
+// FieldValueMapFor takes them, then converted by the Converter registered
+// for their type with RegisterWriteConverter, else by their Value method
+// should they implement driver.Valuer, such as sql.NullString. Values of
+// fields stored as JSON, see SetJSONCollections, are first marshaled to JSON.
+// Should validation be enabled with SetValidation, `o` is validated before
+// the map is returned, else by the Validator set with SetValidator, if any.
+func (self *Cartographer) ColumnValueMapFor(o interface{}) (values map[interface{}]interface{}, err error) {
+	return self.writeValues(o, "")
+}
+
+// writeValues returns the column values of parameter `o` as
+// ColumnValueMapFor does, sanitizing and then validating `o` with the
+// rules of group `group`.
+func (self *Cartographer) writeValues(o interface{}, group string) (values map[interface{}]interface{}, err error) {
+	defer self.hold()()
+
+	if o, err = self.sanitize(o); nil != err {
+		return
+	}
+
+	if values, err = self.columnValues(o, false); nil != err {
+		return
+	}
+
+	if err = self.validateWrite(o, reflect.Indirect(reflect.ValueOf(o)).Type(), values, group); nil != err {
+		return nil, err
+	}
+
+	return
+}
+
+// NonZeroColumnValueMapFor behaves as ColumnValueMapFor, omitting fields
+// holding their zero value, making the map suitable for partial inserts
+// and PATCH style updates.
+func (self *Cartographer) NonZeroColumnValueMapFor(o interface{}) (values map[interface{}]interface{}, err error) {
+	return self.columnValues(o, true)
+}
+
+// columnValues returns a map of parameter `o`'s columns to their fields'
+// converted values, omitting zero valued fields if `omitZero` is true.
+func (self *Cartographer) columnValues(o interface{}, omitZero bool) (values map[interface{}]interface{}, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	fields, err := self.fieldValues(typ, reflect.Indirect(reflect.ValueOf(o)), true)
+
+	if nil != err {
+		return
+	}
+
+	values = make(map[interface{}]interface{})
+
+	for field, value := range fields {
+		if omitZero && isZero(value) {
+			continue
+		}
+
+		column := self.fieldsToColumns[typ][field]
+
+		if mapped := self.fieldNamed(typ, field); nil != mapped && self.isJSON(mapped) && nil != value {
+			if value, err = json.Marshal(value); nil != err {
+				return nil, errors.New(fmt.Sprintf("%s for column %s", err.Error(), column))
+			}
+		}
+
+		if values[column], err = self.convert(value); nil != err {
+			return nil, errors.New(fmt.Sprintf("%s for column %s", err.Error(), column))
+		}
+	}
+
+	self.discriminate(typ, values)
+
+	return
+}
+
+// convert converts `value` with the Converter registered for its type,
+// else with its Value method should it implement driver.Valuer.
+func (self *Cartographer) convert(value interface{}) (interface{}, error) {
+	if converter, ok := self.writeConverters[reflect.TypeOf(value)]; ok {
+		return converter(value)
+	}
+
+	return driverValue(value)
+}
+
+// driverValue returns the result of calling Value on `value`, or on a
+// pointer to a copy of it, should either implement driver.Valuer, else
+// `value` itself. Nil pointers are returned as nil.
+func driverValue(value interface{}) (interface{}, error) {
+	reflected := reflect.ValueOf(value)
+
+	if !reflected.IsValid() {
+		return value, nil
+	}
+
+	if reflect.Ptr == reflected.Kind() && reflected.IsNil() {
+		return nil, nil
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
+	if reflect.Ptr != reflected.Kind() {
+		pointer := reflect.New(reflected.Type())
+		pointer.Elem().Set(reflected)
+
+		if valuer, ok := pointer.Interface().(driver.Valuer); ok {
+			return valuer.Value()
+		}
+	}
+
+	return value, nil
+}
+
+// SetJSONCollections enables or disables storing slice and map fields,
+// other than []byte, as JSON, as fields tagged with the `json` option,
+// e.g. `db:"settings,json"`, always are. Such fields are marshaled to
+// JSON when producing column value maps and unmarshaled from the JSON
+// scanned by Map and Sync.
+func (self *Cartographer) SetJSONCollections(enabled bool) {
+	self.jsonCollections = enabled
+}
+
+// isJSON reports whether the field `mapped` is stored as JSON.
+func (self *Cartographer) isJSON(mapped *mappedField) bool {
+	if mapped.options.Has("json") {
+		return true
+	}
+
+	if !self.jsonCollections {
+		return false
+	}
+
+	switch mapped.typ.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		return reflect.Uint8 != mapped.typ.Elem().Kind()
+	}
+
+	return false
+}
+
+// setJSONFieldValue unmarshals the JSON `value`, scanned as a string
+// or []byte, into field `field`.
+func setJSONFieldValue(field reflect.Value, value interface{}) error {
+	if nil == value {
+		return nil
+	}
+
+	if !field.CanSet() {
+		return errors.New("Failed to set field")
+	}
+
+	switch value.(type) {
+	case []byte:
+		return json.Unmarshal(value.([]byte), field.Addr().Interface())
+	case string:
+		return json.Unmarshal([]byte(value.(string)), field.Addr().Interface())
+	}
+
+	return errors.New(fmt.Sprintf("Expected JSON to be scanned, received %T", value))
+}
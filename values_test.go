@@ -0,0 +1,104 @@
+package cartographer
+
+import (
+	"database/sql"
+	"testing"
+)
+
+type profile struct {
+	Id       int    `db:"id"`
+	Nickname string `db:"nickname,omitempty"`
+	Bio      string `db:"bio"`
+}
+
+func TestNonZeroColumnValueMapFor(t *testing.T) {
+	values, err := instance.NonZeroColumnValueMapFor(profile{Id: 1})
+
+	if nil != err || 1 != len(values) || 1 != values["id"] {
+		t.Errorf("Basic NonZeroColumnValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+}
+
+func TestFieldValueMapForOmitEmpty(t *testing.T) {
+	values, err := instance.FieldValueMapFor(profile{Id: 1})
+
+	if _, ok := values["Nickname"]; nil != err || ok || 2 != len(values) {
+		t.Errorf("Omitempty FieldValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+
+	modified, err := instance.ModifiedColumnsValuesMapFor(values, profile{Id: 1})
+
+	if nil != err || 0 != len(modified) {
+		t.Errorf("Omitempty ModifiedColumnsValuesMapFor test returned an unexpected results: %v, %v", modified, err)
+	}
+}
+
+type cents int
+
+type price struct {
+	Id     int   `db:"id"`
+	Amount cents `db:"amount"`
+}
+
+func TestColumnValueMapFor(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	cartographer.RegisterWriteConverter(cents(0), func(value interface{}) (interface{}, error) {
+		return float64(value.(cents)) / 100, nil
+	})
+
+	values, err := cartographer.ColumnValueMapFor(price{1, 250})
+
+	if nil != err || 2 != len(values) || 1 != values["id"] || 2.5 != values["amount"] {
+		t.Errorf("Basic ColumnValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+}
+
+type contact struct {
+	Id    int            `db:"id"`
+	Phone sql.NullString `db:"phone"`
+	Email *string        `db:"email"`
+}
+
+func TestColumnValueMapForValuer(t *testing.T) {
+	values, err := instance.ColumnValueMapFor(contact{1, sql.NullString{String: "555-0100", Valid: true}, nil})
+
+	if nil != err || "555-0100" != values["phone"] || nil != values["email"] {
+		t.Errorf("Valuer ColumnValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+
+	values, err = instance.ColumnValueMapFor(contact{})
+
+	if nil != err || nil != values["phone"] {
+		t.Errorf("Valuer ColumnValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+}
+
+type preferences struct {
+	Id       int               `db:"id"`
+	Settings map[string]string `db:"settings,json"`
+	Tags     []string          `db:"tags"`
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	var cartographer = Initialize("db")
+
+	cartographer.SetJSONCollections(true)
+
+	values, err := cartographer.ColumnValueMapFor(preferences{1, map[string]string{"theme": "dark"}, []string{"a"}})
+
+	if nil != err || `{"theme":"dark"}` != string(values["settings"].([]byte)) || `["a"]` != string(values["tags"].([]byte)) {
+		t.Fatalf("JSON ColumnValueMapFor test returned an unexpected results: %v, %v", values, err)
+	}
+
+	rows := &fakeRows{columns: []string{"id", "settings", "tags"}, values: [][]interface{}{{1, values["settings"], values["tags"]}}}
+	results, err := cartographer.Map(rows, preferences{})
+
+	if nil != err || 1 != len(results) {
+		t.Fatalf("JSON Map test returned an unexpected results: %v, %v", results, err)
+	}
+
+	if item := results[0].(*preferences); "dark" != item.Settings["theme"] || 1 != len(item.Tags) || "a" != item.Tags[0] {
+		t.Errorf("JSON Map test returned an unexpected item: %v", item)
+	}
+}
@@ -0,0 +1,55 @@
+package cartographer
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// ErrStaleRow is returned when updating a struct whose `version` column no
+// longer matches the row's, the row having been updated since the struct
+// was read.
+type ErrStaleRow struct {
+	Type    reflect.Type // Type of the struct updated.
+	Version interface{}  // Version of the struct updated.
+}
+
+// Error describes the stale row.
+func (self *ErrStaleRow) Error() string {
+	return fmt.Sprintf("Stale %v at version %v, the row has since been updated or deleted", self.Type, self.Version)
+}
+
+// checkVersion returns an *ErrStaleRow for the struct pointed to by `o`
+// should `err` be sql.ErrNoRows and `o` declare a `version` column, else
+// `err`, incrementing the version of `o` should `err` be nil.
+func (self *Cartographer) checkVersion(o interface{}, err error) error {
+	typ, discoverErr := self.DiscoverType(o) // Discovered again should it have been evicted since.
+
+	if nil != discoverErr {
+		return discoverErr
+	}
+
+	item := reflect.ValueOf(o).Elem()
+	version := self.versionField(typ)
+
+	if nil == version {
+		return err
+	}
+
+	field := fieldByIndex(item, version.index, true)
+
+	if sql.ErrNoRows == err {
+		return &ErrStaleRow{item.Type(), field.Interface()}
+	} else if nil != err {
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(field.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(field.Uint() + 1)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package cartographer
+
+import (
+	"errors"
+	"testing"
+)
+
+type document struct {
+	Id      int    `db:"id,pk"`
+	Body    string `db:"body"`
+	Version int    `db:"version,version"`
+}
+
+func TestUpdateSQLVersion(t *testing.T) {
+	query, args, err := Initialize("db").UpdateSQL(document{1, "text", 3})
+
+	if nil != err {
+		t.Fatalf("Basic UpdateSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "UPDATE document SET body = ?, version = version + 1 WHERE id = ? AND version = ?"; expected != query {
+		t.Errorf("Basic UpdateSQL test expected %q, received %q", expected, query)
+	}
+
+	if 3 != len(args) || 3 != args[2] {
+		t.Errorf("Basic UpdateSQL test returned unexpected arguments: %v", args)
+	}
+}
+
+func TestRepositoryUpdateVersion(t *testing.T) {
+	const query = "UPDATE document SET body = ?, version = version + 1 WHERE id = ? AND version = ?"
+
+	item := &document{1, "text", 3}
+	repository := RepositoryFor[document](Initialize("db"), openFake(map[string]fakeResult{query: {affected: 1}}))
+
+	if err := repository.Update(item); nil != err {
+		t.Fatalf("Basic Repository Update test returned an unexpected error: %v", err)
+	}
+
+	if 4 != item.Version {
+		t.Errorf("Basic Repository Update test expected version 4, received %d", item.Version)
+	}
+
+	repository = RepositoryFor[document](Initialize("db"), openFake(map[string]fakeResult{query: {affected: 0}}))
+
+	var stale *ErrStaleRow
+
+	if err := repository.Update(item); !errors.As(err, &stale) || 4 != stale.Version {
+		t.Errorf("Basic Repository Update test expected a stale row at version 4, received %v", err)
+	}
+
+	if 4 != item.Version {
+		t.Errorf("Basic Repository Update test expected version to remain 4, received %d", item.Version)
+	}
+}
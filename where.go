@@ -0,0 +1,100 @@
+package cartographer
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Condition is a composable WHERE condition, built with Where and
+// WhereStruct and combined with And and Or, rendered as parameterized
+// SQL against a type's column mappings by WhereSQL.
+type Condition struct {
+	equal    map[string]interface{} // Columns and the values they must equal, for conditions built with Where.
+	filter   interface{}            // Struct whose non-zero fields must be matched, for conditions built with WhereStruct.
+	operator string                 // Operator joining the children of a combined condition, AND or OR.
+	children []*Condition           // Conditions combined by the operator.
+}
+
+// Where returns a Condition matching the columns of `equal` to their
+// values. Nil values match NULL, slices other than []byte match any of
+// their elements with IN.
+func Where(equal map[string]interface{}) *Condition {
+	return &Condition{equal: equal}
+}
+
+// WhereStruct returns a Condition matching the columns of struct `filter`'s
+// non-zero fields to their values, as values are taken by
+// NonZeroColumnValueMapFor, for query-by-example filtering.
+func WhereStruct(filter interface{}) *Condition {
+	return &Condition{filter: filter}
+}
+
+// And returns a Condition matching rows matched by the Condition and every one of `others`.
+func (self *Condition) And(others ...*Condition) *Condition {
+	return &Condition{operator: "AND", children: append([]*Condition{self}, others...)}
+}
+
+// Or returns a Condition matching rows matched by the Condition or any of `others`.
+func (self *Condition) Or(others ...*Condition) *Condition {
+	return &Condition{operator: "OR", children: append([]*Condition{self}, others...)}
+}
+
+// WhereSQL renders `condition` against parameter `o`'s column mappings as
+// a parameterized SQL fragment suitable for following WHERE, along with
+// `args` followed by its arguments, numbering placeholders after `args`.
+// An empty fragment is returned for a nil or empty condition. An error is
+// returned should `o` or a WhereStruct filter not be a struct, or a Where
+// column not be mapped by `o`.
+func (self *Cartographer) WhereSQL(o interface{}, condition *Condition, args []interface{}) (where string, bound []interface{}, err error) {
+	typ, err := self.DiscoverType(o)
+
+	if nil != err {
+		return
+	}
+
+	return self.renderCondition(typ, condition, args)
+}
+
+// renderCondition renders `condition` against type `typ`, see WhereSQL.
+func (self *Cartographer) renderCondition(typ reflect.Type, condition *Condition, args []interface{}) (where string, bound []interface{}, err error) {
+	bound = args
+
+	switch {
+	case nil == condition:
+		return
+	case nil != condition.filter:
+		var values map[interface{}]interface{}
+
+		if values, err = self.NonZeroColumnValueMapFor(condition.filter); nil != err {
+			return
+		}
+
+		var equal = make(map[string]interface{})
+
+		for column, value := range values {
+			equal[column.(string)] = value
+		}
+
+		return self.whereEqual(indirectType(reflect.TypeOf(condition.filter)), equal, args)
+	case 0 != len(condition.operator):
+		var fragments []string
+
+		for _, child := range condition.children {
+			var fragment string
+
+			if fragment, bound, err = self.renderCondition(typ, child, bound); nil != err {
+				return
+			}
+
+			if 0 != len(fragment) {
+				fragments = append(fragments, "("+fragment+")")
+			}
+		}
+
+		where = strings.Join(fragments, " "+condition.operator+" ")
+
+		return
+	}
+
+	return self.whereEqual(typ, condition.equal, args)
+}
@@ -0,0 +1,64 @@
+package cartographer
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestWhereSQL(t *testing.T) {
+	c := Initialize("db")
+	c.SetPlaceholder(Dollar)
+
+	condition := Where(map[string]interface{}{"customer": []string{"ada", "bob"}}).
+		And(WhereStruct(invoice{Total: 10}).Or(Where(map[string]interface{}{"total": nil})))
+
+	where, args, err := c.WhereSQL(invoice{}, condition, []interface{}{"first"})
+
+	if nil != err {
+		t.Fatalf("Basic WhereSQL test returned an unexpected error: %v", err)
+	}
+
+	if expected := "(customer IN ($2, $3)) AND ((total = $4) OR (total IS NULL))"; expected != where {
+		t.Errorf("Basic WhereSQL test expected %q, received %q", expected, where)
+	}
+
+	if !reflect.DeepEqual([]interface{}{"first", "ada", "bob", 10}, args) {
+		t.Errorf("Basic WhereSQL test returned unexpected arguments: %v", args)
+	}
+}
+
+func TestWhereSQLEmpty(t *testing.T) {
+	c := Initialize("db")
+
+	if where, _, err := c.WhereSQL(invoice{}, nil, nil); nil != err || "" != where {
+		t.Errorf("Basic WhereSQL test expected an empty condition, received %q, %v", where, err)
+	}
+
+	if where, _, err := c.WhereSQL(invoice{}, Where(map[string]interface{}{"id": []int{}}), nil); nil != err || "1 = 0" != where {
+		t.Errorf("Basic WhereSQL test expected a false condition, received %q, %v", where, err)
+	}
+
+	if _, _, err := c.WhereSQL(invoice{}, Where(map[string]interface{}{"missing": 1}), nil); nil == err {
+		t.Error("Basic WhereSQL test expected an error given an unmapped column")
+	}
+}
+
+func TestRepositoryFindWhere(t *testing.T) {
+	db := openFake(map[string]fakeResult{
+		"SELECT id, customer, total FROM invoice WHERE customer = ?": {
+			columns: invoiceColumns,
+			rows:    [][]driver.Value{{int64(1), "ada", int64(10)}},
+		},
+	})
+
+	items, err := RepositoryFor[invoice](Initialize("db"), db).FindWhere(WhereStruct(invoice{Customer: "ada"}))
+
+	if nil != err {
+		t.Fatalf("Basic Repository FindWhere test returned an unexpected error: %v", err)
+	}
+
+	if 1 != len(items) || 10 != items[0].Total {
+		t.Errorf("Basic Repository FindWhere test returned unexpected invoices: %v", items)
+	}
+}